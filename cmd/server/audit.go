@@ -0,0 +1,87 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/audit"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// commandAudit queries the audit log and exits
+const commandAudit = "audit"
+
+// auditCommand prints the audit records matching the flags in args as JSON lines,
+// newest first. It returns the process exit code.
+func auditCommand(w io.Writer, args []string) int {
+	fs := flag.NewFlagSet(commandAudit, flag.ContinueOnError)
+	tool := fs.String("tool", "", "only calls of this tool name")
+	session := fs.String("session", "", "only calls from this MCP session ID")
+	outcome := fs.String("outcome", "", "only calls with this outcome: success, error, denied or not_executed")
+	objectID := fs.String("object-id", "", "only calls involving this Tazapay object ID")
+	since := fs.String("since", "", "only calls after this time, as a duration before now (e.g. 24h) or an RFC 3339 time")
+	limit := fs.Int("limit", audit.DefaultLimit, "maximum number of records to print")
+	verify := fs.Bool("verify", false, "verify the hash chain of the whole log instead of printing records")
+
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	if err := utils.ReadConfigFile(slog.New(slog.NewTextHandler(io.Discard, nil))); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to read config:", err)
+		return 1
+	}
+
+	path := audit.Path()
+
+	if *verify {
+		key, err := audit.Key(path)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "failed to load audit log key:", err)
+			return 1
+		}
+
+		count, err := audit.Verify(path, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%s: verification failed after %d records: %v\n", path, count, err)
+			return 1
+		}
+
+		fmt.Fprintf(w, "%s: hash chain verified, %d records\n", path, count)
+
+		return 0
+	}
+
+	from, err := audit.ParseSince(*since, time.Now())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 2
+	}
+
+	records, err := audit.Query(path, audit.Filter{
+		Since:    from,
+		Tool:     *tool,
+		Session:  *session,
+		Outcome:  *outcome,
+		ObjectID: *objectID,
+		Limit:    *limit,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	enc := json.NewEncoder(w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return 1
+		}
+	}
+
+	return 0
+}
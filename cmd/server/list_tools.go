@@ -0,0 +1,73 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/version"
+	tools "github.com/tazapay/tazapay-mcp-server/tools/register"
+)
+
+// commandListTools prints the effective tool set and exits
+const commandListTools = "list-tools"
+
+// usage prints the command line help
+func usage() {
+	out := flag.CommandLine.Output()
+	fmt.Fprintf(out, "Usage: %s [flags] [%s | %s [audit flags]]\n\n", os.Args[0], commandListTools, commandAudit)
+	fmt.Fprintf(out, "Commands:\n  %s\tprint the tools that would be registered with the current configuration\n",
+		commandListTools)
+	fmt.Fprintf(out, "  %s\tsearch or verify the audit log of tool calls, see %s %s -h\n\n",
+		commandAudit, os.Args[0], commandAudit)
+	fmt.Fprintln(out, "Flags:")
+	flag.PrintDefaults()
+}
+
+// listTools registers the tools on a throwaway server using the current configuration
+// and writes the effective set to w. It returns the process exit code.
+func listTools(w io.Writer) int {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	if err := utils.ReadConfigFile(logger); err != nil {
+		fmt.Fprintln(os.Stderr, "failed to read config:", err)
+		return 1
+	}
+
+	cfg, err := tools.ConfigFromViper()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	registered, err := tools.RegisterTools(server.NewMCPServer("tazapay", version.Version), logger, cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tGROUP\tREAD-ONLY\tDESTRUCTIVE\tMOVES-FUNDS\tDEPRECATED ALIAS")
+
+	for _, tool := range registered {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", tool.Name, tool.Group,
+			strconv.FormatBool(tool.Metadata.ReadOnly),
+			strconv.FormatBool(tool.Metadata.Destructive),
+			strconv.FormatBool(tool.Metadata.MovesFunds),
+			tool.Alias,
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return 1
+	}
+
+	return 0
+}
@@ -2,18 +2,74 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/viper"
 
 	"github.com/tazapay/tazapay-mcp-server/cmd/transport"
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/audit"
+	"github.com/tazapay/tazapay-mcp-server/pkg/batch"
+	"github.com/tazapay/tazapay-mcp-server/pkg/confirm"
+	"github.com/tazapay/tazapay-mcp-server/pkg/dryrun"
 	"github.com/tazapay/tazapay-mcp-server/pkg/log"
+	"github.com/tazapay/tazapay-mcp-server/pkg/metrics"
+	"github.com/tazapay/tazapay-mcp-server/pkg/policy"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/shutdown"
+	"github.com/tazapay/tazapay-mcp-server/pkg/tracing"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/version"
+	"github.com/tazapay/tazapay-mcp-server/pkg/webhook"
+	"github.com/tazapay/tazapay-mcp-server/tools/completion"
 	tools "github.com/tazapay/tazapay-mcp-server/tools/register"
 )
 
+// traceFlushTimeout bounds the export of the remaining spans on exit
+const traceFlushTimeout = 5 * time.Second
+
+// Exit codes of the server
+const (
+	exitOK = 0
+	// exitError reports invalid configuration or a server failure
+	exitError = 1
+	// exitDrainTimeout reports tool calls still running at the shutdown deadline
+	exitDrainTimeout = 3
+)
+
 func main() {
+	readOnly := flag.Bool("read-only", false,
+		"register only read-only tools and refuse non-GET requests to Tazapay (or set TAZAPAY_READ_ONLY=true)")
+	flag.Usage = usage
+	flag.Parse()
+
+	viper.AutomaticEnv()
+
+	if *readOnly {
+		viper.Set(constants.StrTAZAPAYReadOnly, true)
+	}
+
+	switch flag.Arg(0) {
+	case commandListTools:
+		os.Exit(listTools(os.Stdout))
+	case commandAudit:
+		os.Exit(auditCommand(os.Stdout, flag.Args()[1:]))
+	}
+
+	os.Exit(serve())
+}
+
+// serve runs the server until it stops or a shutdown signal is handled, and returns
+// the exit code. It returns instead of exiting so that the audit log, traces and log
+// file are flushed on the way out.
+func serve() int {
 	transportType := os.Getenv("TRANSPORT_TYPE")
 	if transportType == "" {
 		transportType = constants.TransportTypeStreamableHTTP
@@ -26,29 +82,136 @@ func main() {
 	}
 
 	// create logger
-	logger, _, logErr := log.New(logConfig)
+	logger, closeLog, logErr := log.New(logConfig)
 	if logErr != nil {
-		os.Exit(1)
+		return exitError
+	}
+	defer closeLog(context.Background())
+
+	if err := utils.ReadConfigFile(logger); err != nil {
+		return exitError
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background())
+	if err != nil {
+		logger.ErrorContext(context.Background(), "invalid tracing configuration", "error", err)
+		return exitError
+	}
+	defer flushTraces(logger, shutdownTracing)
+
+	toolConfig, err := tools.ConfigFromViper()
+	if err != nil {
+		logger.ErrorContext(context.Background(), "invalid tool configuration", "error", err)
+		return exitError
+	}
+
+	rules, err := policy.RulesFromViper()
+	if err != nil {
+		logger.ErrorContext(context.Background(), "invalid policy configuration", "error", err)
+		return exitError
+	}
+
+	engine, err := policy.NewEngine(rules)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "invalid policy configuration", "error", err)
+		return exitError
+	}
+
+	confirmer, err := confirm.NewFromViper()
+	if err != nil {
+		logger.ErrorContext(context.Background(), "invalid confirmation configuration", "error", err)
+		return exitError
+	}
+
+	auditKey, err := audit.Key(audit.Path())
+	if err != nil {
+		logger.ErrorContext(context.Background(), "failed to load audit log key", "path", audit.Path(), "error", err)
+		return exitError
+	}
+
+	auditLog, err := audit.Open(audit.Path(), auditKey)
+	if err != nil {
+		logger.ErrorContext(context.Background(), "failed to open audit log", "path", audit.Path(), "error", err)
+		return exitError
+	}
+	defer auditLog.Close()
+
+	if err := auditLog.Broken(); err != nil {
+		logger.WarnContext(context.Background(), "audit log hash chain is broken, new records are chained to the last one",
+			"path", audit.Path(), "error", err)
 	}
 
 	//create server and register tools
-	s := server.NewMCPServer("tazapay", "0.1.2")
-	tools.RegisterTools(s, logger)
+	completer := completion.NewProvider(logger)
+	hooks := &server.Hooks{}
+	metrics.AddSessionHooks(hooks)
+	recent.AddSessionHooks(hooks)
+	s := server.NewMCPServer("tazapay", version.Version,
+		server.WithHooks(hooks),
+		server.WithCompletions(),
+		server.WithElicitation(),
+		server.WithLogging(),
+		server.WithPromptCompletionProvider(completer),
+		server.WithResourceCompletionProvider(completer),
+	)
+	dryRun := viper.GetBool(constants.StrTAZAPAYDryRun)
+	drainer := shutdown.NewDrainer()
+	if _, err := tools.RegisterTools(s, logger, toolConfig,
+		drainer.Middleware(),
+		tracing.Middleware(utils.FindObjectIDs),
+		metrics.Middleware(),
+		audit.Middleware(auditLog, logger),
+		policy.Middleware(engine, logger),
+		dryrun.Middleware(dryRun, logger),
+		confirm.Middleware(confirmer, logger),
+		webhook.Default.Middleware(),
+	); err != nil {
+		logger.ErrorContext(context.Background(), "failed to register tools", "error", err)
+		return exitError
+	}
+
+	// batch payout files and results are served as resources
+	batch.Attach(s)
+
+	// prompts walk the model through creating payments, so they are not offered in read-only mode
+	if !toolConfig.ReadOnly {
+		tools.RegisterPrompts(s, logger)
+	}
 
 	// Only keep this high-level log
-	logger.InfoContext(context.Background(), "Tazapay MCP Server started", "Transport type", transportType)
+	logger.InfoContext(context.Background(), "Tazapay MCP Server started", "Transport type", transportType,
+		"read_only", toolConfig.ReadOnly, "policy", engine.Enabled(), "dry_run", dryRun)
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, os.Interrupt)
+	defer stop()
 
 	// based on server type start server and handle accordingly
 	switch transportType {
 	case constants.TransportTypeStdio:
-		if err := transport.HandleStdioServer(s, logger); err != nil {
-			logger.ErrorContext(context.Background(), "server exited with error", "error", err)
-			os.Exit(1)
-		}
+		err = transport.HandleStdioServer(ctx, s, logger, drainer)
 	default:
-		if err := transport.HandleStreamableHTTPServer(s, logger); err != nil {
-			logger.ErrorContext(context.Background(), "server exited with error", "error", err)
-			os.Exit(1)
-		}
+		err = transport.HandleStreamableHTTPServer(ctx, s, logger, drainer)
+	}
+
+	switch {
+	case errors.Is(err, constants.ErrDrainTimeout):
+		return exitDrainTimeout
+	case err != nil:
+		logger.ErrorContext(context.Background(), "server exited with error", "error", err)
+		return exitError
+	}
+
+	logger.InfoContext(context.Background(), "Tazapay MCP Server stopped")
+
+	return exitOK
+}
+
+// flushTraces exports the spans still buffered before the process exits
+func flushTraces(logger *slog.Logger, shutdown func(context.Context) error) {
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+
+	if err := shutdown(ctx); err != nil {
+		logger.ErrorContext(ctx, "failed to flush traces", "error", err)
 	}
 }
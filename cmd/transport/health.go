@@ -0,0 +1,42 @@
+package transport
+
+import (
+	"github.com/tazapay/tazapay-mcp-server/pkg/confirm"
+	"github.com/tazapay/tazapay-mcp-server/pkg/health"
+	"github.com/tazapay/tazapay-mcp-server/pkg/policy"
+	"github.com/tazapay/tazapay-mcp-server/pkg/tlsconfig"
+	tools "github.com/tazapay/tazapay-mcp-server/tools/register"
+)
+
+// configChecks validates on readiness the settings checked at startup, so a
+// broken configuration file is reported before the next restart fails
+func configChecks() []health.Check {
+	return []health.Check{
+		func() error {
+			_, err := tools.ConfigFromViper()
+			return err
+		},
+		func() error {
+			rules, err := policy.RulesFromViper()
+			if err != nil {
+				return err
+			}
+
+			_, err = policy.NewEngine(rules)
+
+			return err
+		},
+		func() error {
+			_, err := confirm.NewFromViper()
+			return err
+		},
+		func() error {
+			_, err := tlsconfig.ConfigFromViper()
+			return err
+		},
+		func() error {
+			_, err := tlsconfig.AccountsFromViper()
+			return err
+		},
+	}
+}
@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/tlsconfig"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// httpSecurity is how the streamable HTTP server protects its connections and
+// authenticates MCP requests
+type httpSecurity struct {
+	// tls is nil when the server serves plain text
+	tls *tls.Config
+	// contextFunc puts the Tazapay credentials of a request in its context
+	contextFunc server.HTTPContextFunc
+	// stream wraps the MCP endpoint, leaving the probes and webhooks open
+	stream func(http.Handler) http.Handler
+}
+
+// serverTLS enables TLS when TAZAPAY_TLS_CERT_FILE is set and mutual TLS when
+// TAZAPAY_TLS_CLIENT_CA_FILE is set. With mutual TLS only the MCP endpoint
+// requires a client certificate.
+func serverTLS(logger *slog.Logger) (httpSecurity, error) {
+	plain := httpSecurity{
+		contextFunc: utils.AuthHeaderHTTPContextFunc,
+		stream:      func(next http.Handler) http.Handler { return next },
+	}
+
+	cfg, err := tlsconfig.ConfigFromViper()
+	if err != nil {
+		return plain, err
+	}
+
+	accounts, err := tlsconfig.AccountsFromViper()
+	if err != nil {
+		return plain, err
+	}
+
+	if accounts != nil && !cfg.MutualTLS() {
+		return plain, fmt.Errorf("%w: %s needs %s", constants.ErrInvalidTLSConfig,
+			constants.StrTAZAPAYTLSClientAccounts, constants.StrTAZAPAYTLSClientCAFile)
+	}
+
+	if !cfg.Enabled() {
+		logger.Warn("TLS disabled, credentials are sent in plain text; set TAZAPAY_TLS_CERT_FILE and TAZAPAY_TLS_KEY_FILE")
+
+		return plain, nil
+	}
+
+	config, err := tlsconfig.Load(cfg, logger)
+	if err != nil {
+		return plain, err
+	}
+
+	security := plain
+	security.tls = config
+
+	mapped := 0
+	if accounts != nil {
+		mapped = accounts.Len()
+	}
+
+	if cfg.MutualTLS() {
+		security.contextFunc = accounts.HTTPContextFunc(utils.AuthHeaderHTTPContextFunc)
+		security.stream = func(next http.Handler) http.Handler {
+			return tlsconfig.RequireClientCert(next, accounts)
+		}
+	}
+
+	logger.Info("TLS enabled", "cert", cfg.CertFile, "mutual_tls", cfg.MutualTLS(), "client_accounts", mapped)
+
+	return security, nil
+}
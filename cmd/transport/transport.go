@@ -2,35 +2,200 @@ package transport
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
+	"net/http"
+	"os"
+	"time"
 
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/spf13/viper"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/health"
+	"github.com/tazapay/tazapay-mcp-server/pkg/metrics"
+	"github.com/tazapay/tazapay-mcp-server/pkg/shutdown"
 )
 
-// HandleStdioServer starts the MCP server using stdio transport.
-// It logs the server start and delegates to server.ServeStdio.
-func HandleStdioServer(s *server.MCPServer, logger *slog.Logger) error {
+// metricsReadHeaderTimeout bounds slow clients of the metrics side port
+const metricsReadHeaderTimeout = 10 * time.Second
+
+// HandleStdioServer starts the MCP server using stdio transport and serves until
+// stdin is closed or ctx is cancelled. On cancellation no further request is read,
+// and the tool calls in flight are given TAZAPAY_SHUTDOWN_TIMEOUT to finish before
+// their context is cancelled. When TAZAPAY_METRICS_ADDR is set, the metrics are
+// served on that address.
+func HandleStdioServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger,
+	drainer *shutdown.Drainer,
+) error {
 	// Only log on actual start
-	logger.InfoContext(context.Background(), "Stdio server started")
-	return server.ServeStdio(s)
+	logger.InfoContext(ctx, "Stdio server started")
+
+	if addr := viper.GetString(constants.StrTAZAPAYMetricsAddr); addr != "" {
+		go serveMetrics(addr, logger)
+	}
+
+	// tool calls must outlive the shutdown signal, so they get their own context
+	callCtx, cancelCalls := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelCalls()
+
+	stdin, stopReading := stoppableReader(os.Stdin)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- server.NewStdioServer(s).Listen(callCtx, stdin, os.Stdout)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.InfoContext(ctx, "Shutting down, waiting for tool calls in flight", "in_flight", drainer.InFlight())
+	stopReading()
+
+	err := drain(drainer, logger)
+	if err != nil {
+		cancelCalls()
+	}
+
+	// the stdio server returns once the responses of the remaining calls are written
+	if listenErr := <-done; listenErr != nil && !errors.Is(listenErr, context.Canceled) {
+		return errors.Join(err, listenErr)
+	}
+
+	return err
+}
+
+// stoppableReader returns a reader of r that reports EOF once stop is called, even
+// while a read of r is blocked
+func stoppableReader(r io.Reader) (io.Reader, func()) {
+	pr, pw := io.Pipe()
+
+	go func() {
+		_, err := io.Copy(pw, r)
+		pw.CloseWithError(err)
+	}()
+
+	return pr, func() { pw.Close() }
+}
+
+// serveMetrics serves /metrics on a side port, stdout being taken by the stdio transport
+func serveMetrics(addr string, logger *slog.Logger) {
+	mux := http.NewServeMux()
+	mux.Handle("GET "+constants.MetricsPath, metrics.Handler())
+
+	logger.InfoContext(context.Background(), "Metrics server started", "addr", addr)
+
+	srv := &http.Server{Addr: addr, Handler: mux, ReadHeaderTimeout: metricsReadHeaderTimeout}
+	if err := srv.ListenAndServe(); err != nil {
+		logger.ErrorContext(context.Background(), "Metrics server stopped", "addr", addr, constants.KeyError, err)
+	}
 }
 
 // HandleStreamableHTTPServer starts the MCP server using a streamable HTTP transport.
 // It sets up the HTTP server with endpoint path and authentication context, logs the start,
-// and listens on the configured address (default :8081).
-func HandleStreamableHTTPServer(s *server.MCPServer, logger *slog.Logger) error {
+// and listens on the configured address (default :8081), over TLS when TAZAPAY_TLS_CERT_FILE
+// is set. The /healthz, /readyz, /version and /metrics endpoints are served on the same
+// address, and so is the Tazapay webhook receiver when it is enabled.
+//
+// When ctx is cancelled the listener is closed, so no new session can start, and the
+// tool calls in flight are given TAZAPAY_SHUTDOWN_TIMEOUT to finish before the
+// remaining connections are closed.
+func HandleStreamableHTTPServer(ctx context.Context, s *server.MCPServer, logger *slog.Logger,
+	drainer *shutdown.Drainer,
+) error {
 	// Only log on actual start
-	logger.InfoContext(context.Background(), "Streamable HTTP server started")
-	streamServer := server.NewStreamableHTTPServer(s,
-		server.WithEndpointPath("/stream"),
-		server.WithHTTPContextFunc(utils.AuthHeaderHTTPContextFunc),
-	)
-	defer streamServer.Shutdown(context.Background())
+	logger.InfoContext(ctx, "Streamable HTTP server started")
 	addr := viper.GetString("STREAM_SERVER_ADDR")
 	if addr == "" {
 		addr = ":8081"
 	}
-	return streamServer.Start(addr)
+
+	security, err := serverTLS(logger)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	httpServer := &http.Server{Addr: addr, Handler: mux, TLSConfig: security.tls}
+	streamServer := server.NewStreamableHTTPServer(s,
+		server.WithEndpointPath("/stream"),
+		server.WithHTTPContextFunc(security.contextFunc),
+		server.WithStreamableHTTPServer(httpServer),
+	)
+	mux.Handle("/stream", security.stream(streamServer))
+	health.NewFromViper(func() int { return len(s.ListTools()) }, configChecks()...).Register(mux)
+	mux.Handle("GET "+constants.MetricsPath, metrics.Handler())
+
+	hooks, events, err := webhookHandler(s, logger)
+	if err != nil {
+		return err
+	}
+
+	if hooks != nil {
+		mux.Handle(constants.WebhookPath, hooks)
+		defer events.Close()
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		if security.tls != nil {
+			// the certificates come from tlsConfig, reloaded when the files change
+			done <- httpServer.ListenAndServeTLS("", "")
+			return
+		}
+
+		done <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+	}
+
+	logger.InfoContext(ctx, "Shutting down, waiting for tool calls in flight", "in_flight", drainer.InFlight())
+
+	// Shutdown closes the listener at once, then waits for the open connections to go idle
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- httpServer.Shutdown(context.WithoutCancel(ctx))
+	}()
+
+	err = drain(drainer, logger)
+
+	// streams held open by clients never go idle, close them with anything left running
+	if closeErr := httpServer.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+
+	<-stopped
+
+	if serveErr := <-done; serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+		err = errors.Join(err, serveErr)
+	}
+
+	return err
+}
+
+// drain waits up to TAZAPAY_SHUTDOWN_TIMEOUT for the tool calls in flight
+func drain(drainer *shutdown.Drainer, logger *slog.Logger) error {
+	timeout := shutdown.Timeout()
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := drainer.Drain(ctx); err != nil {
+		logger.ErrorContext(ctx, "Tool calls still running at the shutdown deadline",
+			"timeout", timeout, constants.KeyError, err)
+
+		return err
+	}
+
+	logger.InfoContext(ctx, "All tool calls finished")
+
+	return nil
 }
@@ -0,0 +1,35 @@
+package transport
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/webhook"
+)
+
+// webhookHandler enables the webhook receiver when TAZAPAY_WEBHOOK_SECRET is set.
+// It returns a nil handler when the receiver is disabled. The events store must be
+// closed once the server has stopped.
+func webhookHandler(s *server.MCPServer, logger *slog.Logger) (http.Handler, *webhook.Store, error) {
+	secret := viper.GetString(constants.StrTAZAPAYWebhookSecret)
+	if secret == "" {
+		logger.Info("Webhook receiver disabled, TAZAPAY_WEBHOOK_SECRET is not set")
+		return nil, nil, nil
+	}
+
+	store, err := webhook.OpenStore(webhook.Path())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	webhook.Default.Attach(s, store, logger)
+	scheme := webhook.SchemeFromViper()
+	logger.Info("Webhook receiver enabled", "path", constants.WebhookPath, "events", webhook.Path(),
+		"signature_header", scheme.SignatureHeader, "timestamp_header", scheme.TimestampHeader)
+
+	return webhook.Default.Handler([]byte(secret), scheme), store, nil
+}
@@ -10,6 +10,41 @@ const (
 
 	// Added for http_utils.go magic string/number linting
 	StrTAZAPAYAuthToken           = "TAZAPAY_AUTH_TOKEN"
+	StrTAZAPAYAPIKey              = "TAZAPAY_API_KEY"
+	StrTAZAPAYAPISecret           = "TAZAPAY_API_SECRET"
+	StrTAZAPAYReadOnly            = "TAZAPAY_READ_ONLY"
+	StrTAZAPAYToolsAllow          = "TAZAPAY_TOOLS_ALLOW"
+	StrTAZAPAYToolsDeny           = "TAZAPAY_TOOLS_DENY"
+	StrTAZAPAYToolPrefix          = "TAZAPAY_TOOL_PREFIX"
+	StrTAZAPAYToolAliases         = "TAZAPAY_TOOL_ALIASES"
+	StrTAZAPAYPolicy              = "TAZAPAY_POLICY"
+	StrTAZAPAYConfirmationSecret  = "TAZAPAY_CONFIRMATION_SECRET"
+	StrTAZAPAYConfirmationTTL     = "TAZAPAY_CONFIRMATION_TTL"
+	StrTAZAPAYDryRun              = "TAZAPAY_DRY_RUN"
+	StrTAZAPAYAuditLogPath        = "TAZAPAY_AUDIT_LOG_PATH"
+	StrTAZAPAYAuditSecret         = "TAZAPAY_AUDIT_SECRET"
+	AuditLogFileName              = ".tazapay-mcp-server-audit.jsonl"
+	AuditLogFileMode              = 0o600
+	StrTAZAPAYWebhookSecret       = "TAZAPAY_WEBHOOK_SECRET"
+	StrTAZAPAYWebhookURL          = "TAZAPAY_WEBHOOK_URL"
+	StrTAZAPAYWebhookEventsPath   = "TAZAPAY_WEBHOOK_EVENTS_PATH"
+	WebhookEventsFileName         = ".tazapay-mcp-server-events.jsonl"
+	WebhookPath                   = "/webhooks/tazapay"
+	WebhookSignatureHeader        = "X-Tazapay-Signature"
+	WebhookTimestampHeader        = "X-Tazapay-Timestamp"
+	StrTAZAPAYWebhookSigHeader    = "TAZAPAY_WEBHOOK_SIGNATURE_HEADER"
+	StrTAZAPAYWebhookTSHeader     = "TAZAPAY_WEBHOOK_TIMESTAMP_HEADER"
+	WebhookNoTimestamp            = "none"
+	WebhookMaxBodySize            = 1 << 20
+	StrTAZAPAYBalanceCheck        = "TAZAPAY_BALANCE_CHECK"
+	BalanceCheckOff               = "off"
+	BalanceCheckWarn              = "warn"
+	BalanceCheckBlock             = "block"
+	StrTAZAPAYBatchDir            = "TAZAPAY_BATCH_DIR"
+	BatchMaxRows                  = 1000
+	BatchMaxDocumentSize          = 4 << 20
+	BatchDefaultConcurrency       = 4
+	BatchMaxConcurrency           = 10
 	StrFailedToCreateHTTPRequest  = "Failed to create HTTP request"
 	StrErrorCreatingRequest       = "error creating request: %w"
 	StrHTTPRequestFailed          = "HTTP request failed"
@@ -46,4 +81,21 @@ const (
 	// string constants for transport types
 	TransportTypeStdio          = "stdio"
 	TransportTypeStreamableHTTP = "streamablehttp"
+
+	// probe endpoints served next to /stream
+	HealthzPath              = "/healthz"
+	ReadyzPath               = "/readyz"
+	VersionPath              = "/version"
+	StrTAZAPAYReadinessCheck = "TAZAPAY_READINESS_CHECK"
+	MetricsPath              = "/metrics"
+	StrTAZAPAYMetricsAddr    = "TAZAPAY_METRICS_ADDR"
+
+	StrTAZAPAYShutdownTimeout = "TAZAPAY_SHUTDOWN_TIMEOUT"
+
+	// TLS of the streamable HTTP server
+	StrTAZAPAYTLSCertFile       = "TAZAPAY_TLS_CERT_FILE"
+	StrTAZAPAYTLSKeyFile        = "TAZAPAY_TLS_KEY_FILE"
+	StrTAZAPAYTLSMinVersion     = "TAZAPAY_TLS_MIN_VERSION"
+	StrTAZAPAYTLSClientCAFile   = "TAZAPAY_TLS_CLIENT_CA_FILE"
+	StrTAZAPAYTLSClientAccounts = "TAZAPAY_TLS_CLIENT_ACCOUNTS"
 )
@@ -5,6 +5,7 @@ import "errors"
 var (
 	ErrNonSuccessStatus              = errors.New("non-success status")
 	ErrInvalidType                   = errors.New("invalid type for field")
+	ErrInvalidFieldValue             = errors.New("invalid value for field")
 	ErrNoDataInResponse              = errors.New("no data received in response")
 	ErrInvalidDataFormat             = errors.New("invalid data format")
 	ErrMissingPaymentLink            = errors.New("missing payment link in response")
@@ -13,6 +14,7 @@ var (
 	ErrInvalidAmountFormat           = errors.New("invalid amount format for currency")
 	ErrMissingRequiredFields         = errors.New("missing one of the required fields")
 	ErrInvalidCurrencyFormat         = errors.New("invalid currency format")
+	ErrMissingPayoutCurrency         = errors.New("payout currency is not known")
 	ErrInvalidCountryFormat          = errors.New("invalid country format")
 	ErrInvalidIDFormat               = errors.New("invalid id format")
 	ErrMissingOrInvalidBeneficiaryID = errors.New("missing or invalid beneficiary id")
@@ -26,4 +28,36 @@ var (
 	ErrHTTPRequestFailed         = errors.New("HTTP request failed")
 	ErrFailedToReadResponseBody  = errors.New("failed to read response body")
 	ErrFailedToDecodeResponse    = errors.New("failed to decode response JSON")
+	ErrInvalidToolPattern        = errors.New("invalid tool name pattern")
+	ErrInvalidToolPrefix         = errors.New("invalid tool name prefix")
+	ErrDuplicateToolName         = errors.New("duplicate tool name")
+	ErrUnknownToolName           = errors.New("tool is not in the tool catalogue")
+	ErrNoToolsRegistered         = errors.New("no tools registered")
+	ErrMissingAuthToken          = errors.New("TAZAPAY_AUTH_TOKEN is not set")
+	ErrShuttingDown              = errors.New("server is shutting down, retry the call once it is back")
+	ErrDrainTimeout              = errors.New("shutdown deadline exceeded")
+	ErrInvalidTLSConfig          = errors.New("invalid TLS configuration")
+	ErrUnknownClientCert         = errors.New("client certificate is not mapped to a Tazapay account")
+	ErrClientCertRequired        = errors.New("a verified client certificate is required")
+	ErrInvalidPolicy             = errors.New("invalid policy configuration")
+	ErrPolicyDenied              = errors.New("denied by policy")
+	ErrInvalidConfirmation       = errors.New("invalid confirmation configuration")
+	ErrInvalidConfirmationToken  = errors.New("invalid, expired or already used confirmation_token, call the tool again without it to get a new summary")
+	ErrDryRun                    = errors.New("dry run, request not sent to Tazapay")
+	ErrAuditChainBroken          = errors.New("audit log hash chain is broken")
+	ErrAuditLogClosed            = errors.New("audit log is closed")
+	ErrMissingAuditKey           = errors.New("audit log hash chain key is empty")
+	ErrInvalidAuditQuery         = errors.New("invalid audit log query")
+	ErrInvalidWebhookSignature   = errors.New("invalid webhook signature")
+	ErrInvalidWebhookEvent       = errors.New("invalid webhook event")
+	ErrStaleWebhook              = errors.New("webhook timestamp is missing or outside the replay window")
+	ErrObjectNotOwned            = errors.New("object not found for the account of this session")
+	ErrWebhookDisabled           = errors.New("webhook receiver is not enabled, run the streamable HTTP transport with TAZAPAY_WEBHOOK_SECRET set")
+	ErrInvalidBatch              = errors.New("invalid batch payout document")
+	ErrBatchDirDisabled          = errors.New("batch files are read from TAZAPAY_BATCH_DIR, which is not set")
+	ErrUnsupportedWaitObject     = errors.New("unsupported object id, should be starting with pot_, pay_ or chk_")
+	ErrCorridorRequirements      = errors.New("bank details do not meet the corridor requirements")
+	ErrInvalidBankIdentifier     = errors.New("invalid bank identifier")
+	ErrInvalidPurposeCode        = errors.New("invalid payout purpose code")
+	ErrReadOnlyMode              = errors.New("server is running in read-only mode, only GET requests to Tazapay are allowed")
 )
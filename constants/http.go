@@ -1,9 +1,11 @@
 package constants
 
 const (
-	HeaderAccept        = "Accept"
-	HeaderAuthorization = "Authorization"
-	HeaderContentType   = "Content-Type"
+	HeaderAccept         = "Accept"
+	HeaderAuthorization  = "Authorization"
+	HeaderContentType    = "Content-Type"
+	HeaderIdempotencyKey = "Idempotency-Key"
+	HeaderRequestID      = "X-Request-Id"
 
 	ContentTypeJSON = "application/json"
 	AcceptJSON      = "application/json"
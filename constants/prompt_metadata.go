@@ -0,0 +1,29 @@
+package constants
+
+// Pay Beneficiary prompt constants
+const (
+	PayBeneficiaryPromptName = "tazapay_pay_beneficiary"
+	PayBeneficiaryPromptDesc = "Pay an existing beneficiary from one of your Tazapay balances"
+)
+
+// Payment Link prompt constants
+const (
+	PaymentLinkPromptName = "tazapay_collect_payment"
+	PaymentLinkPromptDesc = "Collect a payment from a customer with a Tazapay payment link"
+)
+
+// Argument names that support completion
+const (
+	ArgBeneficiary     = "beneficiary"
+	ArgCustomer        = "customer"
+	ArgAmount          = "amount"
+	ArgCurrency        = "currency"
+	ArgHoldingCurrency = "holding_currency"
+	ArgInvoiceCurrency = "invoice_currency"
+	ArgCountry         = "country"
+	ArgPurpose         = "purpose"
+	ArgPurposeCode     = "purpose_code"
+
+	// MaxCompletionValues is the maximum number of values a completion response may carry
+	MaxCompletionValues = 100
+)
@@ -0,0 +1,78 @@
+package constants
+
+// PurposeCode describes a Tazapay payout purpose code. Tazapay does not
+// restrict purpose codes by corridor, so every code is accepted for every
+// payout currency and destination.
+type PurposeCode struct {
+	Code        string
+	Description string
+	// Keywords are words of payment descriptions the code fits, used to recommend it
+	Keywords []string
+}
+
+// DefaultPurposeCode is recommended when no code fits a description
+const DefaultPurposeCode = "PYR028"
+
+// PayoutPurposeCodes lists the payout purpose codes accepted by Tazapay (PYR001 to PYR028)
+var PayoutPurposeCodes = []PurposeCode{
+	{Code: "PYR001", Description: "Family maintenance",
+		Keywords: []string{"family", "parents", "relatives", "allowance", "maintenance", "support"}},
+	{Code: "PYR002", Description: "Household maintenance",
+		Keywords: []string{"household", "housekeeping", "domestic", "nanny", "maid"}},
+	{Code: "PYR003", Description: "Donations and gifts",
+		Keywords: []string{"donation", "gift", "charity", "grant", "sponsorship", "nonprofit"}},
+	{Code: "PYR004", Description: "Payment of loan",
+		Keywords: []string{"loan", "repayment", "emi", "installment", "instalment", "debt", "credit"}},
+	{Code: "PYR005", Description: "Purchase of goods",
+		Keywords: []string{"goods", "purchase", "supplier", "inventory", "stock", "products", "materials",
+			"wholesale", "order", "import"}},
+	{Code: "PYR006", Description: "Payment for services",
+		Keywords: []string{"services", "service", "vendor", "outsourcing", "agency", "support", "maintenance"}},
+	{Code: "PYR007", Description: "Salary payment",
+		Keywords: []string{"salary", "payroll", "wages", "wage", "employee", "staff", "bonus", "compensation"}},
+	{Code: "PYR008", Description: "Rent payment",
+		Keywords: []string{"rent", "lease", "landlord", "office", "warehouse", "tenancy"}},
+	{Code: "PYR009", Description: "Payment of utility bills",
+		Keywords: []string{"utility", "utilities", "electricity", "water", "gas", "internet", "phone", "telecom", "bill"}},
+	{Code: "PYR010", Description: "Payment of taxes",
+		Keywords: []string{"tax", "taxes", "vat", "gst", "duty", "customs", "withholding"}},
+	{Code: "PYR011", Description: "Travel expenses",
+		Keywords: []string{"travel", "trip", "vacation", "holiday", "tour"}},
+	{Code: "PYR012", Description: "Education expenses",
+		Keywords: []string{"education", "tuition", "school", "university", "college", "course", "training", "fees"}},
+	{Code: "PYR013", Description: "Medical expenses",
+		Keywords: []string{"medical", "hospital", "doctor", "treatment", "health", "clinic", "pharmacy"}},
+	{Code: "PYR014", Description: "Insurance premium",
+		Keywords: []string{"insurance", "premium", "policy", "coverage"}},
+	{Code: "PYR015", Description: "Investment",
+		Keywords: []string{"investment", "invest", "equity", "shares", "capital", "funding", "securities"}},
+	{Code: "PYR016", Description: "Business travel",
+		Keywords: []string{"business", "travel", "hotel", "flight", "conference", "per", "diem", "reimbursement"}},
+	{Code: "PYR017", Description: "Advertising and marketing expenses",
+		Keywords: []string{"advertising", "ads", "marketing", "campaign", "promotion", "media", "influencer", "seo"}},
+	{Code: "PYR018", Description: "Royalty and licence fees",
+		Keywords: []string{"royalty", "royalties", "licence", "license", "licensing", "franchise", "copyright",
+			"patent", "trademark"}},
+	{Code: "PYR019", Description: "Commission and brokerage",
+		Keywords: []string{"commission", "brokerage", "broker", "affiliate", "referral", "agent", "finder"}},
+	{Code: "PYR020", Description: "Consultancy and professional fees",
+		Keywords: []string{"consultancy", "consulting", "consultant", "professional", "freelancer", "freelance",
+			"contractor", "invoice", "legal", "accounting", "audit", "advisory", "fees"}},
+	{Code: "PYR021", Description: "Construction expenses",
+		Keywords: []string{"construction", "building", "contractor", "renovation", "civil", "engineering"}},
+	{Code: "PYR022", Description: "Freight charges",
+		Keywords: []string{"freight", "shipping", "cargo", "logistics", "forwarding", "container", "courier"}},
+	{Code: "PYR023", Description: "Transportation charges",
+		Keywords: []string{"transportation", "transport", "delivery", "trucking", "taxi", "ride", "haulage"}},
+	{Code: "PYR024", Description: "Software and IT services",
+		Keywords: []string{"software", "it", "saas", "subscription", "hosting", "cloud", "development", "developer",
+			"app", "website", "programming"}},
+	{Code: "PYR025", Description: "Marketplace or merchant settlement",
+		Keywords: []string{"marketplace", "merchant", "seller", "settlement", "platform", "sales", "proceeds"}},
+	{Code: "PYR026", Description: "Intra-company transfer",
+		Keywords: []string{"intra", "intercompany", "subsidiary", "affiliate", "parent", "branch", "treasury", "group"}},
+	{Code: "PYR027", Description: "Refund",
+		Keywords: []string{"refund", "chargeback", "return", "reversal", "overpayment"}},
+	{Code: "PYR028", Description: "Other business payments",
+		Keywords: []string{"other", "business", "miscellaneous", "general"}},
+}
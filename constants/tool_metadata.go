@@ -1,57 +1,45 @@
 package constants
 
+// Tool naming: tools are published as <prefix><base name>_tool. The prefix is
+// TAZAPAY_TOOL_PREFIX, tazapay_ by default, and the ToolName constants below
+// are base names (see pkg/toolname).
+const (
+	ToolNamePrefixDefault = "tazapay_"
+	ToolNameSuffix        = "_tool"
+	ToolNamePrefixMaxLen  = 32
+	DeprecatedToolDesc    = "Deprecated alias of %s, call %s instead. "
+)
+
 // Payment Link Tool constants
 const (
-	PaymentLinkToolName = "tazapay_generate_payment_link_tool"
+	PaymentLinkToolName = "generate_payment_link"
 	PaymentLinkToolDesc = "Generates a checkout payment link with specified invoice details and customer information"
 
-	InvoiceCurrencyField = "invoice_currency"
-	InvoiceCurrencyDesc  = "Currency in which the invoice is to be raised (e.g., USD, EUR)"
-
-	PaymentAmountField = "payment_amount"
-	PaymentAmountDesc  = "Total invoice amount to be paid"
-
-	CustomerNameField = "customer_name"
-	CustomerNameDesc  = "Full name of the customer"
-
-	CustomerEmailField = "customer_email"
-	CustomerEmailDesc  = "Email address of the customer"
+	InvoiceCurrencyDesc = "Currency in which the invoice is to be raised (e.g., USD, EUR)"
 
 	CustomerCountryField = "customer_country"
 	CustomerCountryDesc  = "Country of the customer"
-
-	TransactionDescField = "transaction_description"
-	TransactionDesc      = "Short description or purpose of the transaction"
 )
 
 // FX Tool constants
 const (
-	FXToolName        = "tazapay_fetch_fx_tool"
+	FXToolName        = "fetch_fx"
 	FXToolDescription = "Get FX rate from one currency to another using Tazapay FX rate"
 
-	FXFromField       = "from"
-	FXFromDescription = "Currency to convert from. It should be in 3 letter currency code. Example : USD, INR"
-
-	FXToField       = "to"
-	FXToDescription = "Currency to convert to. It should be in 3 letter currency code. Example : USD, INR"
-
-	FXAmountField       = "amount"
-	FXAmountDescription = "Amount to convert. It should be a number and should not have any decimal places."
+	FXFromField = "from"
+	FXToField   = "to"
 )
 
 // Balance Fetch tool
 const (
-	BalanceToolName = "tazapay_fetch_balance_tool"
+	BalanceToolName = "fetch_balance"
 	BalanceToolDesc = "Get balance from Tazapay. Send currency code to fetch balance for that currency." +
 		" For all the balances available in Tazapay send empty string."
-
-	BalanceCurrencyField = "currency"
-	BalanceCurrencyDesc  = "Currency to fetch balance for. It should be in 3 letter currency code. Example : USD, INR"
 )
 
 // Create Beneficiary Tool constants
 const (
-	CreateBeneficiaryToolName = "tazapay_create_beneficiary_tool"
+	CreateBeneficiaryToolName = "create_beneficiary"
 	CreateBeneficiaryToolDesc = "Create a beneficiary on Tazapay"
 
 	BeneficiaryNameField               = "name"
@@ -65,9 +53,14 @@ const (
 		" wallet, or local payment network"
 )
 
+// Update Beneficiary Tool constants
+const (
+	UpdateBeneficiaryToolName = "update_beneficiary"
+)
+
 // Create Payout Tool constants
 const (
-	CreatePayoutToolName = "tazapay_create_payout_tool"
+	CreatePayoutToolName = "create_payout"
 	CreatePayoutToolDesc = "Create a payout on Tazapay"
 
 	PayoutPurposeField            = "purpose"
@@ -87,33 +80,33 @@ const (
 	PayoutBeneficiaryDetailsDesc  = "Details of the beneficiary for this payout"
 )
 
+// Fund Payout Tool constants
+const (
+	FundPayoutToolName = "fund_payout"
+)
+
 // Get Payin Tool constants
 const (
-	GetPayinToolName = "tazapay_get_payin_tool"
+	GetPayinToolName = "get_payin"
 	GetPayinToolDesc = "Fetch a payin details by ID from Tazapay"
-	GetPayinIDField  = "id"
-	GetPayinIDDesc   = "ID of the already created payin"
 )
 
 // Get Payout Tool constants
 const (
-	GetPayoutToolName = "tazapay_get_payout_tool"
+	GetPayoutToolName = "get_payout"
 	GetPayoutToolDesc = "Fetch a payout details by ID from Tazapay"
 	GetPayoutIDField  = "id"
-	GetPayoutIDDesc   = "ID of the existing payout"
 )
 
 // Get Beneficiary Tool constants
 const (
-	GetBeneficiaryToolName = "tazapay_get_beneficiary_tool"
+	GetBeneficiaryToolName = "get_beneficiary"
 	GetBeneficiaryToolDesc = "Fetch beneficiary data by ID from Tazapay, should start with bnf_ prefix."
-	GetBeneficiaryIDField  = "id"
-	GetBeneficiaryIDDesc   = "ID of the existing beneficiary"
 )
 
 // Create Payin Tool constants
 const (
-	CreatePayinToolName             = "tazapay_create_payin_tool"
+	CreatePayinToolName             = "create_payin"
 	CreatePayinToolDesc             = "Create and confirm a payin on Tazapay"
 	CreatePayinInvoiceCurrencyField = "invoice_currency"
 	CreatePayinInvoiceCurrencyDesc  = "Currency in which the invoice is to be raised (e.g., USD, EUR)"
@@ -123,10 +116,169 @@ const (
 	CreatePayinCustomerDetailsDesc  = "Customer details object (name, email, country, phone)"
 )
 
+// Update and Confirm Payin Tool constants
+const (
+	UpdatePayinToolName  = "update_payin"
+	ConfirmPayinToolName = "confirm_payin"
+)
+
+// Checkout Session Tool constants
+const (
+	FetchCheckoutToolName  = "fetch_checkout"
+	ExpireCheckoutToolName = "expire_checkout"
+)
+
+// Get Payment Attempt Tool constants
+const (
+	GetPaymentAttemptToolName = "get_payment_attempt"
+)
+
+// Customer Tool constants
+const (
+	CreateCustomerToolName = "create_customer"
+	FetchCustomerToolName  = "fetch_customer"
+)
+
 // Cancel Payin Tool constants
 const (
-	CancelPayinToolName = "tazapay_cancel_payin_tool"
+	CancelPayinToolName = "cancel_payin"
 	CancelPayinToolDesc = "Cancel a payin on Tazapay"
 	CancelPayinIDField  = "id"
-	CancelPayinIDDesc   = "ID of the already created payin to cancel"
 )
+
+// Confirmation constants
+const (
+	ConfirmationTokenField = "confirmation_token"
+	ConfirmationField      = "confirm"
+)
+
+// Dry run constants
+const (
+	DryRunField = "dry_run"
+	DryRunDesc  = "Validate the arguments and return the request that would be sent to Tazapay, " +
+		"without sending it."
+)
+
+// Query Audit Log Tool constants
+const (
+	QueryAuditLogToolName = "query_audit_log"
+	QueryAuditLogToolDesc = "Search the audit log of tool calls made through this server, newest first. " +
+		"Each record has the time, session, client, tool, redacted arguments, Tazapay object IDs, outcome and latency."
+)
+
+// Webhook event tools constants
+const (
+	WatchEventsToolName = "watch_events"
+	WatchEventsToolDesc = "Get notified in this session when Tazapay sends webhook events for the given objects " +
+		"or event types. Objects created through this server are watched automatically. Only objects of your " +
+		"account can be watched, and event types only match the events of objects you watched or read."
+	ListEventsToolName = "list_events"
+	ListEventsToolDesc = "List the Tazapay webhook events received by this server for objects of your account, newest first"
+	EventsDefaultLimit = 20
+)
+
+// Wait For Status Tool constants
+const (
+	WaitForStatusToolName = "wait_for_status"
+	WaitForStatusToolDesc = "Wait until a payout (pot_), payin (pay_) or checkout (chk_) reaches one of the target " +
+		"statuses, polling Tazapay with backoff. Sends a progress notification on every status change. " +
+		"Returns the final status, whether it was reached and the statuses seen."
+)
+
+// Describe Requirements Tool constants
+const (
+	DescribeRequirementsToolName = "describe_requirements"
+	DescribeRequirementsToolDesc = "Describe the bank details Tazapay requires to pay a beneficiary in a destination " +
+		"country and currency: required and optional destination_details.bank fields with their formats and " +
+		"examples, for local and SWIFT transfers. Call it before creating a beneficiary or a payout with " +
+		"beneficiary_details."
+
+	// CorridorRequirementsHint points the payout and beneficiary tools at the requirements
+	// tool, whose published name fills the verb
+	CorridorRequirementsHint = " Required bank details depend on the destination country and currency " +
+		"(e.g. IFSC and purpose code for INR, ABA for USD, sort code for GBP, BSB for AUD, IBAN for EUR, " +
+		"CNAPS for CNY): call %s first."
+)
+
+// Lookup Codes Tool constants
+const (
+	LookupCodesToolName = "lookup_codes"
+	LookupCodesToolDesc = "Find ISO 3166-1 alpha-2 country codes and ISO 4217 currency codes by name, " +
+		"e.g. \"Indian rupee\" gives INR and \"United Kingdom\" gives GB and GBP. Currencies also match the name " +
+		"of their country and report their minor units. Use it whenever a code is not known for sure."
+)
+
+// Recommend Purpose Code Tool constants
+const (
+	RecommendPurposeCodeToolName = "recommend_purpose_code"
+	RecommendPurposeCodeToolDesc = "Recommend the payout purpose code (PYR001 to PYR028) that best fits a free-text " +
+		"description of the payment, e.g. \"freelancer invoice\" or \"salary\". Returns the best codes with their " +
+		"meaning, or the whole catalogue when description is omitted. Use it instead of guessing a purpose code."
+	RecommendLimit = 3
+)
+
+// Pay Vendor Tool constants
+const (
+	PayVendorToolName = "pay_vendor"
+	PayVendorToolDesc = "Pay a vendor in one step: reuse the beneficiary given by ID or create it from " +
+		"beneficiary_details, create the payout and fund it when the holding balance covers it. " +
+		"Reports every step. When a step fails or the balance is short, the payout is left in place and the " +
+		"result says how to resume with payout_id. Prefer this tool over calling the beneficiary, " +
+		"create payout and fund payout tools one by one."
+	PayVendorPayoutIDField       = "payout_id"
+	PayVendorIdempotencyKeyField = "idempotency_key"
+)
+
+// Batch Payout Tool constants
+const (
+	BatchPayoutToolName = "batch_payout"
+	BatchPayoutToolDesc = "Create many payouts at once from a CSV or JSON document, e.g. for payroll or " +
+		"marketplace settlements. Every row is validated first and the payouts are summarised by currency for " +
+		"confirmation. Rows are then created concurrently, each with its own idempotency key. Returns per-row " +
+		"results and a CSV results resource."
+	BatchDocumentField    = "document"
+	BatchDocumentURIField = "document_uri"
+	BatchFormatField      = "format"
+)
+
+// ToolEntry is a tool of the catalogue
+type ToolEntry struct {
+	// Name is the base name, published as <prefix><name>_tool
+	Name string
+	// Legacy is the name the tool was published under before the naming scheme.
+	// It stays registered as a deprecated alias while it differs from the published name.
+	Legacy string
+}
+
+// ToolCatalogue lists every tool of the server. A tool whose name is not in the
+// catalogue is refused at startup.
+var ToolCatalogue = []ToolEntry{
+	{Name: FXToolName, Legacy: "tazapay_fetch_fx_tool"},
+	{Name: BalanceToolName, Legacy: "tazapay_fetch_balance_tool"},
+	{Name: GetPayoutToolName, Legacy: "tazapay_get_payout_tool"},
+	{Name: FundPayoutToolName, Legacy: "fund_payout_tool"},
+	{Name: CreatePayoutToolName, Legacy: "create_payout_tool"},
+	{Name: PayVendorToolName, Legacy: "tazapay_pay_vendor_tool"},
+	{Name: BatchPayoutToolName, Legacy: "tazapay_batch_payout_tool"},
+	{Name: GetPayinToolName, Legacy: "tazapay_get_payin_tool"},
+	{Name: CreatePayinToolName, Legacy: "create_payin_tool"},
+	{Name: UpdatePayinToolName, Legacy: "update_payin_tool"},
+	{Name: ConfirmPayinToolName, Legacy: "confirm_payin_tool"},
+	{Name: CancelPayinToolName, Legacy: "tazapay_cancel_payin_tool"},
+	{Name: PaymentLinkToolName, Legacy: "tazapay_generate_payment_link_tool"},
+	{Name: FetchCheckoutToolName, Legacy: "fetch_checkout_tool"},
+	{Name: ExpireCheckoutToolName, Legacy: "expire_checkout_tool"},
+	{Name: GetBeneficiaryToolName, Legacy: "tazapay_get_beneficiary_tool"},
+	{Name: CreateBeneficiaryToolName, Legacy: "tazapay_create_beneficiary_tool"},
+	{Name: UpdateBeneficiaryToolName, Legacy: "update_beneficiary_tool"},
+	{Name: DescribeRequirementsToolName, Legacy: "tazapay_describe_requirements_tool"},
+	{Name: GetPaymentAttemptToolName, Legacy: "get_payment_attempt_tool"},
+	{Name: CreateCustomerToolName, Legacy: "tazapay_create_customer_tool"},
+	{Name: FetchCustomerToolName, Legacy: "tazapay_fetch_customer_tool"},
+	{Name: QueryAuditLogToolName, Legacy: "tazapay_query_audit_log_tool"},
+	{Name: WatchEventsToolName, Legacy: "tazapay_watch_events_tool"},
+	{Name: ListEventsToolName, Legacy: "tazapay_list_events_tool"},
+	{Name: WaitForStatusToolName, Legacy: "tazapay_wait_for_status_tool"},
+	{Name: LookupCodesToolName, Legacy: "tazapay_lookup_codes_tool"},
+	{Name: RecommendPurposeCodeToolName, Legacy: "tazapay_recommend_purpose_code_tool"},
+}
@@ -0,0 +1,380 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+const (
+	// maxLineSize bounds the size of a single record when reading the log
+	maxLineSize = 4 << 20
+	// keySize is the size of a generated chain key
+	keySize = 32
+	// keyFileSuffix names the generated key file after the log
+	keyFileSuffix = ".key"
+)
+
+// Record is one tool call in the audit log. Every record carries the keyed
+// hash of the previous one, so removing or editing a record breaks the chain
+// unless the key is known.
+type Record struct {
+	Time      time.Time       `json:"time"`
+	Args      json.RawMessage `json:"args,omitempty"`
+	ObjectIDs []string        `json:"object_ids,omitempty"`
+	Session   string          `json:"session,omitempty"`
+	Client    string          `json:"client,omitempty"`
+	Account   string          `json:"account"`
+	Tool      string          `json:"tool"`
+	Outcome   string          `json:"outcome"`
+	Error     string          `json:"error,omitempty"`
+	PrevHash  string          `json:"prev_hash"`
+	Hash      string          `json:"hash"`
+	Seq       int64           `json:"seq"`
+	LatencyMS int64           `json:"latency_ms"`
+}
+
+// computeHash returns the HMAC-SHA256 of the record with its Hash field cleared
+func (r Record) computeHash(key []byte) (string, error) {
+	r.Hash = ""
+
+	raw, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write(raw)
+
+	return hex.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Store appends records to a JSONL file. It is safe for concurrent use.
+type Store struct {
+	file   *os.File
+	key    []byte
+	broken error
+	last   string
+	seq    int64
+	closed bool
+	mu     sync.Mutex
+}
+
+// Path returns the configured audit log path, by default
+// `.tazapay-mcp-server-audit.jsonl` in the home directory
+func Path() string {
+	if path := viper.GetString(constants.StrTAZAPAYAuditLogPath); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return constants.AuditLogFileName
+	}
+
+	return filepath.Join(home, constants.AuditLogFileName)
+}
+
+// Key returns the key of the hash chain: TAZAPAY_AUDIT_SECRET, or else a
+// random key generated on first use and kept next to the log at path with the
+// .key suffix. Keep the secret away from the log to detect tampering by anyone
+// who can write the log.
+func Key(path string) ([]byte, error) {
+	if secret := viper.GetString(constants.StrTAZAPAYAuditSecret); secret != "" {
+		return []byte(secret), nil
+	}
+
+	keyPath := path + keyFileSuffix
+
+	raw, err := os.ReadFile(keyPath)
+	if err == nil {
+		return hex.DecodeString(string(bytes.TrimSpace(raw)))
+	}
+
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(keyPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, constants.AuditLogFileMode)
+	if errors.Is(err, os.ErrExist) {
+		// created concurrently, use that one
+		return Key(path)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if _, err := f.WriteString(hex.EncodeToString(key) + "\n"); err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+// Open opens the audit log at path for appending, creating it if needed,
+// and continues the hash chain of the records already in it, keyed with key.
+// The existing chain is verified on the way, see Broken. A partial record at
+// the end of the log, left by a write cut short, is truncated.
+func Open(path string, key []byte) (*Store, error) {
+	if len(key) == 0 {
+		return nil, constants.ErrMissingAuditKey
+	}
+
+	s := &Store{key: key}
+
+	size, terminated, err := s.resume(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	s.file, err = os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.AuditLogFileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.file.Truncate(size); err != nil {
+		s.file.Close()
+		return nil, err
+	}
+
+	// the last record was written without its newline
+	if !terminated {
+		if _, err := s.file.WriteString("\n"); err != nil {
+			s.file.Close()
+			return nil, err
+		}
+	}
+
+	return s, nil
+}
+
+// resume reads the log at path to continue its chain, remembering where the
+// chain breaks. It returns the size of the complete records and whether they
+// end with a newline.
+func (s *Store) resume(path string) (int64, bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, true, err
+	}
+	defer f.Close()
+
+	var prev string
+
+	size, err := scanRecords(f, func(r Record) error {
+		if s.broken == nil {
+			if hash, err := r.computeHash(s.key); err != nil || r.PrevHash != prev || r.Hash != hash {
+				s.broken = fmt.Errorf("%w at record %d", constants.ErrAuditChainBroken, r.Seq)
+			}
+		}
+
+		prev = r.Hash
+		s.last, s.seq = r.Hash, r.Seq
+
+		return nil
+	})
+
+	// an unreadable record breaks the chain too, new records are chained to
+	// the last one that could be read
+	if errors.Is(err, constants.ErrAuditChainBroken) {
+		if s.broken == nil {
+			s.broken = err
+		}
+
+		size, err = fileSize(f)
+	}
+
+	if err != nil {
+		return size, true, err
+	}
+
+	return size, endsWithNewline(f, size), nil
+}
+
+// Broken reports where the hash chain of the records found by Open breaks, or
+// nil when it is intact. Records appended since are chained to the last record
+// found, so the break stays visible to Verify.
+func (s *Store) Broken() error {
+	return s.broken
+}
+
+// Append chains the record to the log and writes it to disk. It fails once the
+// store is closed.
+func (s *Store) Append(r Record) (Record, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return r, constants.ErrAuditLogClosed
+	}
+
+	r.Seq = s.seq + 1
+	r.PrevHash = s.last
+
+	hash, err := r.computeHash(s.key)
+	if err != nil {
+		return r, err
+	}
+
+	r.Hash = hash
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return r, err
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return r, err
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return r, err
+	}
+
+	s.last, s.seq = r.Hash, r.Seq
+
+	return r, nil
+}
+
+// Close closes the log file. Later appends fail and closing again does nothing.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+
+	s.closed = true
+
+	return s.file.Close()
+}
+
+// Verify checks the hash chain of the log at path, keyed with key, and returns
+// the number of records
+func Verify(path string, key []byte) (int, error) {
+	var (
+		count int
+		prev  string
+	)
+
+	err := readRecords(path, func(r Record) error {
+		hash, err := r.computeHash(key)
+		if err != nil {
+			return err
+		}
+
+		if r.PrevHash != prev || r.Hash != hash {
+			return fmt.Errorf("%w at record %d", constants.ErrAuditChainBroken, r.Seq)
+		}
+
+		prev = r.Hash
+		count++
+
+		return nil
+	})
+
+	return count, err
+}
+
+// readRecords calls fn for every record of the log at path, oldest first
+func readRecords(path string, fn func(Record) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = scanRecords(f, fn)
+
+	return err
+}
+
+// scanRecords decodes one record per line and returns the size of the lines
+// read. A last line without a newline that does not decode is the partial
+// record of a write cut short: it is skipped and not counted in the size.
+func scanRecords(r io.Reader, fn func(Record) error) (int64, error) {
+	var partial bool
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		partial = atEOF && advance == len(data) && len(data) > 0 && data[len(data)-1] != '\n'
+
+		return advance, token, err
+	})
+
+	var size int64
+
+	line := 0
+	for scanner.Scan() {
+		line++
+
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			if partial {
+				return size, nil
+			}
+
+			return size, fmt.Errorf("%w: line %d: %w", constants.ErrAuditChainBroken, line, err)
+		}
+
+		if err := fn(record); err != nil {
+			return size, err
+		}
+
+		size += int64(len(scanner.Bytes()))
+		if !partial {
+			size++
+		}
+	}
+
+	return size, scanner.Err()
+}
+
+// endsWithNewline reports whether the first size bytes of the file are empty or
+// end with a newline
+func endsWithNewline(f *os.File, size int64) bool {
+	if size == 0 {
+		return true
+	}
+
+	last := make([]byte, 1)
+	if _, err := f.ReadAt(last, size-1); err != nil {
+		return true
+	}
+
+	return last[0] == '\n'
+}
+
+// fileSize returns the size of the open file
+func fileSize(f *os.File) (int64, error) {
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	return info.Size(), nil
+}
@@ -0,0 +1,252 @@
+package audit
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// key keys the hash chain of the test logs
+var key = []byte("audit-test-key")
+
+func writeRecords(t *testing.T, path string, records ...Record) {
+	t.Helper()
+
+	store, err := Open(path, key)
+	if err != nil {
+		t.Fatalf("Open() error: %v", err)
+	}
+	defer store.Close()
+
+	for _, r := range records {
+		if _, err := store.Append(r); err != nil {
+			t.Fatalf("Append() error: %v", err)
+		}
+	}
+}
+
+func TestStoreChainAcrossReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writeRecords(t, path,
+		Record{Time: now, Tool: "create_payout_tool", Outcome: types.OutcomeSuccess, Args: []byte(`{"amount":10}`)},
+		Record{Time: now, Tool: "fund_payout_tool", Outcome: types.OutcomeDenied},
+	)
+	writeRecords(t, path, Record{Time: now, Tool: "tazapay_get_payout_tool", Outcome: types.OutcomeError})
+
+	count, err := Verify(path, key)
+	if err != nil || count != 3 {
+		t.Fatalf("Verify() = %d, %v; want 3, nil", count, err)
+	}
+}
+
+func TestVerifyNeedsTheKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	writeRecords(t, path, Record{Tool: "a", Outcome: types.OutcomeSuccess})
+
+	if _, err := Verify(path, []byte("other")); !errors.Is(err, constants.ErrAuditChainBroken) {
+		t.Errorf("Verify() with another key = %v; want ErrAuditChainBroken", err)
+	}
+
+	if _, err := Open(path, nil); !errors.Is(err, constants.ErrMissingAuditKey) {
+		t.Errorf("Open() without key = %v; want ErrMissingAuditKey", err)
+	}
+}
+
+func TestAppendAfterClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	store, err := Open(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	if _, err := store.Append(Record{Tool: "a"}); !errors.Is(err, constants.ErrAuditLogClosed) {
+		t.Errorf("Append() after Close = %v; want ErrAuditLogClosed", err)
+	}
+
+	if err := store.Close(); err != nil {
+		t.Errorf("second Close() = %v; want nil", err)
+	}
+}
+
+func TestKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	generated, err := Key(path)
+	if err != nil || len(generated) != keySize {
+		t.Fatalf("Key() = %x, %v; want a new %d byte key", generated, err, keySize)
+	}
+
+	if info, err := os.Stat(path + keyFileSuffix); err != nil || info.Mode().Perm() != constants.AuditLogFileMode {
+		t.Errorf("key file = %v, %v; want mode %o", info, err, constants.AuditLogFileMode)
+	}
+
+	if again, _ := Key(path); string(again) != string(generated) {
+		t.Error("Key() generated another key; want the stored one")
+	}
+
+	viper.Set(constants.StrTAZAPAYAuditSecret, "configured")
+	defer viper.Set(constants.StrTAZAPAYAuditSecret, "")
+
+	if configured, _ := Key(path); string(configured) != "configured" {
+		t.Errorf("Key() = %q; want TAZAPAY_AUDIT_SECRET", configured)
+	}
+}
+
+func TestVerifyDetectsTampering(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(lines []string) []string
+	}{
+		{"edited record", func(lines []string) []string {
+			lines[1] = strings.Replace(lines[1], `"outcome":"denied"`, `"outcome":"success"`, 1)
+			return lines
+		}},
+		{"removed record", func(lines []string) []string {
+			return append(lines[:1], lines[2:]...)
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			writeRecords(t, path,
+				Record{Tool: "a", Outcome: types.OutcomeSuccess},
+				Record{Tool: "b", Outcome: types.OutcomeDenied},
+				Record{Tool: "c", Outcome: types.OutcomeSuccess},
+			)
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			lines := test.tamper(strings.Split(strings.TrimSpace(string(raw)), "\n"))
+			if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Verify(path, key); !errors.Is(err, constants.ErrAuditChainBroken) {
+				t.Errorf("Verify() = %v; want ErrAuditChainBroken", err)
+			}
+
+			store, err := Open(path, key)
+			if err != nil {
+				t.Fatalf("Open() error: %v", err)
+			}
+			defer store.Close()
+
+			if err := store.Broken(); !errors.Is(err, constants.ErrAuditChainBroken) {
+				t.Errorf("Broken() = %v; want ErrAuditChainBroken", err)
+			}
+		})
+	}
+}
+
+func TestOpenPartialRecord(t *testing.T) {
+	tests := []struct {
+		name string
+		cut  func(raw string) string
+	}{
+		{"record cut short", func(raw string) string {
+			return raw[:len(raw)-10]
+		}},
+		{"record without newline", func(raw string) string {
+			return strings.TrimSuffix(raw, "\n")
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "audit.jsonl")
+			writeRecords(t, path,
+				Record{Tool: "a", Outcome: types.OutcomeSuccess},
+				Record{Tool: "b", Outcome: types.OutcomeSuccess},
+			)
+
+			raw, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if err := os.WriteFile(path, []byte(test.cut(string(raw))), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := Verify(path, key); err != nil {
+				t.Fatalf("Verify() = %v; want the partial record skipped", err)
+			}
+
+			store, err := Open(path, key)
+			if err != nil {
+				t.Fatalf("Open() error: %v", err)
+			}
+
+			if err := store.Broken(); err != nil {
+				t.Errorf("Broken() = %v; want nil", err)
+			}
+
+			store.Close()
+			writeRecords(t, path, Record{Tool: "c", Outcome: types.OutcomeSuccess})
+
+			if _, err := Verify(path, key); err != nil {
+				t.Errorf("Verify() after appending = %v; want nil", err)
+			}
+		})
+	}
+}
+
+func TestQuery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	start := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	writeRecords(t, path,
+		Record{Time: start, Tool: "create_payout_tool", Session: "s1", ObjectIDs: []string{"pot_1"}},
+		Record{Time: start.Add(time.Hour), Tool: "fund_payout_tool", Session: "s1", ObjectIDs: []string{"pot_1"}},
+		Record{Time: start.Add(2 * time.Hour), Tool: "create_payout_tool", Session: "s2", ObjectIDs: []string{"pot_2"}},
+	)
+
+	tests := []struct {
+		name   string
+		filter Filter
+		want   []string
+	}{
+		{"everything newest first", Filter{}, []string{"create_payout_tool", "fund_payout_tool", "create_payout_tool"}},
+		{"by tool", Filter{Tool: "create_payout_tool"}, []string{"create_payout_tool", "create_payout_tool"}},
+		{"by object ID", Filter{ObjectID: "pot_1"}, []string{"fund_payout_tool", "create_payout_tool"}},
+		{"by session and time", Filter{Session: "s1", Since: start.Add(30 * time.Minute)}, []string{"fund_payout_tool"}},
+		{"limit keeps the newest", Filter{Limit: 1}, []string{"create_payout_tool"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			records, err := Query(path, test.filter)
+			if err != nil {
+				t.Fatalf("Query() error: %v", err)
+			}
+
+			got := make([]string, 0, len(records))
+			for _, r := range records {
+				got = append(got, r.Tool)
+			}
+
+			if strings.Join(got, ",") != strings.Join(test.want, ",") {
+				t.Errorf("Query() tools = %v; want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// maxObjectIDs bounds the number of object IDs kept per record
+const maxObjectIDs = 20
+
+// Middleware returns a tool middleware that writes an audit record for every
+// tool call. It must wrap the policy, dry run and confirmation middlewares so
+// that policy denials, dry runs and pending confirmations are recorded as well.
+func Middleware(store *Store, logger *slog.Logger) types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		name := tool.Definition().Name
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+
+			// redact before the tool runs, some tools rewrite their arguments in place
+			redacted, err := utils.MaskPayload(args)
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to redact arguments for audit", constants.KeyError, err)
+			}
+
+			var rawArgs json.RawMessage
+			if redacted != nil {
+				rawArgs, _ = json.Marshal(redacted)
+			}
+
+			ids := utils.FindObjectIDs(string(rawArgs))
+
+			start := time.Now()
+			result, callErr := next(ctx, req)
+
+			record := Record{
+				Time:      start.UTC(),
+				Args:      rawArgs,
+				Tool:      name,
+				Account:   utils.AccountID(ctx),
+				LatencyMS: time.Since(start).Milliseconds(),
+			}
+			record.Session, record.Client = identity(ctx)
+			record.Outcome = types.Outcome(result, callErr)
+
+			switch {
+			case callErr != nil:
+				record.Error = callErr.Error()
+			case result != nil && result.IsError:
+				record.Error = types.ResultText(result)
+			}
+
+			if result != nil {
+				ids = append(ids, utils.FindObjectIDs(types.ResultText(result))...)
+			}
+
+			record.ObjectIDs = uniqueIDs(ids)
+
+			if _, err := store.Append(record); err != nil {
+				logger.ErrorContext(ctx, "Failed to write audit record", "tool", name, constants.KeyError, err)
+			}
+
+			return result, callErr
+		}
+	}
+}
+
+// identity returns the MCP session ID and client name of the request
+func identity(ctx context.Context) (string, string) {
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return "", ""
+	}
+
+	var client string
+	if info, ok := session.(server.SessionWithClientInfo); ok {
+		impl := info.GetClientInfo()
+		client = strings.TrimSpace(impl.Name + " " + impl.Version)
+	}
+
+	return session.SessionID(), client
+}
+
+// uniqueIDs removes duplicates keeping the first occurrence
+func uniqueIDs(ids []string) []string {
+	out := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		if !slices.Contains(out, id) && len(out) < maxObjectIDs {
+			out = append(out, id)
+		}
+	}
+
+	if len(out) == 0 {
+		return nil
+	}
+
+	return out
+}
@@ -0,0 +1,88 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Query limits
+const (
+	DefaultLimit = 20
+	MaxLimit     = 500
+)
+
+// Filter selects records from the audit log. Empty fields match everything.
+type Filter struct {
+	Since    time.Time
+	Tool     string
+	Session  string
+	Outcome  string
+	ObjectID string
+	Limit    int
+}
+
+// match reports whether the record passes the filter
+func (f Filter) match(r Record) bool {
+	return !r.Time.Before(f.Since) &&
+		(f.Tool == "" || r.Tool == f.Tool) &&
+		(f.Session == "" || r.Session == f.Session) &&
+		(f.Outcome == "" || r.Outcome == f.Outcome) &&
+		(f.ObjectID == "" || slices.Contains(r.ObjectIDs, f.ObjectID))
+}
+
+// Query returns the most recent records of the log at path matching the filter, newest first
+func Query(path string, f Filter) ([]Record, error) {
+	limit := f.Limit
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	limit = min(limit, MaxLimit)
+
+	records := make([]Record, 0, limit)
+
+	err := readRecords(path, func(r Record) error {
+		if !f.match(r) {
+			return nil
+		}
+
+		if len(records) == limit {
+			records = records[1:]
+		}
+
+		records = append(records, r)
+
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return records, nil
+	}
+
+	slices.Reverse(records)
+
+	return records, err
+}
+
+// ParseSince accepts either a duration before now (e.g. 24h) or an RFC 3339 time
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if d, err := time.ParseDuration(value); err == nil {
+		return now.Add(-d), nil
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: since %q is neither a duration nor an RFC 3339 time",
+			constants.ErrInvalidAuditQuery, value)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,122 @@
+package batch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Statuses of a row result
+const (
+	StatusCreated = "created"
+	StatusFailed  = "failed"
+)
+
+// Total is the sum of the payouts of a batch in one currency
+type Total = types.BatchTotal
+
+// Totals sums the payout amounts per currency, ordered by currency
+func Totals(rows []Row) []Total {
+	byCurrency := make(map[string]*Total)
+
+	for _, row := range rows {
+		currency, _ := row.Args[constants.KeyCurrency].(string)
+		currency = strings.ToUpper(currency)
+
+		total, ok := byCurrency[currency]
+		if !ok {
+			total = &Total{Currency: currency}
+			byCurrency[currency] = total
+		}
+
+		amount, _ := row.Args[constants.PayoutAmountField].(float64)
+		total.Amount += money.Decimal2ToInt64(amount)
+		total.Count++
+	}
+
+	totals := make([]Total, 0, len(byCurrency))
+	for _, currency := range slices.Sorted(maps.Keys(byCurrency)) {
+		totals = append(totals, *byCurrency[currency])
+	}
+
+	return totals
+}
+
+// Key returns the idempotency key of a batch document. Row keys are derived
+// from it, so executing the same document twice does not pay anyone twice.
+func Key(document []byte) string {
+	sum := sha256.Sum256(document)
+	return "batch-" + hex.EncodeToString(sum[:12])
+}
+
+// RowKey returns the idempotency key of a row of the batch
+func RowKey(batchKey string, row Row) string {
+	return fmt.Sprintf("%s-%d", batchKey, row.Number)
+}
+
+// Result is the outcome of one row
+type Result = types.BatchRowResult
+
+// ExecFunc executes one row and returns the ID of the created payout
+type ExecFunc func(ctx context.Context, row Row) (string, error)
+
+// Run executes the rows with at most concurrency rows in flight and returns the
+// results in row order. Rows not started when ctx is cancelled fail with its error.
+func Run(ctx context.Context, rows []Row, concurrency int, exec ExecFunc) []Result {
+	concurrency = min(max(concurrency, 1), constants.BatchMaxConcurrency)
+
+	results := make([]Result, len(rows))
+	slots := make(chan struct{}, concurrency)
+
+	var wg sync.WaitGroup
+
+	for i, row := range rows {
+		currency, _ := row.Args[constants.KeyCurrency].(string)
+		amount, _ := row.Args[constants.PayoutAmountField].(float64)
+		reference, _ := row.Args[constants.PayoutReferenceIDField].(string)
+
+		results[i] = Result{
+			Row:         row.Number,
+			ReferenceID: reference,
+			Currency:    strings.ToUpper(currency),
+			Amount:      fmt.Sprintf("%.2f", amount),
+		}
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			results[i].Status, results[i].Error = StatusFailed, ctx.Err().Error()
+			continue
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+
+			payoutID, err := exec(ctx, row)
+			if err != nil {
+				results[i].Status, results[i].Error = StatusFailed, err.Error()
+				return
+			}
+
+			results[i].Status, results[i].PayoutID = StatusCreated, payoutID
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
@@ -0,0 +1,160 @@
+package batch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+const payrollCSV = `amount,currency,purpose,transaction_description,beneficiary,beneficiary_details.name,beneficiary_details.destination_details.bank.account_number
+"1,250.50",usd,PYR001,March salary,bnf_1,,
+99,EUR,PYR001,March salary,,Jane,DE123
+`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		format   string
+		rows     int
+		err      bool
+	}{
+		{"csv", payrollCSV, "", 2, false},
+		{"json array", `[{"amount":10,"currency":"USD"},{"amount":5,"currency":"SGD"}]`, "", 2, false},
+		{"json object", `{"payouts":[{"amount":10,"currency":"USD"}]}`, "", 1, false},
+		{"forced format", `amount,currency` + "\n" + `1,USD`, FormatCSV, 1, false},
+		{"empty", "  ", "", 0, true},
+		{"header only", "amount,currency\n", "", 0, true},
+		{"bad amount", "amount,currency\nten,USD\n", "", 0, true},
+		{"ragged csv", "amount,currency\n1,USD,extra\n", "", 0, true},
+		{"unknown format", "[]", "xml", 0, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := Parse([]byte(test.document), test.format)
+			if test.err {
+				if !errors.Is(err, constants.ErrInvalidBatch) {
+					t.Errorf("Parse() error = %v; want ErrInvalidBatch", err)
+				}
+
+				return
+			}
+
+			if err != nil || len(rows) != test.rows {
+				t.Fatalf("Parse() = %d rows, %v; want %d rows", len(rows), err, test.rows)
+			}
+		})
+	}
+}
+
+func TestParseCSVNesting(t *testing.T) {
+	rows, err := Parse([]byte(payrollCSV), "")
+	if err != nil {
+		t.Fatalf("Parse() error: %v", err)
+	}
+
+	if amount := rows[0].Args["amount"]; amount != 1250.5 {
+		t.Errorf("row 1 amount = %v; want 1250.5", amount)
+	}
+
+	if _, ok := rows[0].Args["beneficiary_details"]; ok {
+		t.Error("row 1 has beneficiary_details although its cells are empty")
+	}
+
+	details, _ := rows[1].Args["beneficiary_details"].(map[string]any)
+	destination, _ := details["destination_details"].(map[string]any)
+	bank, _ := destination["bank"].(map[string]any)
+
+	if details["name"] != "Jane" || bank["account_number"] != "DE123" {
+		t.Errorf("row 2 beneficiary_details = %v", details)
+	}
+}
+
+func TestTotals(t *testing.T) {
+	rows, _ := Parse([]byte(`[{"amount":10.5,"currency":"usd"},{"amount":2,"currency":"EUR"},{"amount":0.25,"currency":"USD"}]`), "")
+
+	totals := Totals(rows)
+	want := []Total{{Currency: "EUR", Count: 1, Amount: 200}, {Currency: "USD", Count: 2, Amount: 1075}}
+
+	if fmt.Sprint(totals) != fmt.Sprint(want) {
+		t.Errorf("Totals() = %v; want %v", totals, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	rows := make([]Row, 20)
+	for i := range rows {
+		rows[i] = Row{Number: i + 1, Args: map[string]any{"amount": 1.0, "currency": "USD"}}
+	}
+
+	var inFlight, peak atomic.Int32
+
+	results := Run(context.Background(), rows, 3, func(_ context.Context, row Row) (string, error) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+
+		time.Sleep(time.Millisecond)
+
+		if row.Number%5 == 0 {
+			return "", errors.New("rejected")
+		}
+
+		return fmt.Sprintf("pot_%d", row.Number), nil
+	})
+
+	if peak.Load() > 3 {
+		t.Errorf("%d rows ran concurrently; want at most 3", peak.Load())
+	}
+
+	for i, r := range results {
+		if r.Row != i+1 {
+			t.Fatalf("result %d is for row %d; want results in row order", i, r.Row)
+		}
+
+		failed := r.Row%5 == 0
+		if failed != (r.Status == StatusFailed) || (!failed && r.PayoutID != fmt.Sprintf("pot_%d", r.Row)) {
+			t.Errorf("result %+v", r)
+		}
+	}
+}
+
+func TestReadDocument(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "payroll.csv"), []byte(payrollCSV), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ReadDocument(FilesURIPrefix + "payroll.csv"); !errors.Is(err, constants.ErrBatchDirDisabled) {
+		t.Errorf("ReadDocument() without batch dir = %v; want ErrBatchDirDisabled", err)
+	}
+
+	viper.Set(constants.StrTAZAPAYBatchDir, dir)
+	defer viper.Set(constants.StrTAZAPAYBatchDir, "")
+
+	if document, err := ReadDocument(FilesURIPrefix + "payroll.csv"); err != nil || string(document) != payrollCSV {
+		t.Errorf("ReadDocument() = %q, %v", document, err)
+	}
+
+	for _, uri := range []string{FilesURIPrefix + "../payroll.csv", FilesURIPrefix + "sub/payroll.csv", "file:///etc/passwd"} {
+		if _, err := ReadDocument(uri); !errors.Is(err, constants.ErrInvalidBatch) {
+			t.Errorf("ReadDocument(%q) = %v; want ErrInvalidBatch", uri, err)
+		}
+	}
+}
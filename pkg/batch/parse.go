@@ -0,0 +1,185 @@
+package batch
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Document formats
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+// Row is one payout of a batch
+type Row struct {
+	// Number is the 1-based position of the payout in the document
+	Number int
+	Args   map[string]any
+}
+
+// numericFields are the CSV columns holding numbers
+var numericFields = map[string]bool{
+	constants.PayoutAmountField: true,
+}
+
+// Parse reads the payouts of a CSV or JSON document. An empty format is
+// detected from the first character of the document.
+func Parse(document []byte, format string) ([]Row, error) {
+	document = bytes.TrimSpace(bytes.TrimPrefix(document, []byte("\ufeff")))
+	if len(document) == 0 {
+		return nil, fmt.Errorf("%w: empty document", constants.ErrInvalidBatch)
+	}
+
+	if len(document) > constants.BatchMaxDocumentSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", constants.ErrInvalidBatch, constants.BatchMaxDocumentSize)
+	}
+
+	if format == "" {
+		format = FormatCSV
+		if document[0] == '[' || document[0] == '{' {
+			format = FormatJSON
+		}
+	}
+
+	var (
+		rows []Row
+		err  error
+	)
+
+	switch strings.ToLower(format) {
+	case FormatCSV:
+		rows, err = parseCSV(document)
+	case FormatJSON:
+		rows, err = parseJSON(document)
+	default:
+		return nil, fmt.Errorf("%w: unknown format %q", constants.ErrInvalidBatch, format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: no payouts", constants.ErrInvalidBatch)
+	}
+
+	if len(rows) > constants.BatchMaxRows {
+		return nil, fmt.Errorf("%w: %d payouts, at most %d per batch", constants.ErrInvalidBatch,
+			len(rows), constants.BatchMaxRows)
+	}
+
+	return rows, nil
+}
+
+// parseJSON reads an array of payout objects, or an object with a payouts array
+func parseJSON(document []byte) ([]Row, error) {
+	var items []map[string]any
+
+	if document[0] == '{' {
+		var wrapped struct {
+			Payouts []map[string]any `json:"payouts"`
+		}
+
+		if err := json.Unmarshal(document, &wrapped); err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrInvalidBatch, err)
+		}
+
+		items = wrapped.Payouts
+	} else if err := json.Unmarshal(document, &items); err != nil {
+		return nil, fmt.Errorf("%w: %w", constants.ErrInvalidBatch, err)
+	}
+
+	rows := make([]Row, 0, len(items))
+	for i, item := range items {
+		rows = append(rows, Row{Number: i + 1, Args: item})
+	}
+
+	return rows, nil
+}
+
+// parseCSV reads a CSV document with a header row. Dotted column names build
+// nested objects, e.g. beneficiary_details.name. Empty cells are left out.
+func parseCSV(document []byte) ([]Row, error) {
+	reader := csv.NewReader(bytes.NewReader(document))
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", constants.ErrInvalidBatch, err)
+	}
+
+	header := records[0]
+	for i, column := range header {
+		header[i] = strings.TrimSpace(column)
+	}
+
+	rows := make([]Row, 0, len(records)-1)
+
+	for i, record := range records[1:] {
+		args := make(map[string]any)
+
+		for j, cell := range record {
+			cell = strings.TrimSpace(cell)
+			if cell == "" || header[j] == "" {
+				continue
+			}
+
+			value, err := cellValue(header[j], cell)
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d: %w", constants.ErrInvalidBatch, i+1, err)
+			}
+
+			setPath(args, strings.Split(header[j], "."), value)
+		}
+
+		rows = append(rows, Row{Number: i + 1, Args: args})
+	}
+
+	return rows, nil
+}
+
+// cellValue converts the cells of numeric and boolean columns
+func cellValue(column, cell string) (any, error) {
+	name := column[strings.LastIndex(column, ".")+1:]
+
+	switch {
+	case numericFields[name]:
+		n, err := strconv.ParseFloat(strings.ReplaceAll(cell, ",", ""), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not a number", column, cell)
+		}
+
+		return n, nil
+	case name == "firc_required":
+		b, err := strconv.ParseBool(cell)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %q is not true or false", column, cell)
+		}
+
+		return b, nil
+	}
+
+	return cell, nil
+}
+
+// setPath sets the value at the dotted path, creating the intermediate objects
+func setPath(m map[string]any, path []string, value any) {
+	for _, key := range path[:len(path)-1] {
+		next, ok := m[key].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			m[key] = next
+		}
+
+		m = next
+	}
+
+	m[path[len(path)-1]] = value
+}
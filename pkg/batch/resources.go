@@ -0,0 +1,148 @@
+package batch
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Resource URIs of batch input files and of batch results
+const (
+	FilesURIPrefix     = "tazapay://batch-files/"
+	FilesURITemplate   = FilesURIPrefix + "{name}"
+	ResultsURIPrefix   = "tazapay://batches/"
+	ResultsURITemplate = ResultsURIPrefix + "{batch_id}/results.csv"
+)
+
+// maxResults is the number of batch results kept for download
+const maxResults = 20
+
+// resultsStore keeps the CSV results of the recent batches
+type resultsStore struct {
+	csv   map[string][]byte
+	order []string
+	mu    sync.Mutex
+}
+
+var results = &resultsStore{csv: make(map[string][]byte)}
+
+// Attach registers the batch files and batch results resource templates with s
+func Attach(s *server.MCPServer) {
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(FilesURITemplate, "Batch payout file",
+			mcp.WithTemplateDescription("A CSV or JSON batch payout file from the TAZAPAY_BATCH_DIR directory"),
+			mcp.WithTemplateMIMEType("text/plain"),
+		),
+		readFile,
+	)
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(ResultsURITemplate, "Batch payout results",
+			mcp.WithTemplateDescription("Per-row results of a batch payout"),
+			mcp.WithTemplateMIMEType("text/csv"),
+		),
+		readResults,
+	)
+}
+
+// ReadDocument returns the content of a batch file resource
+func ReadDocument(uri string) ([]byte, error) {
+	dir := viper.GetString(constants.StrTAZAPAYBatchDir)
+	if dir == "" {
+		return nil, constants.ErrBatchDirDisabled
+	}
+
+	name, ok := strings.CutPrefix(uri, FilesURIPrefix)
+	if !ok || name == "" || name != filepath.Base(name) || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("%w: %q is not a %s{name} URI", constants.ErrInvalidBatch, uri, FilesURIPrefix)
+	}
+
+	f, err := os.Open(filepath.Join(dir, name))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	document, err := io.ReadAll(io.LimitReader(f, constants.BatchMaxDocumentSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(document) > constants.BatchMaxDocumentSize {
+		return nil, fmt.Errorf("%w: larger than %d bytes", constants.ErrInvalidBatch, constants.BatchMaxDocumentSize)
+	}
+
+	return document, nil
+}
+
+// SaveResults stores the results of a batch and returns the URI to download them
+func SaveResults(batchKey string, rows []Result) string {
+	var b bytes.Buffer
+
+	w := csv.NewWriter(&b)
+	_ = w.Write([]string{"row", "reference_id", "currency", "amount", "status", "payout_id", "error"})
+
+	for _, r := range rows {
+		_ = w.Write([]string{strconv.Itoa(r.Row), r.ReferenceID, r.Currency, r.Amount, r.Status, r.PayoutID, r.Error})
+	}
+
+	w.Flush()
+
+	results.mu.Lock()
+	defer results.mu.Unlock()
+
+	if _, ok := results.csv[batchKey]; !ok {
+		results.order = append(results.order, batchKey)
+	}
+
+	results.csv[batchKey] = b.Bytes()
+
+	if len(results.order) > maxResults {
+		delete(results.csv, results.order[0])
+		results.order = results.order[1:]
+	}
+
+	return ResultsURIPrefix + batchKey + "/results.csv"
+}
+
+// readFile serves a batch file resource
+func readFile(_ context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	document, err := ReadDocument(req.Params.URI)
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "text/plain", Text: string(document)},
+	}, nil
+}
+
+// readResults serves the results of a batch
+func readResults(_ context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	batchKey := strings.TrimSuffix(strings.TrimPrefix(req.Params.URI, ResultsURIPrefix), "/results.csv")
+
+	results.mu.Lock()
+	body, ok := results.csv[batchKey]
+	results.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: no results for batch %q", constants.ErrInvalidBatch, batchKey)
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "text/csv", Text: string(body)},
+	}, nil
+}
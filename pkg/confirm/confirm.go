@@ -0,0 +1,146 @@
+package confirm
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// DefaultTTL is how long a confirmation token stays valid unless configured otherwise
+const DefaultTTL = 5 * time.Minute
+
+// secretSize is the size of the random signing secret used when none is configured
+const secretSize = 32
+
+// Confirmer issues and verifies short-lived confirmation tokens. A token is an
+// HMAC over the tool name, session, arguments and expiry, so it only confirms
+// the exact call that was summarised. Tokens can be used once.
+type Confirmer struct {
+	now    func() time.Time
+	used   map[string]time.Time
+	secret []byte
+	ttl    time.Duration
+	mu     sync.Mutex
+}
+
+// New returns a Confirmer signing with secret. An empty secret is replaced by a
+// random one, which limits tokens to this process.
+func New(secret []byte, ttl time.Duration) (*Confirmer, error) {
+	if len(secret) == 0 {
+		secret = make([]byte, secretSize)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrInvalidConfirmation, err)
+		}
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &Confirmer{
+		now:    time.Now,
+		used:   make(map[string]time.Time),
+		secret: secret,
+		ttl:    ttl,
+	}, nil
+}
+
+// NewFromViper reads TAZAPAY_CONFIRMATION_SECRET and TAZAPAY_CONFIRMATION_TTL.
+// The secret must be shared by all replicas when the HTTP server is scaled out.
+func NewFromViper() (*Confirmer, error) {
+	var ttl time.Duration
+
+	if raw := viper.GetString(constants.StrTAZAPAYConfirmationTTL); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed <= 0 {
+			return nil, fmt.Errorf("%w: %s %q", constants.ErrInvalidConfirmation, constants.StrTAZAPAYConfirmationTTL, raw)
+		}
+
+		ttl = parsed
+	}
+
+	return New([]byte(viper.GetString(constants.StrTAZAPAYConfirmationSecret)), ttl)
+}
+
+// Issue returns a token confirming the call and its expiry time
+func (c *Confirmer) Issue(tool, session string, args map[string]any) (string, time.Time, error) {
+	expires := c.now().Add(c.ttl).Truncate(time.Second)
+
+	mac, err := c.sign(tool, session, args, expires.Unix())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return strconv.FormatInt(expires.Unix(), 10) + "." + mac, expires, nil
+}
+
+// Verify checks that token confirms exactly this call, has not expired and has
+// not been used before. A valid token is consumed.
+func (c *Confirmer) Verify(token, tool, session string, args map[string]any) error {
+	rawExpiry, mac, ok := strings.Cut(token, ".")
+	if !ok {
+		return constants.ErrInvalidConfirmationToken
+	}
+
+	expiry, err := strconv.ParseInt(rawExpiry, 10, 64)
+	if err != nil {
+		return constants.ErrInvalidConfirmationToken
+	}
+
+	want, err := c.sign(tool, session, args, expiry)
+	if err != nil {
+		return err
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(want)) {
+		return constants.ErrInvalidConfirmationToken
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if now.Unix() > expiry {
+		return constants.ErrInvalidConfirmationToken
+	}
+
+	for key, expires := range c.used {
+		if now.After(expires) {
+			delete(c.used, key)
+		}
+	}
+
+	if _, seen := c.used[mac]; seen {
+		return constants.ErrInvalidConfirmationToken
+	}
+
+	c.used[mac] = time.Unix(expiry, 0)
+
+	return nil
+}
+
+// sign computes the token MAC. Arguments are signed in their JSON encoding,
+// which sorts map keys and so does not depend on the order the client used.
+func (c *Confirmer) sign(tool, session string, args map[string]any, expiry int64) (string, error) {
+	payload, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", constants.ErrInvalidConfirmationToken, err)
+	}
+
+	h := hmac.New(sha256.New, c.secret)
+	fmt.Fprintf(h, "%s\n%s\n%d\n", tool, session, expiry)
+	h.Write(payload)
+
+	return base64.RawURLEncoding.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,85 @@
+package confirm
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestConfirmerVerify(t *testing.T) {
+	args := map[string]any{"amount": 10.5, "currency": "USD", "beneficiary": "bnf_1"}
+
+	tests := []struct {
+		name    string
+		tool    string
+		session string
+		args    map[string]any
+		wait    time.Duration
+		valid   bool
+	}{
+		{"same call", "create_payout_tool", "s1", args, 0, true},
+		{"different tool", "fund_payout_tool", "s1", args, 0, false},
+		{"different session", "create_payout_tool", "s2", args, 0, false},
+		{
+			"different amount", "create_payout_tool", "s1",
+			map[string]any{"amount": 105.0, "currency": "USD", "beneficiary": "bnf_1"}, 0, false,
+		},
+		{"expired", "create_payout_tool", "s1", args, DefaultTTL + time.Second, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, err := New([]byte("secret"), 0)
+			if err != nil {
+				t.Fatalf("New() error: %v", err)
+			}
+
+			now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+			c.now = func() time.Time { return now }
+
+			token, _, err := c.Issue("create_payout_tool", "s1", args)
+			if err != nil {
+				t.Fatalf("Issue() error: %v", err)
+			}
+
+			now = now.Add(test.wait)
+
+			err = c.Verify(token, test.tool, test.session, test.args)
+			if test.valid && err != nil {
+				t.Errorf("Verify() = %v; want valid", err)
+			}
+
+			if !test.valid && !errors.Is(err, constants.ErrInvalidConfirmationToken) {
+				t.Errorf("Verify() = %v; want ErrInvalidConfirmationToken", err)
+			}
+		})
+	}
+}
+
+func TestConfirmerTokenSingleUse(t *testing.T) {
+	c, err := New(nil, time.Minute)
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	args := map[string]any{"id": "pot_1"}
+
+	token, _, err := c.Issue("fund_payout_tool", "", args)
+	if err != nil {
+		t.Fatalf("Issue() error: %v", err)
+	}
+
+	if err := c.Verify(token, "fund_payout_tool", "", args); err != nil {
+		t.Fatalf("first Verify() = %v; want valid", err)
+	}
+
+	if err := c.Verify(token, "fund_payout_tool", "", args); err == nil {
+		t.Error("second Verify() accepted a used token")
+	}
+
+	if err := c.Verify("garbage", "fund_payout_tool", "", args); err == nil {
+		t.Error("Verify() accepted a malformed token")
+	}
+}
@@ -0,0 +1,141 @@
+package confirm
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// errNoElicitation is returned when the client cannot be asked for confirmation directly
+var errNoElicitation = errors.New("client does not support elicitation")
+
+// Middleware returns a tool middleware that holds money-moving calls until the
+// user has confirmed a summary of them. The summary is shown with MCP
+// elicitation when the client supports it. Otherwise the call returns the
+// summary with a confirmation token, and the tool runs when it is called again
+// with the same arguments and the token.
+func Middleware(c *Confirmer, logger *slog.Logger) types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		summarizer, ok := tool.(types.Summarizer)
+		if !ok || !tool.Metadata().MovesFunds {
+			return next
+		}
+
+		name := tool.Definition().Name
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := maps.Clone(req.GetArguments())
+			token, _ := args[constants.ConfirmationTokenField].(string)
+			delete(args, constants.ConfirmationTokenField)
+
+			// a dry run sends nothing, so there is nothing to confirm
+			if utils.IsDryRun(ctx) {
+				req.Params.Arguments = args
+				return next(ctx, req)
+			}
+
+			session := types.SessionID(ctx)
+
+			if token != "" {
+				if err := c.Verify(token, name, session, args); err != nil {
+					logger.WarnContext(ctx, "Rejected confirmation token", "tool", name, constants.KeyError, err)
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+
+				logger.InfoContext(ctx, "Payout confirmed with token", "tool", name)
+				req.Params.Arguments = args
+
+				return next(ctx, req)
+			}
+
+			// the summary validates the call, so no token is issued for a call
+			// the tool would reject
+			summary, err := summarizer.Summarize(ctx, args)
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to summarise payout for confirmation", "tool", name, constants.KeyError, err)
+				return types.NotExecuted(mcp.NewToolResultError(
+					"Invalid payout, nothing was executed or put up for confirmation: " + err.Error())), nil
+			}
+
+			confirmed, err := elicit(ctx, summary)
+			if err == nil {
+				if !confirmed {
+					logger.InfoContext(ctx, "Payout not confirmed by the user", "tool", name)
+					return types.NotExecuted(mcp.NewToolResultText(
+						"The user did not confirm the payout. Nothing was executed.\n\n" + summary.String())), nil
+				}
+
+				logger.InfoContext(ctx, "Payout confirmed with elicitation", "tool", name)
+				req.Params.Arguments = args
+
+				return next(ctx, req)
+			}
+
+			if !errors.Is(err, errNoElicitation) {
+				logger.WarnContext(ctx, "Elicitation failed, falling back to a confirmation token", constants.KeyError, err)
+			}
+
+			token, expires, err := c.Issue(name, session, args)
+			if err != nil {
+				logger.ErrorContext(ctx, "Failed to issue confirmation token", constants.KeyError, err)
+				return nil, err
+			}
+
+			return types.NotExecuted(mcp.NewToolResultText(fmt.Sprintf(
+				"Confirmation required. Nothing has been executed yet.\n\n%s\n\n"+
+					"Show this summary to the user. Only if they explicitly approve it, call %s again "+
+					"with the same arguments and %s set to %q. The token expires at %s.",
+				summary, name, constants.ConfirmationTokenField, token, expires.UTC().Format(time.RFC3339),
+			))), nil
+		}
+	}
+}
+
+// elicit asks the user to confirm the summary through the client
+func elicit(ctx context.Context, summary types.PaymentSummary) (bool, error) {
+	s := server.ServerFromContext(ctx)
+	session, ok := server.ClientSessionFromContext(ctx).(server.SessionWithClientInfo)
+
+	if s == nil || !ok || session.GetClientCapabilities().Elicitation == nil {
+		return false, errNoElicitation
+	}
+
+	result, err := s.RequestElicitation(ctx, mcp.ElicitationRequest{
+		Params: mcp.ElicitationParams{
+			Message: summary.String() + "\n\nDo you want to execute this payout?",
+			RequestedSchema: map[string]any{
+				constants.KeyType: constants.KeyObject,
+				constants.KeyProperties: map[string]any{
+					constants.ConfirmationField: map[string]any{
+						constants.KeyType:        constants.KeyBoolean,
+						"title":                  "Execute payout",
+						constants.KeyDescription: "Tick to send the money as summarised",
+					},
+				},
+				"required": []string{constants.ConfirmationField},
+			},
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+
+	if result.Action != mcp.ElicitationResponseActionAccept {
+		return false, nil
+	}
+
+	content, _ := result.Content.(map[string]any)
+	confirmed, _ := content[constants.ConfirmationField].(bool)
+
+	return confirmed, nil
+}
@@ -0,0 +1,186 @@
+// Package corridor describes the bank details Tazapay requires for a payout
+// corridor, a destination country and currency paid by local transfer or SWIFT,
+// and checks beneficiary bank details against them.
+package corridor
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+)
+
+// Transfer types
+const (
+	TransferLocal = "local"
+	TransferSwift = "swift"
+)
+
+// localCodes are the bank codes of domestic clearing systems
+var localCodes = []string{ifscCode, abaCode, sortCode, bsbCode, cnaps}
+
+// Field is a bank detail of a corridor
+type Field struct {
+	// Paths inside destination_details.bank, any one of which satisfies the field
+	Paths       []string `json:"paths"`
+	Description string   `json:"description"`
+	// Format is the regular expression the value must match, if any
+	Format  string `json:"format,omitempty"`
+	Example string `json:"example,omitempty"`
+}
+
+// Name is how the field is named in messages
+func (f Field) Name() string {
+	return strings.Join(f.Paths, " or ")
+}
+
+// Rule lists the bank details of a corridor. An empty country or currency
+// matches any.
+type Rule struct {
+	Country  string  `json:"country,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	Transfer string  `json:"transfer_type"`
+	Required []Field `json:"required"`
+	Optional []Field `json:"optional,omitempty"`
+}
+
+// formats caches the compiled field formats
+var formats = map[string]*regexp.Regexp{}
+
+func init() {
+	for _, rule := range Rules {
+		for _, field := range slices.Concat(rule.Required, rule.Optional) {
+			if field.Format != "" {
+				formats[field.Format] = regexp.MustCompile(field.Format)
+			}
+		}
+	}
+}
+
+// Lookup returns the rule of the corridor, preferring the most specific one
+func Lookup(country, currency, transfer string) (Rule, bool) {
+	country, currency = strings.ToUpper(country), strings.ToUpper(currency)
+
+	for _, key := range [][2]string{{country, currency}, {"", currency}, {"", ""}} {
+		for _, rule := range Rules {
+			if rule.Country == key[0] && rule.Currency == key[1] && rule.Transfer == transfer {
+				return rule, true
+			}
+		}
+	}
+
+	return Rule{}, false
+}
+
+// TransferOf infers the transfer type of bank details when the caller gives
+// none: SWIFT when a SWIFT or BIC code is given without any domestic bank code,
+// local otherwise
+func TransferOf(bank map[string]any) string {
+	if value(bank, swiftCode) == "" && value(bank, bicCode) == "" {
+		return TransferLocal
+	}
+
+	for _, path := range localCodes {
+		if value(bank, path) != "" {
+			return TransferLocal
+		}
+	}
+
+	return TransferSwift
+}
+
+// Validate checks destination_details.bank against the rule of its corridor
+// for the transfer type, inferred from the bank details when empty. Bank codes
+// are expected under bank_codes. Details without a country and a currency, or
+// of a corridor without a rule, are not checked.
+func Validate(bank map[string]any, transfer string) error {
+	country, _ := bank[constants.KeyCountry].(string)
+	currency, _ := bank[constants.KeyCurrency].(string)
+
+	if country == "" || currency == "" {
+		return nil
+	}
+
+	if transfer == "" {
+		transfer = TransferOf(bank)
+	}
+
+	rule, ok := Lookup(country, currency, transfer)
+	if !ok {
+		return nil
+	}
+
+	var problems []string
+
+	for _, field := range rule.Required {
+		if _, found := lookupField(bank, field); !found {
+			problems = append(problems, "missing "+describe(field))
+		}
+	}
+
+	for _, field := range slices.Concat(rule.Required, rule.Optional) {
+		if v, found := lookupField(bank, field); found && field.Format != "" && !formats[field.Format].MatchString(v) {
+			problems = append(problems, fmt.Sprintf("invalid %s %q, expected %s", field.Name(), v, describeFormat(field)))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w for %s %s %s transfers: %s. Call %s for the full list", constants.ErrCorridorRequirements,
+		strings.ToUpper(country), strings.ToUpper(currency), transfer, strings.Join(problems, "; "),
+		toolname.Name(constants.DescribeRequirementsToolName))
+}
+
+// lookupField returns the normalized value of the first path of the field that is set
+func lookupField(bank map[string]any, field Field) (string, bool) {
+	for _, path := range field.Paths {
+		if v := value(bank, path); v != "" {
+			return v, true
+		}
+	}
+
+	return "", false
+}
+
+// value reads a dotted path of the bank details as an upper case string
+// without spaces
+func value(bank map[string]any, path string) string {
+	var current any = bank
+
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		current = m[key]
+	}
+
+	switch v := current.(type) {
+	case string:
+		return strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(v), " ", ""))
+	case bool, float64:
+		return fmt.Sprint(v)
+	default:
+		return ""
+	}
+}
+
+// describe names a field with its description
+func describe(field Field) string {
+	return fmt.Sprintf("%s (%s)", field.Name(), field.Description)
+}
+
+// describeFormat explains the expected value of a field
+func describeFormat(field Field) string {
+	if field.Example != "" {
+		return field.Description + ", e.g. " + field.Example
+	}
+
+	return field.Description
+}
@@ -0,0 +1,95 @@
+package corridor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestLookup(t *testing.T) {
+	tests := []struct {
+		country, currency, transfer string
+		wantCountry, wantCurrency   string
+	}{
+		{"in", "inr", TransferLocal, "IN", "INR"},
+		{"FR", "EUR", TransferLocal, "", "EUR"},
+		{"SG", "SGD", TransferSwift, "", ""},
+		{"IN", "INR", TransferSwift, "IN", "INR"},
+	}
+
+	for _, test := range tests {
+		rule, ok := Lookup(test.country, test.currency, test.transfer)
+		if !ok || rule.Country != test.wantCountry || rule.Currency != test.wantCurrency || rule.Transfer != test.transfer {
+			t.Errorf("Lookup(%s, %s, %s) = %+v, %v", test.country, test.currency, test.transfer, rule, ok)
+		}
+	}
+
+	if _, ok := Lookup("SG", "SGD", TransferLocal); ok {
+		t.Error("Lookup(SG, SGD, local) found a rule; want none")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		bank     map[string]any
+		transfer string
+		want     []string // substrings of the error, none for valid details
+	}{
+		{"valid inr", map[string]any{"country": "IN", "currency": "INR", "account_number": "50100012345678",
+			"purpose_code": "p0802", "bank_codes": map[string]any{"ifsc_code": "hdfc0001234"}}, "", nil},
+		{"inr missing ifsc and purpose", map[string]any{"country": "IN", "currency": "INR",
+			"account_number": "50100012345678"}, "", []string{"missing bank_codes.ifsc_code", "missing purpose_code"}},
+		{"bad aba", map[string]any{"country": "US", "currency": "USD", "account_number": "000123456789",
+			"bank_codes": map[string]any{"aba_code": "12345"}}, "", []string{"invalid bank_codes.aba_code"}},
+		{"gbp sort code with dashes", map[string]any{"country": "GB", "currency": "GBP", "account_number": "31926819",
+			"bank_codes": map[string]any{"sort_code": "60-16-13"}}, "", nil},
+		{"aud missing bsb", map[string]any{"country": "AU", "currency": "AUD", "account_number": "123456"},
+			"", []string{"missing bank_codes.bsb_code"}},
+		{"eur needs iban", map[string]any{"country": "DE", "currency": "EUR", "account_number": "0532013000"},
+			"", []string{"missing iban"}},
+		{"eur iban with spaces", map[string]any{"country": "DE", "currency": "EUR",
+			"iban": "DE89 3704 0044 0532 0130 00"}, "", nil},
+		{"bad cnaps", map[string]any{"country": "CN", "currency": "CNY", "account_number": "6222020200112233445",
+			"bank_codes": map[string]any{"cnaps": "1021"}}, "", []string{"invalid bank_codes.cnaps"}},
+		{"swift", map[string]any{"country": "SG", "currency": "USD", "account_number": "123",
+			"bank_codes": map[string]any{"swift_code": "DBSSSGSG"}}, "", nil},
+		{"swift inr needs purpose", map[string]any{"country": "IN", "currency": "INR", "account_number": "123",
+			"bank_codes": map[string]any{"bic_code": "HDFCINBB"}}, "", []string{"swift transfers", "missing purpose_code"}},
+		{"no rule", map[string]any{"country": "SG", "currency": "SGD", "account_number": "123"}, "", nil},
+		{"no country", map[string]any{"currency": "INR"}, "", nil},
+		{"explicit swift ignores the local code", map[string]any{"country": "IN", "currency": "INR",
+			"account_number": "123", "bank_codes": map[string]any{"ifsc_code": "HDFC0001234"}}, "swift",
+			[]string{"swift transfers", "missing purpose_code"}},
+		{"explicit local ignores the swift code", map[string]any{"country": "US", "currency": "USD",
+			"account_number": "000123456789", "bank_codes": map[string]any{"swift_code": "CHASUS33"}}, "local",
+			[]string{"local transfers", "missing bank_codes.aba_code"}},
+		{"wallet has no rule", map[string]any{"country": "IN", "currency": "INR", "account_number": "123"},
+			"wallet", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := Validate(test.bank, test.transfer)
+			if test.want == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v; want nil", err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, constants.ErrCorridorRequirements) {
+				t.Fatalf("Validate() = %v; want ErrCorridorRequirements", err)
+			}
+
+			for _, want := range test.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %q; want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package corridor
+
+// Field paths inside destination_details.bank
+const (
+	accountNumber = "account_number"
+	iban          = "iban"
+	purposeCode   = "purpose_code"
+	accountType   = "account_type"
+	fircRequired  = "firc_required"
+	swiftCode     = "bank_codes.swift_code"
+	bicCode       = "bank_codes.bic_code"
+	ifscCode      = "bank_codes.ifsc_code"
+	abaCode       = "bank_codes.aba_code"
+	sortCode      = "bank_codes.sort_code"
+	bsbCode       = "bank_codes.bsb_code"
+	cnaps         = "bank_codes.cnaps"
+)
+
+// Fields shared by several rules
+var (
+	swiftField = Field{
+		Paths:       []string{swiftCode, bicCode},
+		Description: "SWIFT/BIC code of the bank, 8 or 11 characters",
+		Format:      `^[A-Z]{6}[A-Z0-9]{2}([A-Z0-9]{3})?$`,
+		Example:     "HDFCINBBXXX",
+	}
+	accountOrIBANField = Field{
+		Paths:       []string{accountNumber, iban},
+		Description: "Bank account number, or IBAN where the destination country uses IBANs",
+	}
+	inrPurposeField = Field{
+		Paths:       []string{purposeCode},
+		Description: "RBI purpose code of the remittance, a P followed by four digits",
+		Format:      `^P[0-9]{4}$`,
+		Example:     "P0802",
+	}
+	fircField = Field{
+		Paths:       []string{fircRequired},
+		Description: "Set to true when the beneficiary needs a Foreign Inward Remittance Certificate",
+	}
+)
+
+// Rules is the corridor rule table. Lookup prefers a rule for the exact
+// country, then one for any country with the currency, then the catch-all
+// rule of the transfer type.
+var Rules = []Rule{
+	{
+		Country: "IN", Currency: "INR", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{accountNumber}, Description: "Bank account number, 9 to 18 digits",
+				Format: `^[0-9]{9,18}$`, Example: "50100012345678"},
+			{Paths: []string{ifscCode}, Description: "Indian Financial System Code of the branch, 11 characters",
+				Format: `^[A-Z]{4}0[A-Z0-9]{6}$`, Example: "HDFC0001234"},
+			inrPurposeField,
+		},
+		Optional: []Field{fircField},
+	},
+	{
+		Country: "IN", Currency: "INR", Transfer: TransferSwift,
+		Required: []Field{swiftField, accountOrIBANField, inrPurposeField},
+		Optional: []Field{fircField},
+	},
+	{
+		Country: "US", Currency: "USD", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{accountNumber}, Description: "Bank account number, 4 to 17 digits",
+				Format: `^[0-9]{4,17}$`, Example: "000123456789"},
+			{Paths: []string{abaCode}, Description: "ABA routing number, 9 digits",
+				Format: `^[0-9]{9}$`, Example: "021000021"},
+		},
+		Optional: []Field{
+			{Paths: []string{accountType}, Description: "checking or savings"},
+		},
+	},
+	{
+		Country: "GB", Currency: "GBP", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{accountNumber}, Description: "Bank account number, 8 digits",
+				Format: `^[0-9]{8}$`, Example: "31926819"},
+			{Paths: []string{sortCode}, Description: "Sort code, 6 digits",
+				Format: `^[0-9]{2}-?[0-9]{2}-?[0-9]{2}$`, Example: "601613"},
+		},
+		Optional: []Field{
+			{Paths: []string{iban}, Description: "IBAN of the account", Format: ibanFormat, Example: "GB29NWBK60161331926819"},
+		},
+	},
+	{
+		Country: "AU", Currency: "AUD", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{accountNumber}, Description: "Bank account number, 5 to 9 digits",
+				Format: `^[0-9]{5,9}$`, Example: "123456789"},
+			{Paths: []string{bsbCode}, Description: "Bank State Branch code, 6 digits",
+				Format: `^[0-9]{3}-?[0-9]{3}$`, Example: "062000"},
+		},
+	},
+	{
+		Currency: "EUR", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{iban}, Description: "IBAN of the account", Format: ibanFormat,
+				Example: "DE89370400440532013000"},
+		},
+		Optional: []Field{
+			{Paths: []string{bicCode, swiftCode}, Description: "BIC of the bank, 8 or 11 characters",
+				Format: swiftField.Format, Example: "COBADEFFXXX"},
+		},
+	},
+	{
+		Country: "CN", Currency: "CNY", Transfer: TransferLocal,
+		Required: []Field{
+			{Paths: []string{accountNumber}, Description: "Bank account or card number, 8 to 30 digits",
+				Format: `^[0-9]{8,30}$`, Example: "6222020200112233445"},
+			{Paths: []string{cnaps}, Description: "CNAPS code of the branch, 12 digits",
+				Format: `^[0-9]{12}$`, Example: "102100099996"},
+		},
+	},
+	{
+		Transfer: TransferSwift,
+		Required: []Field{swiftField, accountOrIBANField},
+	},
+}
+
+// ibanFormat is the shape of an IBAN: country, check digits and account
+const ibanFormat = `^[A-Z]{2}[0-9]{2}[A-Z0-9]{11,30}$`
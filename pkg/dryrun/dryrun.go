@@ -0,0 +1,84 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"maps"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// WithParameter adds the dry_run argument to the input schema of a mutating tool
+func WithParameter(def mcp.Tool) mcp.Tool {
+	if def.RawInputSchema != nil {
+		return def
+	}
+
+	properties := maps.Clone(def.InputSchema.Properties)
+	if properties == nil {
+		properties = make(map[string]any)
+	}
+
+	properties[constants.DryRunField] = map[string]any{
+		constants.KeyType:        constants.KeyBoolean,
+		constants.KeyDescription: constants.DryRunDesc,
+	}
+	def.InputSchema.Properties = properties
+
+	return def
+}
+
+// Middleware returns a tool middleware that runs mutating tools in dry-run mode
+// when they are called with dry_run set, or always when forced is set. The tool
+// runs its validation and builds its payload as usual, but the request to
+// Tazapay is returned to the caller, with account numbers masked, instead of
+// being sent.
+func Middleware(forced bool, logger *slog.Logger) types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		if tool.Metadata().ReadOnly {
+			return next
+		}
+
+		name := tool.Definition().Name
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+
+			requested, _ := args[constants.DryRunField].(bool)
+			if _, ok := args[constants.DryRunField]; ok {
+				args = maps.Clone(args)
+				delete(args, constants.DryRunField)
+				req.Params.Arguments = args
+			}
+
+			if !requested && !forced {
+				return next(ctx, req)
+			}
+
+			ctx, run := utils.WithDryRun(ctx)
+
+			result, err := next(ctx, req)
+
+			requests := run.Requests()
+			if len(requests) == 0 {
+				// validation failed before any request was built
+				return result, err
+			}
+
+			body, marshalErr := json.MarshalIndent(requests, "", "  ")
+			if marshalErr != nil {
+				return nil, marshalErr
+			}
+
+			logger.InfoContext(ctx, "Dry run completed", "tool", name, "requests", len(requests))
+
+			return types.NotExecuted(mcp.NewToolResultText(
+				"Dry run, nothing was sent to Tazapay. The tool would have sent:\n" + string(body))), nil
+		}
+	}
+}
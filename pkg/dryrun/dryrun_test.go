@@ -0,0 +1,186 @@
+package dryrun
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// payload is what the test tool sends to Tazapay
+var payload = map[string]any{
+	"amount":   100,
+	"currency": "USD",
+	"bank":     map[string]any{"account_number": "123456789012"},
+	"beneficiary_details": map[string]any{
+		"name":    "Jane Doe",
+		"email":   "jane.doe@example.com",
+		"phone":   map[string]any{"calling_code": "65", "number": "91234567"},
+		"address": map[string]any{"line1": "1 Raffles Place", "city": "Singapore"},
+	},
+}
+
+// testTool sends one request with the given method to url
+type testTool struct {
+	method   string
+	url      string
+	readOnly bool
+	// args are the arguments the tool received
+	args map[string]any
+}
+
+func (t *testTool) Definition() mcp.Tool {
+	return mcp.NewTool("test_tool")
+}
+
+func (t *testTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{ReadOnly: t.readOnly}
+}
+
+func (t *testTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	t.args = req.GetArguments()
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	var err error
+
+	switch t.method {
+	case http.MethodGet:
+		_, err = utils.HandleGETHttpRequest(ctx, logger, t.url, t.method)
+	case http.MethodPut:
+		_, err = utils.HandlePUTHttpRequest(ctx, logger, t.url, payload, t.method)
+	case http.MethodDelete:
+		_, err = utils.HandleDELETEHttpRequest(ctx, logger, t.url, t.method)
+	default:
+		_, err = utils.HandlePOSTHttpRequest(ctx, logger, t.url, payload, t.method)
+	}
+
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	return mcp.NewToolResultText("sent"), nil
+}
+
+// tazapay counts the requests reaching it
+func tazapay(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+
+	var hits atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		_, _ = io.WriteString(w, `{"data":{"id":"pot_1"}}`)
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &hits
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		forced   bool
+		args     map[string]any
+		readOnly bool
+		wantSent bool
+	}{
+		{"forced blocks POST", http.MethodPost, true, nil, false, false},
+		{"forced blocks PUT", http.MethodPut, true, nil, false, false},
+		{"forced blocks DELETE", http.MethodDelete, true, nil, false, false},
+		{"forced ignores dry_run false", http.MethodPost, true, map[string]any{"dry_run": false}, false, false},
+		{"forced lets GET through", http.MethodGet, true, nil, false, true},
+		{"dry_run argument blocks POST", http.MethodPost, false, map[string]any{"dry_run": true}, false, false},
+		{"dry_run false sends", http.MethodPost, false, map[string]any{"dry_run": false}, false, true},
+		{"no dry run sends", http.MethodPost, false, nil, false, true},
+		{"read-only tools are not wrapped", http.MethodGet, true, nil, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			srv, hits := tazapay(t)
+
+			tool := &testTool{method: test.method, url: srv.URL + "/v3/payout", readOnly: test.readOnly}
+			handler := Middleware(test.forced, slog.New(slog.NewTextHandler(io.Discard, nil)))(tool, tool.Handle)
+
+			req := mcp.CallToolRequest{}
+			req.Params.Arguments = test.args
+
+			result, err := handler(context.Background(), req)
+			if err != nil {
+				t.Fatalf("handler() error: %v", err)
+			}
+
+			if sent := hits.Load() > 0; sent != test.wantSent {
+				t.Errorf("request sent = %v; want %v", sent, test.wantSent)
+			}
+
+			if test.wantSent == types.IsNotExecuted(result) {
+				t.Errorf("IsNotExecuted() = %v; want %v", types.IsNotExecuted(result), !test.wantSent)
+			}
+
+			if _, ok := tool.args[constants.DryRunField]; ok {
+				t.Errorf("tool received %s; want it removed", constants.DryRunField)
+			}
+		})
+	}
+}
+
+func TestMiddlewarePlan(t *testing.T) {
+	srv, hits := tazapay(t)
+
+	tool := &testTool{method: http.MethodPost, url: srv.URL + "/v3/payout"}
+	handler := Middleware(true, slog.New(slog.NewTextHandler(io.Discard, nil)))(tool, tool.Handle)
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if hits.Load() != 0 {
+		t.Fatalf("%d requests reached Tazapay; want none", hits.Load())
+	}
+
+	text := result.Content[0].(mcp.TextContent).Text
+
+	_, plan, ok := strings.Cut(text, "\n")
+	if !ok {
+		t.Fatalf("result %q has no plan", text)
+	}
+
+	var requests []utils.DryRunRequest
+	if err := json.Unmarshal([]byte(plan), &requests); err != nil {
+		t.Fatalf("plan is not a request list: %v\n%s", err, plan)
+	}
+
+	if len(requests) != 1 {
+		t.Fatalf("plan has %d requests; want 1", len(requests))
+	}
+
+	got := requests[0]
+	if got.Method != http.MethodPost || got.URL != tool.url {
+		t.Errorf("plan request = %s %s; want POST %s", got.Method, got.URL, tool.url)
+	}
+
+	body, _ := json.Marshal(got.Payload)
+	if !strings.Contains(string(body), `"currency":"USD"`) {
+		t.Errorf("plan payload = %s; want the payload sent by the tool", body)
+	}
+
+	for _, sensitive := range []string{"123456789012", "Jane Doe", "jane.doe", "91234567", "Raffles", "Singapore"} {
+		if strings.Contains(string(body), sensitive) {
+			t.Errorf("plan payload = %s; want %q masked", body, sensitive)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+// Package endpoint turns Tazapay API paths into endpoint templates, used to
+// label metrics and name spans without one value per object.
+package endpoint
+
+import "strings"
+
+// idResources are the API resources whose next path segment is an object ID
+var idResources = map[string]bool{
+	"payout": true, "payin": true, "beneficiary": true, "checkout": true,
+	"customer": true, "payment_attempt": true,
+}
+
+// Template returns the path with object IDs replaced by {id}, e.g.
+// /v3/payout/pot_123/fund gives /v3/payout/{id}/fund
+func Template(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i := 1; i < len(segments); i++ {
+		if idResources[segments[i-1]] && segments[i] != "" {
+			segments[i] = "{id}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,23 @@
+package endpoint
+
+import "testing"
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/v3/payout", "/v3/payout"},
+		{"/v3/payout/pot_123", "/v3/payout/{id}"},
+		{"/v3/payout/pot_123/fund", "/v3/payout/{id}/fund"},
+		{"/v3/payment_attempt/pat_9", "/v3/payment_attempt/{id}"},
+		{"/v3/fx/payout", "/v3/fx/payout"},
+		{"/v3/balance", "/v3/balance"},
+	}
+
+	for _, test := range tests {
+		if got := Template(test.path); got != test.want {
+			t.Errorf("Template(%q) = %q; want %q", test.path, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,198 @@
+// Package health serves the liveness, readiness and version probes of the
+// streamable HTTP server.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/version"
+)
+
+const (
+	// probeTimeout bounds the Tazapay call made by the readiness probe
+	probeTimeout = 5 * time.Second
+	// probeTTL is how long a Tazapay probe result is reused, so frequent
+	// readiness probes do not turn into API traffic
+	probeTTL = 30 * time.Second
+)
+
+// Status values of the probe responses
+const (
+	statusOK       = "ok"
+	statusReady    = "ready"
+	statusNotReady = "not ready"
+	statusSkipped  = "skipped"
+)
+
+// Check validates a part of the server configuration
+type Check func() error
+
+// Checker answers the health, readiness and version probes
+type Checker struct {
+	// tools returns the number of registered tools
+	tools func() int
+	// config validates the configuration on readiness
+	config []Check
+	// probe enables the authenticated Tazapay call on readiness
+	probe bool
+	url   string
+	// token is the server's own auth token, never a per-request one
+	token  string
+	client *http.Client
+	now    func() time.Time
+
+	mu      sync.Mutex
+	checked time.Time
+	lastErr error
+}
+
+// New returns a checker reporting the tool count given by tools. Readiness
+// requires every check to pass and, when probe is set, a cheap call to Tazapay
+// authenticated with token to succeed.
+func New(tools func() int, token string, probe bool, checks ...Check) *Checker {
+	return &Checker{
+		tools:  tools,
+		config: checks,
+		probe:  probe,
+		url:    constants.BalanceBaseURLProd,
+		token:  token,
+		client: &http.Client{
+			Timeout: probeTimeout,
+		},
+		now: time.Now,
+	}
+}
+
+// NewFromViper returns a checker whose Tazapay probe, enabled by
+// TAZAPAY_READINESS_CHECK, authenticates with the configured API credentials
+func NewFromViper(tools func() int, checks ...Check) *Checker {
+	token, _ := utils.ConfiguredAuthToken()
+
+	return New(tools, token, viper.GetBool(constants.StrTAZAPAYReadinessCheck), checks...)
+}
+
+// Register serves the probes on mux
+func (c *Checker) Register(mux *http.ServeMux) {
+	mux.HandleFunc("GET "+constants.HealthzPath, c.healthz)
+	mux.HandleFunc("GET "+constants.ReadyzPath, c.readyz)
+	mux.HandleFunc("GET "+constants.VersionPath, c.version)
+}
+
+// healthz reports that the process is alive and serving requests
+func (c *Checker) healthz(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": statusOK})
+}
+
+// readyz reports whether the server can take traffic: the configuration is
+// valid with tools registered and, when enabled, Tazapay accepts the credentials
+func (c *Checker) readyz(w http.ResponseWriter, r *http.Request) {
+	checks := map[string]string{"config": statusOK, "tazapay": statusSkipped}
+	ready := true
+
+	if err := c.checkConfig(); err != nil {
+		checks["config"] = err.Error()
+		ready = false
+	}
+
+	if c.probe {
+		checks["tazapay"] = statusOK
+
+		if err := c.checkTazapay(r.Context()); err != nil {
+			checks["tazapay"] = err.Error()
+			ready = false
+		}
+	}
+
+	status, code := statusReady, http.StatusOK
+	if !ready {
+		status, code = statusNotReady, http.StatusServiceUnavailable
+	}
+
+	writeJSON(w, code, map[string]any{"status": status, "checks": checks})
+}
+
+// version reports the build version, commit and the number of registered tools
+func (c *Checker) version(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, struct {
+		version.Info
+		Tools int `json:"tools"`
+	}{version.Get(), c.tools()})
+}
+
+// checkConfig runs the configuration checks and requires tools to be registered
+func (c *Checker) checkConfig() error {
+	errs := make([]error, 0, len(c.config)+1)
+
+	if c.tools() == 0 {
+		errs = append(errs, constants.ErrNoToolsRegistered)
+	}
+
+	for _, check := range c.config {
+		errs = append(errs, check())
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkTazapay fetches the balance with the configured credentials, reusing
+// the last result for probeTTL
+func (c *Checker) checkTazapay(ctx context.Context) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.checked.IsZero() && c.now().Sub(c.checked) < probeTTL {
+		return c.lastErr
+	}
+
+	c.lastErr = c.callTazapay(ctx)
+	c.checked = c.now()
+
+	return c.lastErr
+}
+
+// callTazapay makes the authenticated GET request, only looking at the status code
+func (c *Checker) callTazapay(ctx context.Context) error {
+	if c.token == "" {
+		return constants.ErrMissingAuthKeys
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url, http.NoBody)
+	if err != nil {
+		return fmt.Errorf(constants.StrErrorCreatingRequest, err)
+	}
+
+	req.Header.Set(constants.HeaderAccept, constants.AcceptJSON)
+	req.Header.Set(constants.HeaderAuthorization, constants.AuthSchemeBasic+c.token)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return fmt.Errorf(constants.StrErrorMakingRequest, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < constants.HTTPStatusOKMin || resp.StatusCode >= constants.HTTPStatusOKMax {
+		return fmt.Errorf("%w: %s", constants.ErrNonSuccessStatus, resp.Status)
+	}
+
+	return nil
+}
+
+// writeJSON writes v as the JSON response body
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,137 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+func TestReadyz(t *testing.T) {
+	invalid := func() error { return constants.ErrInvalidTLSConfig }
+
+	tests := []struct {
+		name     string
+		tools    int
+		checks   []Check
+		probe    bool
+		token    string
+		upstream int
+		want     int
+	}{
+		{"ready without probe", 5, nil, false, "", 0, http.StatusOK},
+		{"no tools registered", 0, nil, false, "", 0, http.StatusServiceUnavailable},
+		{"config checks pass", 5, []Check{func() error { return nil }}, false, "", 0, http.StatusOK},
+		{"invalid config", 5, []Check{func() error { return nil }, invalid}, false, "", 0, http.StatusServiceUnavailable},
+		{"probe succeeds", 5, nil, true, "token", http.StatusOK, http.StatusOK},
+		{"probe refused", 5, nil, true, "token", http.StatusUnauthorized, http.StatusServiceUnavailable},
+		{"probe without token", 5, nil, true, "", http.StatusOK, http.StatusServiceUnavailable},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Header.Get(constants.HeaderAuthorization) != constants.AuthSchemeBasic+test.token {
+					t.Errorf("probe sent authorization %q", r.Header.Get(constants.HeaderAuthorization))
+				}
+				w.WriteHeader(test.upstream)
+			}))
+			defer upstream.Close()
+
+			c := New(func() int { return test.tools }, test.token, test.probe, test.checks...)
+			c.url = upstream.URL
+
+			if got := serve(t, c, constants.ReadyzPath); got.Code != test.want {
+				t.Errorf("GET /readyz = %d %s; want %d", got.Code, got.Body, test.want)
+			}
+		})
+	}
+}
+
+func TestReadyzReusesProbe(t *testing.T) {
+	calls := 0
+	upstream := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) { calls++ }))
+	defer upstream.Close()
+
+	now := time.Unix(0, 0)
+	c := New(func() int { return 1 }, "token", true)
+	c.url = upstream.URL
+	c.now = func() time.Time { return now }
+
+	serve(t, c, constants.ReadyzPath)
+	serve(t, c, constants.ReadyzPath)
+
+	now = now.Add(probeTTL)
+	serve(t, c, constants.ReadyzPath)
+
+	if calls != 2 {
+		t.Errorf("Tazapay called %d times; want 2", calls)
+	}
+}
+
+func TestReadyzUsesServerCredentials(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(constants.HeaderAuthorization) != constants.AuthSchemeBasic+"server" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	defer upstream.Close()
+
+	c := New(func() int { return 1 }, "server", true)
+	c.url = upstream.URL
+
+	mux := http.NewServeMux()
+	c.Register(mux)
+
+	// the credentials of a client request must not reach the probe
+	req := httptest.NewRequestWithContext(utils.WithAuthToken(context.Background(), "client"),
+		http.MethodGet, constants.ReadyzPath, http.NoBody)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("GET /readyz = %d %s; want 200", rec.Code, rec.Body)
+	}
+}
+
+func TestVersion(t *testing.T) {
+	got := serve(t, New(func() int { return 27 }, "", false), constants.VersionPath)
+
+	var body struct {
+		Version string `json:"version"`
+		Commit  string `json:"commit"`
+		Tools   int    `json:"tools"`
+	}
+	if err := json.Unmarshal(got.Body.Bytes(), &body); err != nil {
+		t.Fatal(err)
+	}
+
+	if body.Version == "" || body.Commit == "" || body.Tools != 27 {
+		t.Errorf("GET /version = %s", got.Body)
+	}
+}
+
+func TestHealthz(t *testing.T) {
+	if got := serve(t, New(func() int { return 0 }, "", true), constants.HealthzPath); got.Code != http.StatusOK {
+		t.Errorf("GET /healthz = %d; want 200", got.Code)
+	}
+}
+
+// serve sends a GET request for path to the checker's probes
+func serve(t *testing.T, c *Checker, path string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	c.Register(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, http.NoBody))
+
+	return rec
+}
@@ -0,0 +1,110 @@
+// Package idempotency remembers the objects created with an idempotency key, so
+// that a retried call resumes the object instead of creating another one.
+package idempotency
+
+import (
+	"sync"
+	"time"
+)
+
+// Defaults of the cache used by the tools
+const (
+	// DefaultTTL covers the retries of a call, not a later deliberate repeat
+	DefaultTTL = time.Hour
+	// DefaultSize bounds the memory held by the cache
+	DefaultSize = 1000
+)
+
+// entry is an object created with a key
+type entry struct {
+	id string
+	at time.Time
+}
+
+// Cache maps idempotency keys to object IDs for ttl, keeping at most size keys.
+// It is safe for concurrent use.
+type Cache struct {
+	now     func() time.Time
+	entries map[string]entry
+	ttl     time.Duration
+	size    int
+	mu      sync.Mutex
+}
+
+// New returns a cache forgetting keys after ttl and evicting the oldest key
+// once size keys are held
+func New(ttl time.Duration, size int) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	if size <= 0 {
+		size = DefaultSize
+	}
+
+	return &Cache{now: time.Now, entries: make(map[string]entry), ttl: ttl, size: size}
+}
+
+// Get returns the ID of the object created with key, if it was stored less than ttl ago
+func (c *Cache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+
+	if c.now().Sub(e.at) >= c.ttl {
+		delete(c.entries, key)
+		return "", false
+	}
+
+	return e.id, true
+}
+
+// Put records the ID of the object created with key
+func (c *Cache) Put(key, id string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict(now)
+	}
+
+	c.entries[key] = entry{id: id, at: now}
+}
+
+// Len returns the number of keys held, expired ones included
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
+
+// evict drops the expired keys, or the oldest key when none has expired.
+// Callers must hold c.mu.
+func (c *Cache) evict(now time.Time) {
+	var (
+		oldest   string
+		oldestAt time.Time
+	)
+
+	for key, e := range c.entries {
+		if now.Sub(e.at) >= c.ttl {
+			delete(c.entries, key)
+			continue
+		}
+
+		if oldest == "" || e.at.Before(oldestAt) {
+			oldest, oldestAt = key, e.at
+		}
+	}
+
+	if len(c.entries) >= c.size {
+		delete(c.entries, oldest)
+	}
+}
@@ -0,0 +1,66 @@
+package idempotency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCache(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache := New(time.Hour, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("a", "pot_a")
+	now = now.Add(time.Minute)
+	cache.Put("b", "pot_b")
+
+	if id, ok := cache.Get("a"); !ok || id != "pot_a" {
+		t.Errorf("Get(a) = %q, %v; want pot_a", id, ok)
+	}
+
+	// the cache is full, the oldest key goes
+	now = now.Add(time.Minute)
+	cache.Put("c", "pot_c")
+
+	tests := []struct {
+		key  string
+		want string
+		ok   bool
+	}{
+		{"a", "", false},
+		{"b", "pot_b", true},
+		{"c", "pot_c", true},
+	}
+
+	for _, test := range tests {
+		if id, ok := cache.Get(test.key); ok != test.ok || id != test.want {
+			t.Errorf("Get(%s) = %q, %v; want %q, %v", test.key, id, ok, test.want, test.ok)
+		}
+	}
+
+	if n := cache.Len(); n != 2 {
+		t.Errorf("Len() = %d; want 2", n)
+	}
+
+	now = now.Add(time.Hour)
+	if id, ok := cache.Get("c"); ok {
+		t.Errorf("Get(c) after the TTL = %q; want expired", id)
+	}
+}
+
+func TestCacheEvictsExpiredFirst(t *testing.T) {
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cache := New(time.Hour, 2)
+	cache.now = func() time.Time { return now }
+
+	cache.Put("old", "pot_old")
+	now = now.Add(2 * time.Hour)
+	cache.Put("b", "pot_b")
+	cache.Put("c", "pot_c")
+
+	if _, ok := cache.Get("b"); !ok {
+		t.Error("Get(b) = missing; want the expired key evicted instead")
+	}
+}
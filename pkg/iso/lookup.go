@@ -0,0 +1,181 @@
+package iso
+
+import (
+	"slices"
+	"strings"
+)
+
+// maxSuggestions bounds the codes suggested for a typo
+const maxSuggestions = 3
+
+// LookupCountry returns the country of an alpha-2 code, in any case
+func LookupCountry(code string) (Country, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	i, found := slices.BinarySearchFunc(Countries, code, func(c Country, code string) int {
+		return strings.Compare(c.Code, code)
+	})
+
+	if !found {
+		return Country{}, false
+	}
+
+	return Countries[i], true
+}
+
+// LookupCurrency returns the currency of an ISO 4217 code, in any case
+func LookupCurrency(code string) (Currency, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	i, found := slices.BinarySearchFunc(Currencies, code, func(c Currency, code string) int {
+		return strings.Compare(c.Code, code)
+	})
+
+	if !found {
+		return Currency{}, false
+	}
+
+	return Currencies[i], true
+}
+
+// SuggestCountry returns the closest country codes to an invalid one: the code
+// of a country named s, or codes one typo away
+func SuggestCountry(s string) []string {
+	codes := make([]string, len(Countries))
+	for i, c := range Countries {
+		if strings.EqualFold(c.Name, strings.TrimSpace(s)) {
+			return []string{c.Code}
+		}
+
+		codes[i] = c.Code
+	}
+
+	return closest(s, codes)
+}
+
+// SuggestCurrency returns the closest currency codes to an invalid one: the
+// code of a currency named s, or codes one typo away
+func SuggestCurrency(s string) []string {
+	codes := make([]string, len(Currencies))
+	for i, c := range Currencies {
+		if strings.EqualFold(c.Name, strings.TrimSpace(s)) {
+			return []string{c.Code}
+		}
+
+		codes[i] = c.Code
+	}
+
+	return closest(s, codes)
+}
+
+// SearchCountries returns the countries whose code is query or whose name
+// contains every word of query
+func SearchCountries(query string) []Country {
+	out := make([]Country, 0)
+	for _, c := range Countries {
+		if strings.EqualFold(c.Code, strings.TrimSpace(query)) || containsWords(c.Name, query) {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// SearchCurrencies returns the currencies whose code is query or whose name,
+// together with the name of the country issuing it, contains every word of
+// query, so that "Indian rupee" and "United Kingdom" both match
+func SearchCurrencies(query string) []Currency {
+	out := make([]Currency, 0)
+	for _, c := range Currencies {
+		text := c.Name
+		// the first two letters of most currency codes are the country code
+		if country, ok := LookupCountry(c.Code[:2]); ok {
+			text += " " + country.Name
+		}
+
+		if strings.EqualFold(c.Code, strings.TrimSpace(query)) || containsWords(text, query) {
+			out = append(out, c)
+		}
+	}
+
+	return out
+}
+
+// containsWords reports whether text contains every word of query, ignoring case
+func containsWords(text, query string) bool {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return false
+	}
+
+	text = strings.ToLower(text)
+	for _, word := range words {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// closest returns up to maxSuggestions codes one substitution, transposition,
+// insertion or deletion away from s, transpositions first
+func closest(s string, codes []string) []string {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	out := make([]string, 0)
+	for _, code := range codes {
+		if distance(s, code) == 1 {
+			out = append(out, code)
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b string) int {
+		switch {
+		case sameLetters(a, s) && !sameLetters(b, s):
+			return -1
+		case sameLetters(b, s) && !sameLetters(a, s):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	return out[:min(len(out), maxSuggestions)]
+}
+
+// sameLetters reports whether a and b are anagrams
+func sameLetters(a, b string) bool {
+	x, y := []byte(a), []byte(b)
+	slices.Sort(x)
+	slices.Sort(y)
+
+	return string(x) == string(y)
+}
+
+// distance is the optimal string alignment distance between a and b
+func distance(a, b string) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(a)][len(b)]
+}
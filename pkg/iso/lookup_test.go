@@ -0,0 +1,93 @@
+package iso
+
+import (
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestTablesSorted(t *testing.T) {
+	if !sort.SliceIsSorted(Countries, func(i, j int) bool { return Countries[i].Code < Countries[j].Code }) {
+		t.Error("Countries is not sorted by code")
+	}
+
+	if !sort.SliceIsSorted(Currencies, func(i, j int) bool { return Currencies[i].Code < Currencies[j].Code }) {
+		t.Error("Currencies is not sorted by code")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	if c, ok := LookupCurrency(" usd "); !ok || c.Code != "USD" || c.MinorUnits != 2 {
+		t.Errorf("LookupCurrency(usd) = %+v, %v", c, ok)
+	}
+
+	if c, ok := LookupCurrency("JPY"); !ok || c.MinorUnits != 0 {
+		t.Errorf("LookupCurrency(JPY) = %+v, %v", c, ok)
+	}
+
+	if _, ok := LookupCurrency("XYZ"); ok {
+		t.Error("LookupCurrency(XYZ) found a currency")
+	}
+
+	if c, ok := LookupCountry("gb"); !ok || c.Name != "United Kingdom" {
+		t.Errorf("LookupCountry(gb) = %+v, %v", c, ok)
+	}
+
+	if _, ok := LookupCountry("QQ"); ok {
+		t.Error("LookupCountry(QQ) found a country")
+	}
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		name    string
+		suggest func(string) []string
+		input   string
+		want    string
+	}{
+		{"transposed currency", SuggestCurrency, "UDS", "USD"},
+		{"currency name", SuggestCurrency, "indian rupee", "INR"},
+		{"country name", SuggestCountry, "Singapore", "SG"},
+		{"extra letter", SuggestCountry, "IND", "IN"},
+		{"transposed country", SuggestCountry, "GS", "SG"},
+	}
+
+	for _, test := range tests {
+		if got := test.suggest(test.input); !slices.Contains(got, test.want) {
+			t.Errorf("%s: suggest(%q) = %v; want it to contain %s", test.name, test.input, got, test.want)
+		}
+	}
+
+	if got := SuggestCurrency("QQQQQQ"); len(got) != 0 {
+		t.Errorf("SuggestCurrency(QQQQQQ) = %v; want none", got)
+	}
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		query      string
+		currencies []string
+		countries  []string
+	}{
+		{"Indian rupee", []string{"INR"}, nil},
+		{"United Kingdom", []string{"GBP"}, []string{"GB"}},
+		{"sgd", []string{"SGD"}, nil},
+		{"rupee", []string{"INR", "LKR", "MUR", "NPR", "PKR", "SCR"}, nil},
+		{"korea", []string{"KPW", "KRW"}, []string{"KP", "KR"}},
+	}
+
+	for _, test := range tests {
+		var currencies, countries []string
+		for _, c := range SearchCurrencies(test.query) {
+			currencies = append(currencies, c.Code)
+		}
+
+		for _, c := range SearchCountries(test.query) {
+			countries = append(countries, c.Code)
+		}
+
+		if !slices.Equal(currencies, test.currencies) || !slices.Equal(countries, test.countries) {
+			t.Errorf("search %q = %v, %v; want %v, %v", test.query, currencies, countries, test.currencies, test.countries)
+		}
+	}
+}
@@ -92,8 +92,14 @@ func fallbackLogger(cfg Config) *slog.Logger {
 	return slog.New(handler)
 }
 
-// getHandler creates the appropriate slog handler.
+// getHandler creates the appropriate slog handler, adding the trace and span IDs
+// of the active span to every record.
 func getHandler(cfg Config, out any) slog.Handler {
+	return traceHandler{formatHandler(cfg, out)}
+}
+
+// formatHandler creates the text or JSON slog handler.
+func formatHandler(cfg Config, out any) slog.Handler {
 	opts := &slog.HandlerOptions{
 		Level: parseLogLevel(cfg.Level),
 	}
@@ -104,14 +110,14 @@ func getHandler(cfg Config, out any) slog.Handler {
 			return slog.NewJSONHandler(w, opts)
 		}
 
-		panic("getHandler: out is not an io.Writer for JSON format")
+		panic("formatHandler: out is not an io.Writer for JSON format")
 
 	default:
 		if w, ok := out.(io.Writer); ok {
 			return slog.NewTextHandler(w, opts)
 		}
 
-		panic("getHandler: out is not an io.Writer for text format")
+		panic("formatHandler: out is not an io.Writer for text format")
 	}
 }
 
@@ -0,0 +1,34 @@
+package log
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceHandler(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(traceHandler{slog.NewTextHandler(&buf, nil)})
+
+	sc := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID{0x4b, 0xf9},
+		SpanID:  trace.SpanID{0x00, 0xf0},
+	})
+
+	logger.InfoContext(trace.ContextWithSpanContext(context.Background(), sc), "inside span")
+	logger.InfoContext(context.Background(), "outside span")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if !strings.Contains(lines[0], "trace_id="+sc.TraceID().String()) ||
+		!strings.Contains(lines[0], "span_id="+sc.SpanID().String()) {
+		t.Errorf("record inside a span lacks its IDs: %s", lines[0])
+	}
+
+	if strings.Contains(lines[1], "trace_id") {
+		t.Errorf("record outside a span has a trace ID: %s", lines[1])
+	}
+}
@@ -0,0 +1,95 @@
+// Package metrics collects Prometheus metrics about tool calls, Tazapay API
+// requests, policy denials and MCP sessions.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// namespace prefixes every metric name
+const namespace = "tazapay_mcp"
+
+// Registry holds the metrics of the server, next to the Go runtime and process collectors
+var Registry = prometheus.NewRegistry()
+
+var (
+	toolCalls = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tool_calls_total",
+		Help:      "Tool calls by tool and outcome (success, error, denied, not_executed).",
+	}, []string{"tool", "outcome"})
+
+	toolDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tool_call_duration_seconds",
+		Help:      "Tool call latency by tool, including the middlewares.",
+		Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30, 60, 300},
+	}, []string{"tool"})
+
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tazapay_requests_total",
+		Help:      "Requests sent to the Tazapay API by method, endpoint template and status class.",
+	}, []string{"method", "endpoint", "status_class"})
+
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "tazapay_request_duration_seconds",
+		Help:      "Tazapay API latency by method and endpoint template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "endpoint"})
+
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "tazapay_request_retries_total",
+		Help:      "Tazapay API requests repeating the idempotency key of an earlier request.",
+	}, []string{"method", "endpoint"})
+
+	policyDenials = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "policy_denials_total",
+		Help:      "Tool calls refused by the spending policy, by tool.",
+	}, []string{"tool"})
+
+	activeSessions = prometheus.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "active_sessions",
+		Help:      "MCP client sessions currently registered.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		toolCalls, toolDuration,
+		requests, requestDuration, retries,
+		policyDenials, activeSessions,
+	)
+}
+
+// Handler serves the metrics in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// PolicyDenied counts a tool call refused by the policy engine
+func PolicyDenied(tool string) {
+	policyDenials.WithLabelValues(tool).Inc()
+}
+
+// AddSessionHooks tracks the number of active sessions with the server hooks
+func AddSessionHooks(hooks *server.Hooks) {
+	hooks.AddOnRegisterSession(func(context.Context, server.ClientSession) {
+		activeSessions.Inc()
+	})
+	hooks.AddOnUnregisterSession(func(context.Context, server.ClientSession) {
+		activeSessions.Dec()
+	})
+}
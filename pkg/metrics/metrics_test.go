@@ -0,0 +1,56 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestTransport(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/fund") {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+
+	send := func(path, key string) {
+		req, err := http.NewRequest(http.MethodPost, upstream.URL+path, http.NoBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if key != "" {
+			req.Header.Set(constants.HeaderIdempotencyKey, key)
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	send("/v3/payout", "key-1")
+	send("/v3/payout", "key-1")
+	send("/v3/payout/pot_1/fund", "")
+
+	if got := testutil.ToFloat64(requests.WithLabelValues(http.MethodPost, "/v3/payout", "2xx")); got != 2 {
+		t.Errorf("2xx payout requests = %v; want 2", got)
+	}
+
+	if got := testutil.ToFloat64(requests.WithLabelValues(http.MethodPost, "/v3/payout/{id}/fund", "4xx")); got != 1 {
+		t.Errorf("4xx fund requests = %v; want 1", got)
+	}
+
+	if got := testutil.ToFloat64(retries.WithLabelValues(http.MethodPost, "/v3/payout")); got != 1 {
+		t.Errorf("retries = %v; want 1", got)
+	}
+}
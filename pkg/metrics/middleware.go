@@ -0,0 +1,29 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Middleware returns a tool middleware counting tool calls by outcome and
+// timing them. It must wrap the policy, dry run and confirmation middlewares
+// so that policy denials, dry runs and pending confirmations are counted as well.
+func Middleware() types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		name := tool.Definition().Name
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			start := time.Now()
+			result, err := next(ctx, req)
+
+			toolDuration.WithLabelValues(name).Observe(time.Since(start).Seconds())
+			toolCalls.WithLabelValues(name, types.Outcome(result, err)).Inc()
+
+			return result, err
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/endpoint"
+)
+
+// maxTrackedKeys bounds the idempotency keys remembered to detect retries
+const maxTrackedKeys = 10000
+
+// transport instruments the requests sent to Tazapay
+type transport struct {
+	next http.RoundTripper
+
+	mu   sync.Mutex
+	keys map[string]bool
+}
+
+// Transport wraps next, counting and timing every request by endpoint template
+// and status class. A request repeating the idempotency key of an earlier one
+// is counted as a retry.
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return &transport{next: next, keys: make(map[string]bool)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := endpoint.Template(req.URL.Path)
+
+	if key := req.Header.Get(constants.HeaderIdempotencyKey); key != "" && t.seen(req.Method+" "+key) {
+		retries.WithLabelValues(req.Method, route).Inc()
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+
+	requestDuration.WithLabelValues(req.Method, route).Observe(time.Since(start).Seconds())
+	requests.WithLabelValues(req.Method, route, statusClass(resp, err)).Inc()
+
+	return resp, err
+}
+
+// seen records the key and reports whether it was sent before
+func (t *transport) seen(key string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.keys[key] {
+		return true
+	}
+
+	if len(t.keys) >= maxTrackedKeys {
+		clear(t.keys)
+	}
+
+	t.keys[key] = true
+
+	return false
+}
+
+// statusClass returns 2xx, 4xx, ... for a response, or error when none was received
+func statusClass(resp *http.Response, err error) string {
+	if err != nil || resp == nil {
+		return "error"
+	}
+
+	return strconv.Itoa(resp.StatusCode/constants.Num100) + "xx"
+}
@@ -0,0 +1,62 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// batchHandler evaluates every payout of a batch call. The batch is denied as
+// a whole when one of its payouts is. After the call, the reservations of the
+// payouts that were not created are released.
+func batchHandler(engine *Engine, logger *slog.Logger, name string, batcher types.Batcher,
+	next types.ToolHandlerFunc,
+) types.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		payouts, err := batcher.BatchPayouts(ctx, req.GetArguments())
+		if err != nil {
+			return mcp.NewToolResultError(err.Error()), nil
+		}
+
+		reservations := make([]*Reservation, 0, len(payouts))
+		releaseAll := func() {
+			for _, reservation := range reservations {
+				reservation.Release()
+			}
+		}
+
+		for i, args := range payouts {
+			call := NewCall(ctx, name, args, true)
+
+			reservation, err := engine.Reserve(&call, "")
+			if err != nil {
+				releaseAll()
+				return deniedResult(ctx, logger, &call, fmt.Errorf("payout %d of the batch: %w", i+1, err)), nil
+			}
+
+			reservations = append(reservations, reservation)
+		}
+
+		result, err := next(ctx, req)
+		if err != nil || result == nil || result.IsError || types.IsNotExecuted(result) {
+			releaseAll()
+			return result, err
+		}
+
+		ids := types.BatchPayoutIDs(result)
+
+		for i, reservation := range reservations {
+			if i < len(ids) && ids[i] != "" {
+				reservation.Commit(ids[i])
+			} else {
+				reservation.Release()
+			}
+		}
+
+		return result, nil
+	}
+}
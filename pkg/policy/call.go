@@ -0,0 +1,98 @@
+package policy
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Call holds the fields of a tool call the rules are evaluated against
+type Call struct {
+	Tool        string
+	Session     string
+	Account     string
+	Currency    string
+	Country     string
+	Beneficiary string
+	ReferenceID string
+	// Amount in cents, zero when the call does not move a known amount
+	Amount int64
+	// MovesFunds is set for calls that send money out of the account
+	MovesFunds bool
+	// RequiresReference is set for calls that create a payout and carry a reference_id
+	RequiresReference bool
+}
+
+// NewCall extracts the policy relevant fields from tool arguments. It understands
+// the create payout layout (amount, currency, beneficiary, beneficiary_details)
+// and the beneficiary layout (destination_details at the top level).
+func NewCall(ctx context.Context, tool string, args map[string]any, movesFunds bool) Call {
+	call := Call{
+		Tool:        tool,
+		Session:     types.SessionID(ctx),
+		Account:     utils.AccountID(ctx),
+		MovesFunds:  movesFunds,
+		Beneficiary: stringArg(args, constants.ArgBeneficiary),
+		ReferenceID: stringArg(args, constants.PayoutReferenceIDField),
+	}
+
+	if movesFunds {
+		call.Currency = strings.ToUpper(stringArg(args, constants.KeyCurrency))
+
+		if amount, ok := args[constants.PayoutAmountField].(float64); ok {
+			call.Amount = money.Decimal2ToInt64(amount)
+			call.RequiresReference = true
+		}
+	}
+
+	destination, _ := args[constants.BeneficiaryDestinationDetailsField].(map[string]any)
+	if details, ok := args[constants.KeyBeneficiaryDetails].(map[string]any); ok {
+		destination, _ = details[constants.BeneficiaryDestinationDetailsField].(map[string]any)
+	}
+
+	for _, kind := range []string{constants.KeyBank, constants.KeyWallet} {
+		target, ok := destination[kind].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		if country := stringArg(target, constants.KeyCountry); country != "" {
+			call.Country = strings.ToUpper(country)
+		}
+
+		if currency := stringArg(target, constants.KeyCurrency); currency != "" && call.Currency == "" {
+			call.Currency = strings.ToUpper(currency)
+		}
+	}
+
+	return call
+}
+
+// ResolvePayout fills in amount, currency and beneficiary of an existing payout,
+// used for tools such as tazapay_fund_payout_tool that only receive the payout ID
+func ResolvePayout(ctx context.Context, logger *slog.Logger, call *Call, payoutID string) error {
+	data, err := utils.FetchPayout(ctx, logger, payoutID)
+	if err != nil {
+		return err
+	}
+
+	if amount, ok := data[constants.PayoutAmountField].(float64); ok {
+		call.Amount = int64(amount)
+	}
+
+	call.Currency = strings.ToUpper(stringArg(data, constants.KeyCurrency))
+	call.Beneficiary = stringArg(data, constants.ArgBeneficiary)
+
+	return nil
+}
+
+// stringArg returns args[key] if it is a string
+func stringArg(args map[string]any, key string) string {
+	v, _ := args[key].(string)
+	return v
+}
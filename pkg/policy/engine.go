@@ -0,0 +1,246 @@
+package policy
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+)
+
+// day is the length of the daily cap window
+const day = 24 * time.Hour
+
+// entry is an amount counted against the caps
+type entry struct {
+	at       time.Time
+	session  string
+	account  string
+	currency string
+	payoutID string
+	amount   int64
+}
+
+// Engine evaluates calls against the rules and keeps the usage needed for caps.
+// It is safe for concurrent use.
+type Engine struct {
+	now     func() time.Time
+	entries []*entry
+	rules   Rules
+	mu      sync.Mutex
+}
+
+// NewEngine returns an Engine for the given rules
+func NewEngine(rules Rules) (*Engine, error) {
+	if err := rules.compile(); err != nil {
+		return nil, err
+	}
+
+	return &Engine{rules: rules, now: time.Now}, nil
+}
+
+// Enabled reports whether any rule is configured
+func (e *Engine) Enabled() bool {
+	return !e.rules.Empty()
+}
+
+// Reservation is the amount of an allowed call held against the caps
+type Reservation struct {
+	engine *Engine
+	entry  *entry
+}
+
+// Commit keeps the reserved amount and links it to the created payout, if known
+func (r *Reservation) Commit(payoutID string) {
+	if r == nil || r.entry == nil {
+		return
+	}
+
+	r.engine.mu.Lock()
+	defer r.engine.mu.Unlock()
+
+	if r.entry.payoutID == "" {
+		r.entry.payoutID = payoutID
+	}
+}
+
+// Release drops the reserved amount, used when the call failed
+func (r *Reservation) Release() {
+	if r == nil || r.entry == nil {
+		return
+	}
+
+	r.engine.mu.Lock()
+	defer r.engine.mu.Unlock()
+
+	r.engine.entries = slices.DeleteFunc(r.engine.entries, func(e *entry) bool { return e == r.entry })
+}
+
+// Reserve checks the call against every rule. When it is allowed, the amount is
+// reserved against the caps until the reservation is committed or released, so
+// concurrent calls cannot overshoot a cap together. A denial is returned as an
+// error wrapping constants.ErrPolicyDenied.
+func (e *Engine) Reserve(call *Call, payoutID string) (*Reservation, error) {
+	if err := e.checkStatic(call); err != nil {
+		return nil, err
+	}
+
+	if !call.MovesFunds {
+		return &Reservation{}, nil
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	e.prune(now)
+
+	// a payout created through this server has already been counted
+	if e.counted(payoutID) {
+		return &Reservation{}, nil
+	}
+
+	if err := e.checkCaps(call, now); err != nil {
+		return nil, err
+	}
+
+	en := &entry{
+		at:       now,
+		session:  call.Session,
+		account:  call.Account,
+		currency: call.Currency,
+		payoutID: payoutID,
+		amount:   call.Amount,
+	}
+	e.entries = append(e.entries, en)
+
+	return &Reservation{engine: e, entry: en}, nil
+}
+
+// Counted reports whether the amount of a payout created through this server
+// is counted against the caps
+func (e *Engine) Counted(payoutID string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.counted(payoutID)
+}
+
+// counted reports whether payoutID is linked to an entry. Callers must hold e.mu.
+func (e *Engine) counted(payoutID string) bool {
+	return payoutID != "" && slices.ContainsFunc(e.entries, func(en *entry) bool { return en.payoutID == payoutID })
+}
+
+// checkStatic evaluates the rules that do not depend on past usage
+func (e *Engine) checkStatic(call *Call) error {
+	r := &e.rules
+
+	if call.Country != "" && len(r.AllowedCountries) > 0 && !slices.Contains(r.AllowedCountries, call.Country) {
+		return deny("destination country %s is not in the allowed countries %v", call.Country, r.AllowedCountries)
+	}
+
+	if call.Currency != "" && len(r.AllowedCurrencies) > 0 && !slices.Contains(r.AllowedCurrencies, call.Currency) {
+		return deny("currency %s is not in the allowed currencies %v", call.Currency, r.AllowedCurrencies)
+	}
+
+	if !call.MovesFunds {
+		return nil
+	}
+
+	// without a currency or a positive amount no cap or maximum could be applied
+	if call.Currency == "" {
+		return deny("the currency of the payout is unknown, so the spending limits cannot be checked")
+	}
+
+	if call.Amount <= 0 {
+		return deny("payout amount must be greater than zero, got %s", format(call.Amount, call.Currency))
+	}
+
+	if len(r.AllowedBeneficiaries) > 0 && !slices.Contains(r.AllowedBeneficiaries, call.Beneficiary) {
+		if call.Beneficiary == "" {
+			return deny("payouts must go to an allowlisted beneficiary ID, inline beneficiary details are not allowed")
+		}
+
+		return deny("beneficiary %s is not in the beneficiary allowlist", call.Beneficiary)
+	}
+
+	if call.RequiresReference && r.reference != nil && !r.reference.MatchString(call.ReferenceID) {
+		return deny("reference_id %q does not match the required format %s", call.ReferenceID, r.ReferenceIDPattern)
+	}
+
+	if limit, ok := r.MaxPerTransaction[call.Currency]; ok && call.Amount > money.Decimal2ToInt64(limit) {
+		return deny("payout of %s exceeds the per-transaction maximum of %.2f %s",
+			format(call.Amount, call.Currency), limit, call.Currency)
+	}
+
+	return nil
+}
+
+// checkCaps evaluates the daily and rolling window caps. Callers must hold e.mu.
+func (e *Engine) checkCaps(call *Call, now time.Time) error {
+	r := &e.rules
+
+	caps := []struct {
+		limits  map[string]float64
+		window  time.Duration
+		name    string
+		session bool
+	}{
+		{r.DailyAccountCap, day, "daily account cap", false},
+		{r.DailySessionCap, day, "daily session cap", true},
+		{r.RollingAccountCap, r.window, "rolling " + r.window.String() + " account cap", false},
+		{r.RollingSessionCap, r.window, "rolling " + r.window.String() + " session cap", true},
+	}
+
+	for _, c := range caps {
+		limit, ok := c.limits[call.Currency]
+		if !ok {
+			continue
+		}
+
+		used := e.usage(call, now.Add(-c.window), c.session)
+		if used+call.Amount > money.Decimal2ToInt64(limit) {
+			return deny("payout of %s would exceed the %s of %.2f %s (already used %s)",
+				format(call.Amount, call.Currency), c.name, limit, call.Currency, format(used, call.Currency))
+		}
+	}
+
+	return nil
+}
+
+// usage sums the amounts of the call's account, and optionally session, since the given time
+func (e *Engine) usage(call *Call, since time.Time, perSession bool) int64 {
+	var total int64
+
+	for _, en := range e.entries {
+		if en.at.Before(since) || en.account != call.Account || en.currency != call.Currency {
+			continue
+		}
+
+		if perSession && en.session != call.Session {
+			continue
+		}
+
+		total += en.amount
+	}
+
+	return total
+}
+
+// prune drops entries older than every cap window. Callers must hold e.mu.
+func (e *Engine) prune(now time.Time) {
+	keep := max(day, e.rules.window)
+	e.entries = slices.DeleteFunc(e.entries, func(en *entry) bool { return now.Sub(en.at) > keep })
+}
+
+// deny builds a policy denial error
+func deny(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", constants.ErrPolicyDenied, fmt.Sprintf(format, args...))
+}
+
+// format renders an amount in cents with its currency
+func format(amount int64, currency string) string {
+	return fmt.Sprintf("%.2f %s", money.Int64ToDecimal2(amount), currency)
+}
@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func payout(session string, amount int64) *Call {
+	return &Call{
+		Tool:              "create_payout_tool",
+		Session:           session,
+		Account:           "acc",
+		Currency:          "USD",
+		Country:           "SG",
+		Beneficiary:       "bnf_1",
+		ReferenceID:       "INV-1",
+		Amount:            amount,
+		MovesFunds:        true,
+		RequiresReference: true,
+	}
+}
+
+func TestEngineReserveStatic(t *testing.T) {
+	rules := Rules{
+		MaxPerTransaction:    map[string]float64{"usd": 100},
+		AllowedCountries:     []string{"sg"},
+		AllowedCurrencies:    []string{"USD"},
+		AllowedBeneficiaries: []string{"bnf_1"},
+		ReferenceIDPattern:   "^INV-[0-9]+$",
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(c *Call)
+		allowed bool
+	}{
+		{"allowed payout", func(*Call) {}, true},
+		{"at the per-transaction maximum", func(c *Call) { c.Amount = 10000 }, true},
+		{"over the per-transaction maximum", func(c *Call) { c.Amount = 10001 }, false},
+		{"country not allowed", func(c *Call) { c.Country = "IN" }, false},
+		{"currency not allowed", func(c *Call) { c.Currency = "EUR" }, false},
+		{"beneficiary not allowed", func(c *Call) { c.Beneficiary = "bnf_2" }, false},
+		{"inline beneficiary", func(c *Call) { c.Beneficiary = "" }, false},
+		{"reference does not match", func(c *Call) { c.ReferenceID = "order-1" }, false},
+		{"no currency", func(c *Call) { c.Currency = "" }, false},
+		{"zero amount", func(c *Call) { c.Amount = 0 }, false},
+		{"negative amount", func(c *Call) { c.Amount = -5000 }, false},
+		{
+			"non money-moving call skips beneficiary and reference",
+			func(c *Call) { c.MovesFunds, c.Beneficiary, c.ReferenceID = false, "", "" },
+			true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine, err := NewEngine(rules)
+			if err != nil {
+				t.Fatalf("NewEngine() error: %v", err)
+			}
+
+			call := payout("s1", 5000)
+			test.modify(call)
+
+			_, err = engine.Reserve(call, "")
+			if test.allowed && err != nil {
+				t.Errorf("Reserve() = %v; want allowed", err)
+			}
+
+			if !test.allowed && !errors.Is(err, constants.ErrPolicyDenied) {
+				t.Errorf("Reserve() = %v; want ErrPolicyDenied", err)
+			}
+		})
+	}
+}
+
+func TestEngineCaps(t *testing.T) {
+	engine, err := NewEngine(Rules{
+		DailyAccountCap:   map[string]float64{"USD": 300},
+		RollingSessionCap: map[string]float64{"USD": 150},
+		RollingWindow:     "1h",
+	})
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	engine.now = func() time.Time { return now }
+
+	reserve := func(session string, amount int64, payoutID string) error {
+		r, err := engine.Reserve(payout(session, amount), payoutID)
+		if err == nil {
+			r.Commit("")
+		}
+
+		return err
+	}
+
+	if err := reserve("s1", 10000, ""); err != nil {
+		t.Fatalf("first payout denied: %v", err)
+	}
+
+	if err := reserve("s1", 10000, ""); err == nil {
+		t.Error("payout over the rolling session cap was allowed")
+	}
+
+	if err := reserve("s2", 10000, ""); err != nil {
+		t.Errorf("payout in another session denied: %v", err)
+	}
+
+	now = now.Add(2 * time.Hour)
+
+	if err := reserve("s1", 10000, ""); err != nil {
+		t.Errorf("payout after the rolling window denied: %v", err)
+	}
+
+	if err := reserve("s3", 10000, ""); err == nil {
+		t.Error("payout over the daily account cap was allowed")
+	}
+
+	now = now.Add(day)
+
+	if err := reserve("s3", 10000, ""); err != nil {
+		t.Errorf("payout on the next day denied: %v", err)
+	}
+}
+
+func TestEngineReleaseAndCommittedPayout(t *testing.T) {
+	engine, err := NewEngine(Rules{DailyAccountCap: map[string]float64{"USD": 100}})
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	r, err := engine.Reserve(payout("s1", 10000), "")
+	if err != nil {
+		t.Fatalf("Reserve() error: %v", err)
+	}
+
+	r.Release()
+
+	r, err = engine.Reserve(payout("s1", 10000), "")
+	if err != nil {
+		t.Fatalf("Reserve() after Release error: %v", err)
+	}
+
+	r.Commit("pot_1")
+
+	// funding the payout created above must not count it twice
+	if _, err := engine.Reserve(payout("s1", 10000), "pot_1"); err != nil {
+		t.Errorf("Reserve() for a counted payout = %v; want allowed", err)
+	}
+
+	if _, err := engine.Reserve(payout("s1", 100), "pot_2"); err == nil {
+		t.Error("Reserve() over the cap for another payout was allowed")
+	}
+}
+
+func TestNewEngineInvalidRules(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules Rules
+	}{
+		{"rolling cap without window", Rules{RollingAccountCap: map[string]float64{"USD": 1}}},
+		{"invalid window", Rules{RollingWindow: "soon"}},
+		{"invalid reference pattern", Rules{ReferenceIDPattern: "("}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewEngine(test.rules); !errors.Is(err, constants.ErrInvalidPolicy) {
+				t.Errorf("NewEngine() = %v; want ErrInvalidPolicy", err)
+			}
+		})
+	}
+}
+
+func TestNewCallMoneyFields(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         map[string]any
+		wantCurrency string
+		wantAmount   int64
+	}{
+		{"payout", map[string]any{"amount": 12.5, "currency": "usd"}, "USD", 1250},
+		{"currency from the bank account", map[string]any{
+			"amount":              10.0,
+			"beneficiary_details": map[string]any{"destination_details": map[string]any{"bank": map[string]any{"currency": "sgd"}}},
+		}, "SGD", 1000},
+		{"no currency", map[string]any{"amount": 10.0}, "", 1000},
+		{"negative amount", map[string]any{"amount": -10.0, "currency": "USD"}, "USD", -1000},
+		{"no amount", map[string]any{"currency": "USD"}, "USD", 0},
+	}
+
+	engine, err := NewEngine(Rules{MaxPerTransaction: map[string]float64{"USD": 100}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			call := NewCall(context.Background(), "create_payout_tool", test.args, true)
+			if call.Currency != test.wantCurrency || call.Amount != test.wantAmount {
+				t.Errorf("NewCall() = %s %d; want %s %d", call.Currency, call.Amount, test.wantCurrency, test.wantAmount)
+			}
+
+			// only a positive amount in a known currency can be checked against the limits
+			_, err := engine.Reserve(&call, "")
+			allowed := test.wantCurrency != "" && test.wantAmount > 0
+			if allowed != (err == nil) {
+				t.Errorf("Reserve() = %v; want allowed %v", err, allowed)
+			}
+		})
+	}
+}
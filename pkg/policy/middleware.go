@@ -0,0 +1,105 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/metrics"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// payoutIDPattern finds the payout ID in a tool result
+var payoutIDPattern = regexp.MustCompile(`pot_[A-Za-z0-9]+`)
+
+// Middleware returns a tool middleware that evaluates every mutating call
+// against the engine before the tool runs. Read-only tools are not wrapped.
+// A denied call returns an error result explaining the violated rule.
+func Middleware(engine *Engine, logger *slog.Logger) types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		meta := tool.Metadata()
+		if meta.ReadOnly || !engine.Enabled() {
+			return next
+		}
+
+		name := tool.Definition().Name
+		resumer, _ := tool.(types.Resumer)
+
+		if batcher, ok := tool.(types.Batcher); ok && meta.MovesFunds {
+			return batchHandler(engine, logger, name, batcher, next)
+		}
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			args := req.GetArguments()
+			call := NewCall(ctx, name, args, meta.MovesFunds)
+
+			// tools that act on an existing payout only receive its ID
+			payoutID, _ := args[constants.GetPayoutIDField].(string)
+
+			// a resumed vendor payment funds an existing payout, whatever amount is passed
+			// along. A payout created here was checked and counted when it was created.
+			if resumer != nil && meta.MovesFunds {
+				if resumed := resumer.ResumedPayout(ctx, args); resumed != "" {
+					if engine.Counted(resumed) {
+						return next(ctx, req)
+					}
+
+					payoutID, call.Amount = resumed, 0
+				}
+			}
+			if meta.MovesFunds && payoutID != "" && call.Amount == 0 {
+				if err := ResolvePayout(ctx, logger, &call, payoutID); err != nil {
+					logger.ErrorContext(ctx, "Policy could not resolve payout", "id", payoutID, constants.KeyError, err)
+					return deniedResult(ctx, logger, &call, err), nil
+				}
+			}
+
+			reservation, err := engine.Reserve(&call, payoutID)
+			if err != nil {
+				return deniedResult(ctx, logger, &call, err), nil
+			}
+
+			result, err := next(ctx, req)
+			if err != nil || result == nil || result.IsError || types.IsNotExecuted(result) {
+				reservation.Release()
+				return result, err
+			}
+
+			if payoutID == "" {
+				payoutID = payoutIDPattern.FindString(types.ResultText(result))
+			}
+
+			reservation.Commit(payoutID)
+
+			return result, nil
+		}
+	}
+}
+
+// deniedResult logs an audit record of the denial and builds the tool result
+func deniedResult(ctx context.Context, logger *slog.Logger, call *Call, err error) *mcp.CallToolResult {
+	metrics.PolicyDenied(call.Tool)
+
+	logger.WarnContext(ctx, "Tool call denied by policy",
+		slog.Bool("audit", true),
+		slog.String("tool", call.Tool),
+		slog.String("session", call.Session),
+		slog.String("account", call.Account),
+		slog.String("currency", call.Currency),
+		slog.Int64("amount", call.Amount),
+		slog.String("country", call.Country),
+		slog.String("beneficiary", call.Beneficiary),
+		slog.String("reason", err.Error()),
+	)
+
+	text := err.Error()
+	if !errors.Is(err, constants.ErrPolicyDenied) {
+		text = constants.ErrPolicyDenied.Error() + ": " + text
+	}
+
+	return mcp.NewToolResultError(text)
+}
@@ -0,0 +1,129 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Rules configures the policy engine. Amounts are in major currency units
+// (e.g. 10.50) and keyed by ISO 4217 currency code. A zero value disables a rule.
+//
+// Example `.tazapay-mcp-server.yaml` section:
+//
+//	TAZAPAY_POLICY:
+//	  max_per_transaction: {USD: 5000}
+//	  daily_account_cap: {USD: 20000}
+//	  daily_session_cap: {USD: 10000}
+//	  rolling_window: 1h
+//	  rolling_account_cap: {USD: 8000}
+//	  rolling_session_cap: {USD: 5000}
+//	  allowed_countries: [SG, IN]
+//	  allowed_currencies: [USD, INR]
+//	  allowed_beneficiaries: [bnf_123]
+//	  reference_id_pattern: "^INV-[0-9]+$"
+type Rules struct {
+	MaxPerTransaction    map[string]float64 `json:"max_per_transaction"   mapstructure:"max_per_transaction"`
+	DailyAccountCap      map[string]float64 `json:"daily_account_cap"     mapstructure:"daily_account_cap"`
+	DailySessionCap      map[string]float64 `json:"daily_session_cap"     mapstructure:"daily_session_cap"`
+	RollingAccountCap    map[string]float64 `json:"rolling_account_cap"   mapstructure:"rolling_account_cap"`
+	RollingSessionCap    map[string]float64 `json:"rolling_session_cap"   mapstructure:"rolling_session_cap"`
+	RollingWindow        string             `json:"rolling_window"        mapstructure:"rolling_window"`
+	ReferenceIDPattern   string             `json:"reference_id_pattern"  mapstructure:"reference_id_pattern"`
+	AllowedCountries     []string           `json:"allowed_countries"     mapstructure:"allowed_countries"`
+	AllowedCurrencies    []string           `json:"allowed_currencies"    mapstructure:"allowed_currencies"`
+	AllowedBeneficiaries []string           `json:"allowed_beneficiaries" mapstructure:"allowed_beneficiaries"`
+
+	window    time.Duration
+	reference *regexp.Regexp
+}
+
+// RulesFromViper reads the TAZAPAY_POLICY section of the config file, or a JSON
+// document in the TAZAPAY_POLICY environment variable
+func RulesFromViper() (Rules, error) {
+	var rules Rules
+
+	switch raw := viper.Get(constants.StrTAZAPAYPolicy).(type) {
+	case nil:
+		return rules, nil
+	case string:
+		if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+			return rules, fmt.Errorf("%w: %w", constants.ErrInvalidPolicy, err)
+		}
+	default:
+		if err := viper.UnmarshalKey(constants.StrTAZAPAYPolicy, &rules); err != nil {
+			return rules, fmt.Errorf("%w: %w", constants.ErrInvalidPolicy, err)
+		}
+	}
+
+	return rules, rules.compile()
+}
+
+// compile normalises codes and parses the window and reference pattern
+func (r *Rules) compile() error {
+	for _, caps := range []*map[string]float64{
+		&r.MaxPerTransaction, &r.DailyAccountCap, &r.DailySessionCap, &r.RollingAccountCap, &r.RollingSessionCap,
+	} {
+		*caps = upperKeys(*caps)
+	}
+
+	r.AllowedCountries = upperAll(r.AllowedCountries)
+	r.AllowedCurrencies = upperAll(r.AllowedCurrencies)
+
+	if r.RollingWindow != "" {
+		window, err := time.ParseDuration(r.RollingWindow)
+		if err != nil || window <= 0 {
+			return fmt.Errorf("%w: rolling_window %q", constants.ErrInvalidPolicy, r.RollingWindow)
+		}
+
+		r.window = window
+	}
+
+	if (len(r.RollingAccountCap) > 0 || len(r.RollingSessionCap) > 0) && r.window == 0 {
+		return fmt.Errorf("%w: rolling caps need a rolling_window", constants.ErrInvalidPolicy)
+	}
+
+	if r.ReferenceIDPattern != "" {
+		re, err := regexp.Compile(r.ReferenceIDPattern)
+		if err != nil {
+			return fmt.Errorf("%w: reference_id_pattern: %w", constants.ErrInvalidPolicy, err)
+		}
+
+		r.reference = re
+	}
+
+	return nil
+}
+
+// Empty reports whether no rule is configured
+func (r *Rules) Empty() bool {
+	return len(r.MaxPerTransaction) == 0 && len(r.DailyAccountCap) == 0 && len(r.DailySessionCap) == 0 &&
+		len(r.RollingAccountCap) == 0 && len(r.RollingSessionCap) == 0 && r.reference == nil &&
+		len(r.AllowedCountries) == 0 && len(r.AllowedCurrencies) == 0 && len(r.AllowedBeneficiaries) == 0
+}
+
+// upperKeys returns a copy of m with upper-cased keys
+func upperKeys(m map[string]float64) map[string]float64 {
+	out := make(map[string]float64, len(m))
+	for k, v := range m {
+		out[strings.ToUpper(k)] = v
+	}
+
+	return out
+}
+
+// upperAll returns a copy of values in upper case
+func upperAll(values []string) []string {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		out = append(out, strings.ToUpper(v))
+	}
+
+	return out
+}
@@ -0,0 +1,121 @@
+// Package purpose looks up and recommends Tazapay payout purpose codes from
+// the constants.PayoutPurposeCodes catalogue.
+package purpose
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+)
+
+// stopWords are ignored when matching descriptions
+var stopWords = map[string]bool{
+	"a": true, "an": true, "and": true, "the": true, "for": true, "of": true, "to": true, "in": true, "on": true,
+	"from": true, "with": true, "our": true, "my": true, "pay": true, "paying": true, "payment": true,
+	"payments": true, "expenses": true, "charges": true,
+}
+
+// Recommendation is a purpose code fitting a payment description
+type Recommendation struct {
+	Code        string   `json:"code"`
+	Description string   `json:"description"`
+	Score       int      `json:"score"`
+	Matched     []string `json:"matched_words,omitempty"`
+}
+
+// Lookup returns the purpose code of the catalogue, in any case
+func Lookup(code string) (constants.PurposeCode, bool) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	for _, p := range constants.PayoutPurposeCodes {
+		if p.Code == code {
+			return p, true
+		}
+	}
+
+	return constants.PurposeCode{}, false
+}
+
+// Validate checks that code is in the catalogue and returns it in upper case
+func Validate(code string) (string, error) {
+	p, ok := Lookup(code)
+	if !ok {
+		return "", fmt.Errorf("%w: %q is not a Tazapay purpose code (PYR001 to PYR028), call %s to pick one",
+			constants.ErrInvalidPurposeCode, code, toolname.Name(constants.RecommendPurposeCodeToolName))
+	}
+
+	return p.Code, nil
+}
+
+// Recommend ranks the purpose codes by how well they fit description, best
+// first, and returns at most n. The default code is recommended when nothing
+// fits.
+func Recommend(description string, n int) []Recommendation {
+	query := words(description)
+
+	out := make([]Recommendation, 0)
+
+	for _, p := range constants.PayoutPurposeCodes {
+		named := words(p.Description)
+
+		r := Recommendation{Code: p.Code, Description: p.Description}
+		for _, word := range query {
+			// a word of the code's own description counts more than a keyword
+			switch {
+			case slices.Contains(named, word) || slices.Contains(named, singular(word)):
+				r.Score += 2
+			case slices.Contains(p.Keywords, word) || slices.Contains(p.Keywords, singular(word)):
+				r.Score++
+			default:
+				continue
+			}
+
+			r.Matched = append(r.Matched, word)
+		}
+
+		if r.Score > 0 {
+			out = append(out, r)
+		}
+	}
+
+	// stable, so that ties keep the catalogue order
+	slices.SortStableFunc(out, func(a, b Recommendation) int { return b.Score - a.Score })
+
+	if len(out) == 0 {
+		if p, ok := Lookup(constants.DefaultPurposeCode); ok {
+			out = append(out, Recommendation{Code: p.Code, Description: p.Description})
+		}
+	}
+
+	return out[:min(len(out), n)]
+}
+
+// words splits text into lower case words, without stop words and duplicates
+func words(text string) []string {
+	out := make([]string, 0)
+
+	for _, word := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if !stopWords[word] && !slices.Contains(out, word) {
+			out = append(out, word)
+		}
+	}
+
+	return out
+}
+
+// singular strips a plural ending from an English word
+func singular(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies"):
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	default:
+		return word
+	}
+}
@@ -0,0 +1,58 @@
+package purpose
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestRecommend(t *testing.T) {
+	tests := []struct {
+		description string
+		want        string
+	}{
+		{"freelancer invoice", "PYR020"},
+		{"salary", "PYR007"},
+		{"March salaries for the support team", "PYR007"},
+		{"business travel reimbursement", "PYR016"},
+		{"AWS hosting subscription", "PYR024"},
+		{"seller payouts for marketplace sales", "PYR025"},
+		{"shipping container freight", "PYR022"},
+		{"refund of order 123", "PYR027"},
+		{"office rent for April", "PYR008"},
+		{"xyzzy", constants.DefaultPurposeCode},
+	}
+
+	for _, test := range tests {
+		got := Recommend(test.description, 3)
+		if len(got) == 0 || got[0].Code != test.want {
+			t.Errorf("Recommend(%q) = %+v; want %s first", test.description, got, test.want)
+		}
+	}
+
+	if got := Recommend("business", 1); len(got) != 1 {
+		t.Errorf("Recommend(n=1) returned %d codes", len(got))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		code string
+		want string
+		err  bool
+	}{
+		{"pyr028", "PYR028", false},
+		{" PYR001 ", "PYR001", false},
+		{"PYR099", "", true},
+		{"salary", "", true},
+		{"", "", true},
+	}
+
+	for _, test := range tests {
+		got, err := Validate(test.code)
+		if test.err != errors.Is(err, constants.ErrInvalidPurposeCode) || got != test.want {
+			t.Errorf("Validate(%q) = %q, %v", test.code, got, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package recent
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Kinds of IDs tracked by the cache
+const (
+	KindBeneficiary = "beneficiary"
+	KindCustomer    = "customer"
+
+	defaultSize = 50
+	// maxScopes bounds the sessions remembered, the least recently used is
+	// dropped first
+	maxScopes = 1000
+)
+
+// Scope is the MCP session and Tazapay account IDs are recorded for, so that
+// one client never sees the IDs used by another
+type Scope struct {
+	Session string
+	Account string
+}
+
+// ScopeOf returns the scope of the session and credentials of ctx
+func ScopeOf(ctx context.Context) Scope {
+	return Scope{Session: types.SessionID(ctx), Account: utils.AccountID(ctx)}
+}
+
+// Store keeps the most recently used IDs per scope and kind, newest first.
+// It is safe for concurrent use.
+type Store struct {
+	ids  map[Scope]map[string][]string
+	size int
+	// scopes orders the scopes from least to most recently used
+	scopes []Scope
+	mu     sync.Mutex
+}
+
+// New returns a Store that keeps at most size IDs per scope and kind
+func New(size int) *Store {
+	if size <= 0 {
+		size = defaultSize
+	}
+
+	return &Store{ids: make(map[Scope]map[string][]string), size: size}
+}
+
+// Default is the process wide cache used by the tools
+var Default = New(defaultSize)
+
+// Add records id as the most recently used ID of the given kind in scope
+func (s *Store) Add(scope Scope, kind, id string) {
+	if id == "" {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.touch(scope)
+
+	list := s.ids[scope][kind]
+	for i, existing := range list {
+		if existing == id {
+			list = append(list[:i], list[i+1:]...)
+			break
+		}
+	}
+
+	list = append([]string{id}, list...)
+	if len(list) > s.size {
+		list = list[:s.size]
+	}
+
+	s.ids[scope][kind] = list
+}
+
+// List returns the IDs of the given kind in scope starting with prefix, newest first
+func (s *Store) List(scope Scope, kind, prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]string, 0, len(s.ids[scope][kind]))
+	for _, id := range s.ids[scope][kind] {
+		if strings.HasPrefix(id, prefix) {
+			out = append(out, id)
+		}
+	}
+
+	return out
+}
+
+// Forget drops the IDs of every scope of session
+func (s *Store) Forget(session string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.scopes = slices.DeleteFunc(s.scopes, func(scope Scope) bool {
+		if scope.Session != session {
+			return false
+		}
+
+		delete(s.ids, scope)
+
+		return true
+	})
+}
+
+// touch marks scope as the most recently used, creating it and dropping the
+// least recently used scope over maxScopes
+func (s *Store) touch(scope Scope) {
+	if i := slices.Index(s.scopes, scope); i >= 0 {
+		s.scopes = append(slices.Delete(s.scopes, i, i+1), scope)
+		return
+	}
+
+	s.ids[scope] = make(map[string][]string)
+	s.scopes = append(s.scopes, scope)
+
+	if len(s.scopes) > maxScopes {
+		delete(s.ids, s.scopes[0])
+		s.scopes = s.scopes[1:]
+	}
+}
+
+// AddSessionHooks forgets the IDs of the Default store for sessions that end
+func AddSessionHooks(hooks *server.Hooks) {
+	hooks.AddOnUnregisterSession(func(_ context.Context, session server.ClientSession) {
+		Default.Forget(session.SessionID())
+	})
+}
+
+// Add records id in the Default store for the session and account of ctx
+func Add(ctx context.Context, kind, id string) {
+	Default.Add(ScopeOf(ctx), kind, id)
+}
+
+// List returns matching IDs from the Default store for the session and account of ctx
+func List(ctx context.Context, kind, prefix string) []string {
+	return Default.List(ScopeOf(ctx), kind, prefix)
+}
@@ -0,0 +1,97 @@
+package recent
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// alice is the scope used by tests that do not look at scoping
+var alice = Scope{Session: "s1", Account: "a1"}
+
+func TestStoreAddAndList(t *testing.T) {
+	s := New(3)
+	s.Add(alice, KindBeneficiary, "bnf_1")
+	s.Add(alice, KindBeneficiary, "bnf_2")
+	s.Add(alice, KindBeneficiary, "bnf_3")
+	s.Add(alice, KindBeneficiary, "bnf_1")
+	s.Add(alice, KindBeneficiary, "bnf_4")
+	s.Add(alice, KindCustomer, "cus_1")
+
+	got := s.List(alice, KindBeneficiary, "")
+	want := []string{"bnf_4", "bnf_1", "bnf_3"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("List() = %v; want %v", got, want)
+	}
+
+	got = s.List(alice, KindCustomer, "cus")
+	if !reflect.DeepEqual(got, []string{"cus_1"}) {
+		t.Errorf("List(customer) = %v; want [cus_1]", got)
+	}
+
+	if got = s.List(alice, KindCustomer, "bnf"); len(got) != 0 {
+		t.Errorf("List with non-matching prefix = %v; want empty", got)
+	}
+}
+
+func TestStoreIgnoresEmptyID(t *testing.T) {
+	s := New(0)
+	s.Add(alice, KindCustomer, "")
+
+	if got := s.List(alice, KindCustomer, ""); len(got) != 0 {
+		t.Errorf("List() = %v; want empty", got)
+	}
+}
+
+func TestStoreScopes(t *testing.T) {
+	s := New(0)
+	s.Add(alice, KindBeneficiary, "bnf_alice")
+
+	tests := []struct {
+		name  string
+		scope Scope
+		want  []string
+	}{
+		{"same scope", alice, []string{"bnf_alice"}},
+		{"other session", Scope{Session: "s2", Account: "a1"}, []string{}},
+		{"other account", Scope{Session: "s1", Account: "a2"}, []string{}},
+	}
+
+	for _, test := range tests {
+		if got := s.List(test.scope, KindBeneficiary, ""); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: List() = %v; want %v", test.name, got, test.want)
+		}
+	}
+
+	s.Forget(alice.Session)
+
+	if got := s.List(alice, KindBeneficiary, ""); len(got) != 0 {
+		t.Errorf("List() after Forget = %v; want empty", got)
+	}
+}
+
+func TestStoreDropsLeastRecentlyUsedScope(t *testing.T) {
+	s := New(0)
+	s.Add(alice, KindCustomer, "cus_alice")
+
+	for i := range maxScopes {
+		s.Add(Scope{Session: fmt.Sprint(i)}, KindCustomer, "cus_other")
+
+		// alice keeps using the server, so it is never the oldest scope
+		if i == maxScopes/2 {
+			s.Add(alice, KindCustomer, "cus_alice")
+		}
+	}
+
+	if len(s.ids) != maxScopes {
+		t.Errorf("store keeps %d scopes; want %d", len(s.ids), maxScopes)
+	}
+
+	if got := s.List(alice, KindCustomer, ""); len(got) != 1 {
+		t.Errorf("List() = %v; want the IDs of the recently used scope kept", got)
+	}
+
+	if got := s.List(Scope{Session: "0"}, KindCustomer, ""); len(got) != 0 {
+		t.Errorf("List() = %v; want the oldest scope dropped", got)
+	}
+}
@@ -0,0 +1,243 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// Decode validates tool arguments against the fields of the struct pointed to
+// by out and decodes them into it. Every problem is reported, missing required
+// fields first. Normalized values, such as upper case currency codes, are
+// written back to args so that checks working on the raw arguments see them.
+// Arguments without a field, e.g. dry_run, are left to the middlewares.
+func Decode(args map[string]any, out any) error {
+	v := reflect.ValueOf(out)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: decode target must be a pointer to a struct", constants.ErrInvalidType)
+	}
+
+	if args == nil {
+		args = make(map[string]any)
+	}
+
+	var d decoder
+	d.object(v.Elem().Type(), args, "")
+
+	if err := d.err(); err != nil {
+		return err
+	}
+
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(raw, out)
+}
+
+// decoder collects the problems found in the arguments
+type decoder struct {
+	missing  []string
+	problems []error
+}
+
+// err returns the problems found, or nil
+func (d *decoder) err() error {
+	errs := make([]error, 0, len(d.problems)+1)
+	if len(d.missing) > 0 {
+		errs = append(errs, utils.WrapMissingFieldsError(d.missing))
+	}
+
+	return errors.Join(append(errs, d.problems...)...)
+}
+
+// object checks the properties of a struct value
+func (d *decoder) object(t reflect.Type, m map[string]any, prefix string) {
+	for _, f := range fields(t) {
+		path := prefix + f.name
+
+		v, ok := m[f.name]
+		if !ok || v == nil || v == "" {
+			if f.required {
+				d.missing = append(d.missing, path)
+			}
+
+			continue
+		}
+
+		m[f.name] = d.value(f.typ, f, v, path)
+	}
+}
+
+// value checks a value of type t described by f and returns it normalized
+func (d *decoder) value(t reflect.Type, f field, v any, path string) any {
+	t = indirect(t)
+
+	kind := f.jsonType
+	if kind == "" {
+		kind = jsonType(t)
+	}
+
+	switch kind {
+	case typeString:
+		s, ok := v.(string)
+		if !ok {
+			return d.typeError(path, kind, v)
+		}
+
+		return d.text(f, s, path)
+	case typeBoolean:
+		if _, ok := v.(bool); !ok {
+			return d.typeError(path, kind, v)
+		}
+	case typeInteger:
+		n, ok := v.(float64)
+		if !ok || n != math.Trunc(n) {
+			return d.typeError(path, kind, v)
+		}
+
+		d.number(f, n, path)
+	case typeNumber:
+		n, ok := v.(float64)
+		if !ok {
+			return d.typeError(path, kind, v)
+		}
+
+		d.number(f, n, path)
+	case typeArray:
+		items, ok := v.([]any)
+		if !ok {
+			return d.typeError(path, kind, v)
+		}
+
+		if t.Kind() == reflect.Slice || t.Kind() == reflect.Array {
+			for i, item := range items {
+				if item != nil {
+					items[i] = d.value(t.Elem(), field{}, item, fmt.Sprintf("%s[%d]", path, i))
+				}
+			}
+		}
+	case typeObject:
+		m, ok := v.(map[string]any)
+		if !ok {
+			return d.typeError(path, kind, v)
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			d.object(t, m, path+".")
+		case reflect.Map:
+			for key, item := range m {
+				if item != nil {
+					m[key] = d.value(t.Elem(), field{}, item, path+"."+key)
+				}
+			}
+		}
+	}
+
+	return v
+}
+
+// number checks a number against the bounds of f
+func (d *decoder) number(f field, n float64, path string) {
+	if f.positive && n <= 0 {
+		d.problems = append(d.problems, fmt.Errorf("%w: %s must be greater than 0, got %v",
+			constants.ErrInvalidFieldValue, path, n))
+	}
+}
+
+// text checks a string against the enum, prefix and format of f
+func (d *decoder) text(f field, s, path string) string {
+	if len(f.enum) > 0 && !slices.Contains(f.enum, s) {
+		d.problems = append(d.problems, fmt.Errorf("%w: %s must be one of %s, got %q",
+			constants.ErrInvalidFieldValue, path, strings.Join(f.enum, ", "), s))
+
+		return s
+	}
+
+	if f.prefix != "" {
+		if err := utils.ValidatePrefixID(f.prefix, s); err != nil {
+			d.problems = append(d.problems, fmt.Errorf("%s: %w", path, err))
+			return s
+		}
+	}
+
+	var err error
+
+	switch f.format {
+	case FormatCurrency:
+		var code string
+		if code, err = utils.NormalizeCurrency(s); err == nil {
+			return code
+		}
+	case FormatCountry:
+		var code string
+		if code, err = utils.NormalizeCountry(s); err == nil {
+			return code
+		}
+	case FormatEmail:
+		if _, parseErr := mail.ParseAddress(s); parseErr != nil {
+			err = fmt.Errorf("%w: %q is not an email address", constants.ErrInvalidFieldValue, s)
+		}
+	case FormatURI:
+		if u, parseErr := url.Parse(s); parseErr != nil || u.Scheme == "" || u.Host == "" {
+			err = fmt.Errorf("%w: %q is not an absolute URL", constants.ErrInvalidFieldValue, s)
+		}
+	case FormatDateTime:
+		if _, parseErr := time.Parse(time.RFC3339, s); parseErr != nil {
+			err = fmt.Errorf("%w: %q is not an RFC 3339 time", constants.ErrInvalidFieldValue, s)
+		}
+	}
+
+	if err != nil {
+		d.problems = append(d.problems, fmt.Errorf("%s: %w", path, err))
+	}
+
+	return s
+}
+
+// typeError records a value of the wrong JSON type
+func (d *decoder) typeError(path, want string, v any) any {
+	d.problems = append(d.problems, fmt.Errorf("%w: %s must be %s %s, got %s",
+		constants.ErrInvalidType, path, article(want), want, describe(v)))
+
+	return v
+}
+
+// describe names the JSON type of a decoded value
+func describe(v any) string {
+	switch v := v.(type) {
+	case string:
+		return fmt.Sprintf("string %q", v)
+	case bool:
+		return typeBoolean
+	case float64:
+		return typeNumber + " " + fmt.Sprint(v)
+	case []any:
+		return typeArray
+	case map[string]any:
+		return typeObject
+	default:
+		return fmt.Sprintf("%T", v)
+	}
+}
+
+// article returns the indefinite article of a JSON type name
+func article(kind string) string {
+	if strings.ContainsRune("aeiou", rune(kind[0])) {
+		return "an"
+	}
+
+	return "a"
+}
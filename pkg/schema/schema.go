@@ -0,0 +1,263 @@
+// Package schema generates tool input and output schemas from Go structs and
+// decodes tool arguments into them, so that the schema a client sees and the
+// validation a tool runs come from the same struct tags.
+//
+// Besides the json tag naming the property, fields are described by:
+//
+//	description:"Amount in major units"                      property description
+//	schema:"required,enum=local|swift,format=currency"       options, comma separated
+//
+// The options are required, enum (values separated by |), format, type
+// (overrides the JSON type derived from the Go type, e.g. for any fields),
+// prefix (an ID prefix, such as pot_) and positive (a number above zero). The formats checked by Decode are
+// currency, country, email, uri and date-time; currency and country codes are
+// normalized to upper case. time.Time fields are date-time strings. Embedded
+// structs without a json name are inlined.
+package schema
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// JSON types
+const (
+	typeString  = "string"
+	typeBoolean = "boolean"
+	typeInteger = "integer"
+	typeNumber  = "number"
+	typeArray   = "array"
+	typeObject  = "object"
+)
+
+// Formats checked by Decode
+const (
+	FormatCurrency = "currency"
+	FormatCountry  = "country"
+	FormatEmail    = "email"
+	FormatURI      = "uri"
+	FormatDateTime = "date-time"
+)
+
+// field is a struct field seen as a JSON property
+type field struct {
+	name        string
+	typ         reflect.Type
+	description string
+	jsonType    string
+	format      string
+	prefix      string
+	enum        []string
+	required    bool
+	positive    bool
+}
+
+// timeType is encoded as an RFC 3339 string
+var timeType = reflect.TypeFor[time.Time]()
+
+// fieldCache holds the fields of the struct types seen so far
+var fieldCache sync.Map // reflect.Type -> []field
+
+// Input declares the input schema of a tool from the fields of the struct T
+func Input[T any]() mcp.ToolOption {
+	properties, required := object(reflect.TypeFor[T]())
+
+	return func(t *mcp.Tool) {
+		t.InputSchema.Type = typeObject
+		t.InputSchema.Properties = properties
+		t.InputSchema.Required = required
+	}
+}
+
+// Output declares the structured output schema of a tool from the fields of
+// the struct T. Output fields are not tagged required, so that results
+// returned without running the call, such as dry runs and confirmation
+// summaries, conform too.
+func Output[T any]() mcp.ToolOption {
+	properties, required := object(reflect.TypeFor[T]())
+
+	return func(t *mcp.Tool) {
+		t.OutputSchema.Type = typeObject
+		t.OutputSchema.Properties = properties
+		t.OutputSchema.Required = required
+	}
+}
+
+// object returns the properties and required property names of a struct type
+func object(t reflect.Type) (map[string]any, []string) {
+	properties := make(map[string]any)
+	required := make([]string, 0)
+
+	for _, f := range fields(t) {
+		properties[f.name] = property(f.typ, f)
+
+		if f.required {
+			required = append(required, f.name)
+		}
+	}
+
+	return properties, required
+}
+
+// property returns the schema of a value of type t, described by f when the
+// value is a struct field
+func property(t reflect.Type, f field) map[string]any {
+	t = indirect(t)
+	s := make(map[string]any)
+
+	kind := f.jsonType
+	if kind == "" {
+		kind = jsonType(t)
+	}
+
+	if kind != "" {
+		s[constants.KeyType] = kind
+	}
+
+	if f.description != "" {
+		s[constants.KeyDescription] = f.description
+	}
+
+	if len(f.enum) > 0 {
+		s["enum"] = f.enum
+	}
+
+	if f.format != "" {
+		s["format"] = f.format
+	}
+
+	if f.prefix != "" {
+		s["pattern"] = "^" + f.prefix
+	}
+
+	if f.positive {
+		s["exclusiveMinimum"] = 0
+	}
+
+	switch {
+	case kind == typeArray && (t.Kind() == reflect.Slice || t.Kind() == reflect.Array):
+		s["items"] = property(t.Elem(), field{})
+	case kind == typeObject && t.Kind() == reflect.Struct:
+		properties, required := object(t)
+		s[constants.KeyProperties] = properties
+
+		if len(required) > 0 {
+			s["required"] = required
+		}
+	case kind == typeObject && t.Kind() == reflect.Map && indirect(t.Elem()).Kind() != reflect.Interface:
+		s["additionalProperties"] = property(t.Elem(), field{})
+	}
+
+	return s
+}
+
+// fields returns the JSON properties of a struct type, inlining embedded structs
+func fields(t reflect.Type) []field {
+	if cached, ok := fieldCache.Load(t); ok {
+		return cached.([]field)
+	}
+
+	list := make([]field, 0, t.NumField())
+
+	for i := range t.NumField() {
+		sf := t.Field(i)
+
+		name, _, _ := strings.Cut(sf.Tag.Get("json"), ",")
+		if name == "-" || (!sf.IsExported() && !sf.Anonymous) {
+			continue
+		}
+
+		if sf.Anonymous && name == "" && indirect(sf.Type).Kind() == reflect.Struct {
+			list = append(list, fields(indirect(sf.Type))...)
+
+			continue
+		}
+
+		if name == "" {
+			name = sf.Name
+		}
+
+		f := field{
+			name:        name,
+			typ:         sf.Type,
+			description: sf.Tag.Get("description"),
+		}
+		parseOptions(&f, sf.Tag.Get("schema"))
+
+		if indirect(sf.Type) == timeType && f.format == "" {
+			f.format = FormatDateTime
+		}
+
+		list = append(list, f)
+	}
+
+	fieldCache.Store(t, list)
+
+	return list
+}
+
+// parseOptions reads the schema tag of a field
+func parseOptions(f *field, tag string) {
+	for option := range strings.SplitSeq(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+
+		switch key {
+		case "required":
+			f.required = true
+		case "enum":
+			f.enum = strings.Split(value, "|")
+		case "format":
+			f.format = value
+		case "type":
+			f.jsonType = value
+		case "prefix":
+			f.prefix = value
+		case "positive":
+			f.positive = true
+		}
+	}
+}
+
+// jsonType returns the JSON type of values of the Go type t, or "" for any
+func jsonType(t reflect.Type) string {
+	switch t {
+	case reflect.TypeFor[json.RawMessage]():
+		return ""
+	case timeType:
+		return typeString
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return typeString
+	case reflect.Bool:
+		return typeBoolean
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return typeInteger
+	case reflect.Float32, reflect.Float64:
+		return typeNumber
+	case reflect.Slice, reflect.Array:
+		return typeArray
+	case reflect.Map, reflect.Struct:
+		return typeObject
+	default:
+		return ""
+	}
+}
+
+// indirect returns the type pointed to by pointer types
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	return t
+}
@@ -0,0 +1,185 @@
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+type testAddress struct {
+	Country string `json:"country" schema:"required,format=country" description:"Country code"`
+	City    string `json:"city,omitempty"`
+}
+
+type testCommon struct {
+	Token string `json:"token,omitempty" description:"Embedded field"`
+}
+
+type testParams struct {
+	testCommon
+
+	Amount   float64           `json:"amount" schema:"required,positive" description:"Amount in major units"`
+	Currency string            `json:"currency" schema:"required,format=currency"`
+	Type     string            `json:"type,omitempty" schema:"enum=local|swift"`
+	ID       string            `json:"id,omitempty" schema:"prefix=pot_"`
+	Email    string            `json:"email,omitempty" schema:"format=email"`
+	Limit    int               `json:"limit,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	Address  *testAddress      `json:"address,omitempty"`
+	Metadata map[string]any    `json:"metadata,omitempty"`
+	Labels   map[string]string `json:"labels,omitempty"`
+	Raw      json.RawMessage   `json:"raw,omitempty" schema:"type=object"`
+	Ignored  string            `json:"-"`
+}
+
+func TestInput(t *testing.T) {
+	tool := mcp.NewTool("test", Input[testParams]())
+
+	got, err := json.Marshal(tool.InputSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"properties":{` +
+		`"address":{"properties":{"city":{"type":"string"},"country":{"description":"Country code","format":"country","type":"string"}},"required":["country"],"type":"object"},` +
+		`"amount":{"description":"Amount in major units","exclusiveMinimum":0,"type":"number"},` +
+		`"currency":{"format":"currency","type":"string"},` +
+		`"email":{"format":"email","type":"string"},` +
+		`"id":{"pattern":"^pot_","type":"string"},` +
+		`"labels":{"additionalProperties":{"type":"string"},"type":"object"},` +
+		`"limit":{"type":"integer"},` +
+		`"metadata":{"type":"object"},` +
+		`"raw":{"type":"object"},` +
+		`"tags":{"items":{"type":"string"},"type":"array"},` +
+		`"token":{"description":"Embedded field","type":"string"},` +
+		`"type":{"enum":["local","swift"],"type":"string"}},` +
+		`"required":["amount","currency"],"type":"object"}`
+	if string(got) != want {
+		t.Errorf("input schema\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+type testOutput struct {
+	ID      string          `json:"id"`
+	Status  string          `json:"status" schema:"enum=created|failed"`
+	At      time.Time       `json:"at" description:"When it happened"`
+	Changes []testChange    `json:"changes,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+type testChange struct {
+	At *time.Time `json:"at"`
+}
+
+func TestOutput(t *testing.T) {
+	tool := mcp.NewTool("test", Output[testOutput]())
+
+	got, err := json.Marshal(tool.OutputSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"properties":{` +
+		`"at":{"description":"When it happened","format":"date-time","type":"string"},` +
+		`"changes":{"items":{"properties":{"at":{"format":"date-time","type":"string"}},"type":"object"},"type":"array"},` +
+		`"data":{},` +
+		`"id":{"type":"string"},` +
+		`"status":{"enum":["created","failed"],"type":"string"}},` +
+		`"required":[],"type":"object"}`
+	if string(got) != want {
+		t.Errorf("output schema\ngot:  %s\nwant: %s", got, want)
+	}
+}
+
+func TestDecode(t *testing.T) {
+	args := map[string]any{
+		"amount": 10.5, "currency": "usd", "type": "swift", "id": "pot_123", "limit": float64(3),
+		"tags": []any{"a"}, "address": map[string]any{"country": "in"}, "metadata": map[string]any{"k": 1.0},
+		"token": "abc", "dry_run": true,
+	}
+
+	var p testParams
+	if err := Decode(args, &p); err != nil {
+		t.Fatalf("Decode() = %v", err)
+	}
+
+	if p.Amount != 10.5 || p.Currency != "USD" || p.Type != "swift" || p.Limit != 3 || p.Token != "abc" ||
+		p.Address == nil || p.Address.Country != "IN" || p.Metadata["k"] != 1.0 || len(p.Tags) != 1 {
+		t.Errorf("Decode() decoded %+v", p)
+	}
+
+	if args["currency"] != "USD" {
+		t.Errorf("Decode() left currency %v in the arguments; want USD", args["currency"])
+	}
+}
+
+func TestDecodeErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		is   error
+		want []string // substrings of the error
+	}{
+		{"missing", map[string]any{"currency": ""}, constants.ErrMissingRequiredFields,
+			[]string{"amount, currency"}},
+		{"nested missing", map[string]any{"amount": 1.0, "currency": "USD", "address": map[string]any{}},
+			constants.ErrMissingRequiredFields, []string{"address.country"}},
+		{"wrong type", map[string]any{"amount": "10", "currency": "USD"}, constants.ErrInvalidType,
+			[]string{`amount must be a number, got string "10"`}},
+		{"fraction for integer", map[string]any{"amount": 1.0, "currency": "USD", "limit": 1.5},
+			constants.ErrInvalidType, []string{"limit must be an integer"}},
+		{"object for string", map[string]any{"amount": 1.0, "currency": "USD", "email": map[string]any{}},
+			constants.ErrInvalidType, []string{"email must be a string, got object"}},
+		{"array item", map[string]any{"amount": 1.0, "currency": "USD", "tags": []any{"a", 2.0}},
+			constants.ErrInvalidType, []string{"tags[1] must be a string"}},
+		{"enum", map[string]any{"amount": 1.0, "currency": "USD", "type": "wire"}, constants.ErrInvalidFieldValue,
+			[]string{"type must be one of local, swift"}},
+		{"currency", map[string]any{"amount": 1.0, "currency": "UDS"}, constants.ErrInvalidCurrencyFormat,
+			[]string{"currency: ", "did you mean USD"}},
+		{"country", map[string]any{"amount": 1.0, "currency": "USD", "address": map[string]any{"country": "XX"}},
+			constants.ErrInvalidCountryFormat, []string{"address.country"}},
+		{"prefix", map[string]any{"amount": 1.0, "currency": "USD", "id": "bnf_1"}, constants.ErrInvalidIDFormat,
+			[]string{"id: ", "pot_"}},
+		{"email", map[string]any{"amount": 1.0, "currency": "USD", "email": "nope"}, constants.ErrInvalidFieldValue,
+			[]string{"not an email address"}},
+		{"positive", map[string]any{"amount": 0.0, "currency": "USD"}, constants.ErrInvalidFieldValue,
+			[]string{"amount must be greater than 0"}},
+		{"map value", map[string]any{"amount": 1.0, "currency": "USD", "labels": map[string]any{"k": true}},
+			constants.ErrInvalidType, []string{"labels.k must be a string"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var p testParams
+
+			err := Decode(test.args, &p)
+			if !errors.Is(err, test.is) {
+				t.Fatalf("Decode() = %v; want %v", err, test.is)
+			}
+
+			for _, want := range test.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Decode() = %q; want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeReportsEveryProblem(t *testing.T) {
+	var p testParams
+
+	err := Decode(map[string]any{"amount": "1", "type": "wire"}, &p)
+	for _, is := range []error{constants.ErrMissingRequiredFields, constants.ErrInvalidType,
+		constants.ErrInvalidFieldValue} {
+		if !errors.Is(err, is) {
+			t.Errorf("Decode() = %v; want it to wrap %v", err, is)
+		}
+	}
+}
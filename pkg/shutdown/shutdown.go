@@ -0,0 +1,117 @@
+// Package shutdown lets the server finish the tool calls in flight before it
+// exits on SIGTERM or SIGINT.
+package shutdown
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// DefaultTimeout is how long in-flight tool calls may run after a shutdown signal
+const DefaultTimeout = 30 * time.Second
+
+// Timeout returns TAZAPAY_SHUTDOWN_TIMEOUT, or DefaultTimeout when it is unset or invalid
+func Timeout() time.Duration {
+	if timeout := viper.GetDuration(constants.StrTAZAPAYShutdownTimeout); timeout > 0 {
+		return timeout
+	}
+
+	return DefaultTimeout
+}
+
+// Drainer tracks the tool calls in flight. Once draining starts, new calls are
+// refused and Drain waits for the running ones.
+type Drainer struct {
+	mu       sync.Mutex
+	inFlight int
+	draining bool
+	// idle is closed once draining with no call in flight
+	idle chan struct{}
+}
+
+// NewDrainer returns a drainer accepting calls
+func NewDrainer() *Drainer {
+	return &Drainer{idle: make(chan struct{})}
+}
+
+// Middleware returns a tool middleware counting the calls in flight and
+// refusing new calls while the server shuts down
+func (d *Drainer) Middleware() types.ToolMiddleware {
+	return func(_ types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			if !d.enter() {
+				return mcp.NewToolResultError(constants.ErrShuttingDown.Error()), nil
+			}
+			defer d.leave()
+
+			return next(ctx, req)
+		}
+	}
+}
+
+// Drain refuses new calls and waits until the calls in flight have finished or
+// ctx is done. It returns an error wrapping ErrDrainTimeout when calls are still
+// running.
+func (d *Drainer) Drain(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.draining {
+		d.draining = true
+		if d.inFlight == 0 {
+			close(d.idle)
+		}
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.idle:
+		return nil
+	case <-ctx.Done():
+		// the calls may have finished just as the deadline passed
+		if n := d.InFlight(); n > 0 {
+			return fmt.Errorf("%w: %d tool calls still running", constants.ErrDrainTimeout, n)
+		}
+
+		return nil
+	}
+}
+
+// InFlight returns the number of tool calls running
+func (d *Drainer) InFlight() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return d.inFlight
+}
+
+// enter counts a new call, or reports false while draining
+func (d *Drainer) enter() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.draining {
+		return false
+	}
+
+	d.inFlight++
+
+	return true
+}
+
+// leave counts a finished call
+func (d *Drainer) leave() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.inFlight--
+	if d.draining && d.inFlight == 0 {
+		close(d.idle)
+	}
+}
@@ -0,0 +1,92 @@
+package shutdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestTimeout(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{"", DefaultTimeout},
+		{"45s", 45 * time.Second},
+		{"2m", 2 * time.Minute},
+		{"soon", DefaultTimeout},
+		{"-5s", DefaultTimeout},
+	}
+
+	for _, test := range tests {
+		viper.Set(constants.StrTAZAPAYShutdownTimeout, test.value)
+
+		if got := Timeout(); got != test.want {
+			t.Errorf("Timeout() with %q = %v; want %v", test.value, got, test.want)
+		}
+	}
+
+	viper.Set(constants.StrTAZAPAYShutdownTimeout, nil)
+}
+
+func TestDrain(t *testing.T) {
+	drainer := NewDrainer()
+	release := make(chan struct{})
+	started := make(chan struct{})
+
+	handler := drainer.Middleware()(nil, func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		close(started)
+		<-release
+
+		return mcp.NewToolResultText("done"), nil
+	})
+
+	results := make(chan *mcp.CallToolResult, 1)
+	go func() {
+		result, _ := handler(context.Background(), mcp.CallToolRequest{})
+		results <- result
+	}()
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	if err := drainer.Drain(ctx); !errors.Is(err, constants.ErrDrainTimeout) {
+		t.Fatalf("Drain() with a call running = %v; want %v", err, constants.ErrDrainTimeout)
+	}
+
+	refused, err := handler(context.Background(), mcp.CallToolRequest{})
+	if err != nil || !refused.IsError {
+		t.Errorf("call while draining = %v, %v; want an error result", refused, err)
+	}
+
+	close(release)
+
+	if err := drainer.Drain(context.Background()); err != nil {
+		t.Errorf("Drain() after the call finished = %v; want nil", err)
+	}
+
+	if result := <-results; result.IsError {
+		t.Errorf("call in flight result = %v; want success", result)
+	}
+
+	if n := drainer.InFlight(); n != 0 {
+		t.Errorf("InFlight() = %d; want 0", n)
+	}
+}
+
+func TestDrainIdle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// with nothing in flight, draining succeeds even past the deadline
+	if err := NewDrainer().Drain(ctx); err != nil {
+		t.Errorf("Drain() = %v; want nil", err)
+	}
+}
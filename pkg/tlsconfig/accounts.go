@@ -0,0 +1,174 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// Account maps a client certificate, by subject common name or SHA-256
+// fingerprint, to the Tazapay API credentials used for its calls.
+//
+// Example `.tazapay-mcp-server.yaml` section:
+//
+//	TAZAPAY_TLS_CLIENT_ACCOUNTS:
+//	  - common_name: payouts-agent
+//	    api_key: ak_live_123
+//	    api_secret: sk_live_456
+//	  - fingerprint: "9f:86:d0:81:..."
+//	    api_key: ak_live_789
+//	    api_secret: sk_live_012
+type Account struct {
+	CommonName  string `json:"common_name" mapstructure:"common_name"`
+	Fingerprint string `json:"fingerprint" mapstructure:"fingerprint"`
+	APIKey      string `json:"api_key"     mapstructure:"api_key"`
+	APISecret   string `json:"api_secret"  mapstructure:"api_secret"`
+}
+
+// Accounts resolves verified client certificates to Tazapay auth tokens
+type Accounts struct {
+	byCommonName  map[string]string
+	byFingerprint map[string]string
+}
+
+// AccountsFromViper reads the TAZAPAY_TLS_CLIENT_ACCOUNTS section of the config
+// file, or a JSON list in the TAZAPAY_TLS_CLIENT_ACCOUNTS environment variable.
+// It returns nil when no account is configured.
+func AccountsFromViper() (*Accounts, error) {
+	var list []Account
+
+	switch raw := viper.Get(constants.StrTAZAPAYTLSClientAccounts).(type) {
+	case nil:
+		return nil, nil
+	case string:
+		if raw == "" {
+			return nil, nil
+		}
+
+		if err := json.Unmarshal([]byte(raw), &list); err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrInvalidTLSConfig, err)
+		}
+	default:
+		if err := viper.UnmarshalKey(constants.StrTAZAPAYTLSClientAccounts, &list); err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrInvalidTLSConfig, err)
+		}
+	}
+
+	if len(list) == 0 {
+		return nil, nil
+	}
+
+	return NewAccounts(list)
+}
+
+// NewAccounts indexes list, refusing entries without a certificate match or credentials
+func NewAccounts(list []Account) (*Accounts, error) {
+	a := &Accounts{byCommonName: map[string]string{}, byFingerprint: map[string]string{}}
+
+	for i, account := range list {
+		if account.APIKey == "" || account.APISecret == "" {
+			return nil, fmt.Errorf("%w: client account %d has no api_key or api_secret", constants.ErrInvalidTLSConfig, i)
+		}
+
+		token := base64.StdEncoding.EncodeToString([]byte(account.APIKey + ":" + account.APISecret))
+
+		switch {
+		case account.Fingerprint != "":
+			a.byFingerprint[normalizeFingerprint(account.Fingerprint)] = token
+		case account.CommonName != "":
+			a.byCommonName[account.CommonName] = token
+		default:
+			return nil, fmt.Errorf("%w: client account %d has no common_name or fingerprint", constants.ErrInvalidTLSConfig, i)
+		}
+	}
+
+	return a, nil
+}
+
+// Len returns the number of mapped certificates
+func (a *Accounts) Len() int {
+	return len(a.byCommonName) + len(a.byFingerprint)
+}
+
+// Token returns the auth token of a verified client certificate. The
+// fingerprint wins over the common name when both are mapped.
+func (a *Accounts) Token(cert *x509.Certificate) (string, bool) {
+	sum := sha256.Sum256(cert.Raw)
+	if token, ok := a.byFingerprint[hex.EncodeToString(sum[:])]; ok {
+		return token, true
+	}
+
+	token, ok := a.byCommonName[cert.Subject.CommonName]
+
+	return token, ok
+}
+
+// HTTPContextFunc authenticates requests made over a mapped client certificate
+// with the account credentials, ignoring any Authorization header. Other
+// requests are passed to next.
+func (a *Accounts) HTTPContextFunc(next server.HTTPContextFunc) server.HTTPContextFunc {
+	if a == nil {
+		return next
+	}
+
+	return func(ctx context.Context, r *http.Request) context.Context {
+		cert := verifiedCert(r)
+		if cert == nil {
+			return next(ctx, r)
+		}
+
+		token, ok := a.Token(cert)
+		if !ok {
+			return next(ctx, r)
+		}
+
+		return utils.WithAuthToken(ctx, token)
+	}
+}
+
+// RequireClientCert refuses requests without a verified client certificate and,
+// when accounts is not nil, those whose certificate it does not map
+func RequireClientCert(next http.Handler, accounts *Accounts) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cert := verifiedCert(r)
+		if cert == nil {
+			http.Error(w, constants.ErrClientCertRequired.Error(), http.StatusUnauthorized)
+			return
+		}
+
+		if accounts != nil {
+			if _, ok := accounts.Token(cert); !ok {
+				http.Error(w, constants.ErrUnknownClientCert.Error(), http.StatusForbidden)
+				return
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// verifiedCert returns the client certificate of r when it chains to a trusted CA
+func verifiedCert(r *http.Request) *x509.Certificate {
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return nil
+	}
+
+	return r.TLS.VerifiedChains[0][0]
+}
+
+// normalizeFingerprint lowercases a hex fingerprint and drops the colon separators
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ToLower(strings.ReplaceAll(fingerprint, ":", ""))
+}
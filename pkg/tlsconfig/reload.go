@@ -0,0 +1,126 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// reloadInterval is how often handshakes look at the certificate files for changes
+const reloadInterval = 5 * time.Second
+
+// reloader keeps the certificate and client CA pool loaded from disk, reloading
+// them when one of the files changes. A file that fails to load keeps the
+// previous version in use, so a half-written rotation does not drop clients.
+type reloader struct {
+	cfg    Config
+	logger *slog.Logger
+	now    func() time.Time
+
+	mu        sync.Mutex
+	checked   time.Time
+	modTimes  map[string]time.Time
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+}
+
+// newReloader loads the files of cfg, failing when any of them is invalid
+func newReloader(cfg Config, logger *slog.Logger) (*reloader, error) {
+	r := &reloader{cfg: cfg, logger: logger, now: time.Now}
+
+	if err := r.load(); err != nil {
+		return nil, err
+	}
+
+	r.checked = r.now()
+
+	return r, nil
+}
+
+// current returns the certificate and client CA pool, reloading them first when
+// the files changed since the last look
+func (r *reloader) current() (*tls.Certificate, *x509.CertPool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.now().Sub(r.checked) >= reloadInterval {
+		r.checked = r.now()
+
+		if r.changed() {
+			if err := r.load(); err != nil {
+				r.logger.ErrorContext(context.Background(), "Failed to reload TLS files, keeping the previous ones",
+					constants.KeyError, err)
+			} else {
+				r.logger.InfoContext(context.Background(), "Reloaded TLS files", "cert", r.cfg.CertFile)
+			}
+		}
+	}
+
+	return r.cert, r.clientCAs
+}
+
+// files returns the paths watched for changes
+func (r *reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.MutualTLS() {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+
+	return files
+}
+
+// changed reports whether a file has a different modification time than at the last load
+func (r *reloader) changed() bool {
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil || !info.ModTime().Equal(r.modTimes[file]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// load reads every file, replacing the loaded versions only when all of them are valid
+func (r *reloader) load() error {
+	modTimes := make(map[string]time.Time)
+
+	for _, file := range r.files() {
+		info, err := os.Stat(file)
+		if err != nil {
+			return fmt.Errorf("%w: %w", constants.ErrInvalidTLSConfig, err)
+		}
+
+		modTimes[file] = info.ModTime()
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("%w: %w", constants.ErrInvalidTLSConfig, err)
+	}
+
+	var clientCAs *x509.CertPool
+
+	if r.cfg.MutualTLS() {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("%w: %w", constants.ErrInvalidTLSConfig, err)
+		}
+
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("%w: no certificate found in %s", constants.ErrInvalidTLSConfig, r.cfg.ClientCAFile)
+		}
+	}
+
+	r.cert, r.clientCAs, r.modTimes = &cert, clientCAs, modTimes
+
+	return nil
+}
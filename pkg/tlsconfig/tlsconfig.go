@@ -0,0 +1,112 @@
+// Package tlsconfig builds the TLS configuration of the streamable HTTP server,
+// with optional client certificate authentication mapped to Tazapay accounts.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"fmt"
+	"log/slog"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Config locates the server certificate and, for mutual TLS, the CA bundle
+// client certificates must chain to
+type Config struct {
+	CertFile     string
+	KeyFile      string
+	MinVersion   uint16
+	ClientCAFile string
+}
+
+// minVersions lists the accepted TAZAPAY_TLS_MIN_VERSION values
+var minVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// ConfigFromViper reads the TAZAPAY_TLS_* settings. TLS is off when neither
+// the certificate nor the key is set.
+func ConfigFromViper() (Config, error) {
+	cfg := Config{
+		CertFile:     viper.GetString(constants.StrTAZAPAYTLSCertFile),
+		KeyFile:      viper.GetString(constants.StrTAZAPAYTLSKeyFile),
+		ClientCAFile: viper.GetString(constants.StrTAZAPAYTLSClientCAFile),
+	}
+
+	version := viper.GetString(constants.StrTAZAPAYTLSMinVersion)
+
+	minVersion, ok := minVersions[version]
+	if !ok {
+		return cfg, fmt.Errorf("%w: %s must be 1.2 or 1.3, got %q",
+			constants.ErrInvalidTLSConfig, constants.StrTAZAPAYTLSMinVersion, version)
+	}
+
+	cfg.MinVersion = minVersion
+
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		return cfg, fmt.Errorf("%w: set both %s and %s",
+			constants.ErrInvalidTLSConfig, constants.StrTAZAPAYTLSCertFile, constants.StrTAZAPAYTLSKeyFile)
+	}
+
+	if cfg.ClientCAFile != "" && !cfg.Enabled() {
+		return cfg, fmt.Errorf("%w: %s needs a server certificate",
+			constants.ErrInvalidTLSConfig, constants.StrTAZAPAYTLSClientCAFile)
+	}
+
+	return cfg, nil
+}
+
+// Enabled reports whether the server should serve TLS
+func (c Config) Enabled() bool {
+	return c.CertFile != ""
+}
+
+// MutualTLS reports whether clients must present a certificate
+func (c Config) MutualTLS() bool {
+	return c.ClientCAFile != ""
+}
+
+// Load reads the certificate, key and client CA bundle and returns a server TLS
+// configuration picking up changes to those files without a restart. With mutual
+// TLS a client certificate is verified when one is presented, but not required:
+// probes and webhook deliveries connect without one, and RequireClientCert
+// enforces it on the MCP endpoint.
+func Load(cfg Config, logger *slog.Logger) (*tls.Config, error) {
+	files, err := newReloader(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	base := &tls.Config{
+		MinVersion: cfg.MinVersion,
+		// the HTTP server adds h2 to its own copy of the config, which
+		// GetConfigForClient bypasses
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	if cfg.MutualTLS() {
+		base.ClientAuth = tls.VerifyClientCertIfGiven
+	}
+
+	base.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		cert, _ := files.current()
+		return cert, nil
+	}
+
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		cert, clientCAs := files.current()
+
+		conn := base.Clone()
+		conn.Certificates = []tls.Certificate{*cert}
+		conn.ClientCAs = clientCAs
+		conn.GetConfigForClient = nil
+
+		return conn, nil
+	}
+
+	return base, nil
+}
@@ -0,0 +1,402 @@
+package tlsconfig
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// testCA issues certificates for the tests
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	pem  []byte
+}
+
+func newTestCA(t *testing.T) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCA{cert: cert, key: key, pem: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+}
+
+// issue returns a leaf certificate for commonName as a key pair and its PEM encodings
+func (ca *testCA) issue(t *testing.T, commonName string, usage x509.ExtKeyUsage) (tls.Certificate, []byte, []byte) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	pair, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pair, certPEM, keyPEM
+}
+
+// writeFiles writes the server certificate, key and client CA bundle to dir
+func writeFiles(t *testing.T, dir string, certPEM, keyPEM, caPEM []byte) Config {
+	t.Helper()
+
+	cfg := Config{
+		CertFile:     filepath.Join(dir, "server.crt"),
+		KeyFile:      filepath.Join(dir, "server.key"),
+		ClientCAFile: filepath.Join(dir, "clients.crt"),
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	for file, data := range map[string][]byte{cfg.CertFile: certPEM, cfg.KeyFile: keyPEM, cfg.ClientCAFile: caPEM} {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	return cfg
+}
+
+func TestConfigFromViper(t *testing.T) {
+	tests := []struct {
+		name     string
+		settings map[string]string
+		want     uint16
+		wantErr  bool
+	}{
+		{"disabled", nil, tls.VersionTLS12, false},
+		{"cert and key", map[string]string{
+			constants.StrTAZAPAYTLSCertFile: "a.crt", constants.StrTAZAPAYTLSKeyFile: "a.key",
+		}, tls.VersionTLS12, false},
+		{"tls 1.3", map[string]string{constants.StrTAZAPAYTLSMinVersion: "1.3"}, tls.VersionTLS13, false},
+		{"tls 1.1", map[string]string{constants.StrTAZAPAYTLSMinVersion: "1.1"}, 0, true},
+		{"cert without key", map[string]string{constants.StrTAZAPAYTLSCertFile: "a.crt"}, 0, true},
+		{"client CA without cert", map[string]string{constants.StrTAZAPAYTLSClientCAFile: "ca.crt"}, 0, true},
+	}
+
+	keys := []string{
+		constants.StrTAZAPAYTLSCertFile, constants.StrTAZAPAYTLSKeyFile,
+		constants.StrTAZAPAYTLSMinVersion, constants.StrTAZAPAYTLSClientCAFile,
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, key := range keys {
+				viper.Set(key, test.settings[key])
+			}
+
+			cfg, err := ConfigFromViper()
+			if gotErr := errors.Is(err, constants.ErrInvalidTLSConfig); gotErr != test.wantErr {
+				t.Fatalf("ConfigFromViper() error = %v; want error %v", err, test.wantErr)
+			}
+
+			if !test.wantErr && cfg.MinVersion != test.want {
+				t.Errorf("MinVersion = %x; want %x", cfg.MinVersion, test.want)
+			}
+		})
+	}
+
+	for _, key := range keys {
+		viper.Set(key, nil)
+	}
+}
+
+func TestNewAccounts(t *testing.T) {
+	tests := []struct {
+		name    string
+		account Account
+		wantErr bool
+	}{
+		{"common name", Account{CommonName: "agent", APIKey: "ak", APISecret: "sk"}, false},
+		{"fingerprint", Account{Fingerprint: "AB:CD", APIKey: "ak", APISecret: "sk"}, false},
+		{"no match", Account{APIKey: "ak", APISecret: "sk"}, true},
+		{"no secret", Account{CommonName: "agent", APIKey: "ak"}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewAccounts([]Account{test.account})
+			if gotErr := errors.Is(err, constants.ErrInvalidTLSConfig); gotErr != test.wantErr {
+				t.Errorf("NewAccounts() error = %v; want error %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	ca := newTestCA(t)
+	otherCA := newTestCA(t)
+
+	_, serverCert, serverKey := ca.issue(t, "server", x509.ExtKeyUsageServerAuth)
+	agent, _, _ := ca.issue(t, "payouts-agent", x509.ExtKeyUsageClientAuth)
+	pinned, _, _ := ca.issue(t, "pinned", x509.ExtKeyUsageClientAuth)
+	unmapped, _, _ := ca.issue(t, "unmapped", x509.ExtKeyUsageClientAuth)
+	foreign, _, _ := otherCA.issue(t, "payouts-agent", x509.ExtKeyUsageClientAuth)
+
+	sum := sha256.Sum256(pinned.Certificate[0])
+
+	accounts, err := NewAccounts([]Account{
+		{CommonName: "payouts-agent", APIKey: "ak_agent", APISecret: "sk_agent"},
+		{Fingerprint: hex.EncodeToString(sum[:]), APIKey: "ak_pinned", APISecret: "sk_pinned"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := writeFiles(t, t.TempDir(), serverCert, serverKey, ca.pem)
+
+	config, err := Load(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authContext := accounts.HTTPContextFunc(func(ctx context.Context, _ *http.Request) context.Context {
+		return utils.WithAuthToken(ctx, "from-header")
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/stream", RequireClientCert(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, utils.AuthToken(authContext(r.Context(), r)))
+	}), accounts))
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, "ok")
+	})
+
+	srv := httptest.NewUnstartedServer(mux)
+	srv.TLS = config
+	srv.StartTLS()
+	defer srv.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	tests := []struct {
+		name     string
+		certs    []tls.Certificate
+		path     string
+		wantCode int
+		want     string
+	}{
+		{"mapped common name", []tls.Certificate{agent}, "/stream", http.StatusOK, token("ak_agent", "sk_agent")},
+		{"mapped fingerprint", []tls.Certificate{pinned}, "/stream", http.StatusOK, token("ak_pinned", "sk_pinned")},
+		{"unmapped certificate", []tls.Certificate{unmapped}, "/stream", http.StatusForbidden, ""},
+		{"no certificate", nil, "/stream", http.StatusUnauthorized, ""},
+		{"probe without certificate", nil, "/healthz", http.StatusOK, "ok"},
+		{"unknown CA", []tls.Certificate{foreign}, "/healthz", 0, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{
+				RootCAs:      roots,
+				Certificates: test.certs,
+				MinVersion:   tls.VersionTLS12,
+			}}}
+
+			resp, err := client.Get(srv.URL + test.path)
+			if test.wantCode == 0 {
+				if err == nil {
+					resp.Body.Close()
+					t.Fatal("request succeeded; want the handshake refused")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != test.wantCode {
+				t.Fatalf("status = %d; want %d", resp.StatusCode, test.wantCode)
+			}
+
+			body, _ := io.ReadAll(resp.Body)
+			if test.want != "" && string(body) != test.want {
+				t.Errorf("body = %q; want %q", body, test.want)
+			}
+		})
+	}
+}
+
+func TestHTTPContextFuncConcurrentAccounts(t *testing.T) {
+	ca := newTestCA(t)
+	first, _, _ := ca.issue(t, "first", x509.ExtKeyUsageClientAuth)
+	second, _, _ := ca.issue(t, "second", x509.ExtKeyUsageClientAuth)
+
+	accounts, err := NewAccounts([]Account{
+		{CommonName: "first", APIKey: "ak_1", APISecret: "sk_1"},
+		{CommonName: "second", APIKey: "ak_2", APISecret: "sk_2"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authContext := accounts.HTTPContextFunc(utils.AuthHeaderHTTPContextFunc)
+
+	request := func(cert tls.Certificate) *http.Request {
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		r := httptest.NewRequest(http.MethodPost, "/stream", http.NoBody)
+		r.TLS = &tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{leaf}}}
+
+		return r
+	}
+
+	r1, r2 := request(first), request(second)
+	ctx1 := authContext(r1.Context(), r1)
+	ctx2 := authContext(r2.Context(), r2)
+
+	// each request keeps its own credentials, whatever was handled after it
+	if got := utils.AuthToken(ctx1); got != token("ak_1", "sk_1") {
+		t.Errorf("first request token = %q; want the first account", got)
+	}
+
+	if got := utils.AuthToken(ctx2); got != token("ak_2", "sk_2") {
+		t.Errorf("second request token = %q; want the second account", got)
+	}
+}
+
+func TestReload(t *testing.T) {
+	ca := newTestCA(t)
+	_, firstCert, firstKey := ca.issue(t, "first", x509.ExtKeyUsageServerAuth)
+	_, secondCert, secondKey := ca.issue(t, "second", x509.ExtKeyUsageServerAuth)
+
+	cfg := writeFiles(t, t.TempDir(), firstCert, firstKey, ca.pem)
+
+	files, err := newReloader(cfg, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	files.now = func() time.Time { return now }
+
+	commonName := func() string {
+		cert, _ := files.current()
+
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return leaf.Subject.CommonName
+	}
+
+	// a rotation that only wrote the certificate is not picked up
+	if err := os.WriteFile(cfg.CertFile, secondCert, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rotated := now.Add(time.Minute)
+	if err := os.Chtimes(cfg.CertFile, rotated, rotated); err != nil {
+		t.Fatal(err)
+	}
+
+	now = now.Add(reloadInterval)
+	if got := commonName(); got != "first" {
+		t.Errorf("certificate with a mismatched key = %q; want the previous one", got)
+	}
+
+	if err := os.WriteFile(cfg.KeyFile, secondKey, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.Chtimes(cfg.KeyFile, rotated, rotated); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := commonName(); got != "first" {
+		t.Errorf("certificate before the reload interval = %q; want first", got)
+	}
+
+	now = now.Add(reloadInterval)
+	if got := commonName(); got != "second" {
+		t.Errorf("certificate after rotation = %q; want second", got)
+	}
+}
+
+// token returns the Basic auth token of an API key pair
+func token(key, secret string) string {
+	return base64.StdEncoding.EncodeToString([]byte(key + ":" + secret))
+}
@@ -0,0 +1,68 @@
+// Package toolname builds the published tool names from the base names of the
+// tool catalogue and the configured prefix.
+package toolname
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// prefixPattern keeps published names within the characters MCP allows in tool names
+var prefixPattern = regexp.MustCompile(`^[A-Za-z0-9_-]*$`)
+
+// Prefix returns the configured tool name prefix, TAZAPAY_TOOL_PREFIX or tazapay_ when unset
+func Prefix() string {
+	if !viper.IsSet(constants.StrTAZAPAYToolPrefix) {
+		return constants.ToolNamePrefixDefault
+	}
+
+	return viper.GetString(constants.StrTAZAPAYToolPrefix)
+}
+
+// Name returns the published name of the tool with the given base name
+func Name(base string) string {
+	return Prefix() + base + constants.ToolNameSuffix
+}
+
+// ValidatePrefix reports a prefix that is too long or has characters not allowed in tool names
+func ValidatePrefix(prefix string) error {
+	if len(prefix) > constants.ToolNamePrefixMaxLen || !prefixPattern.MatchString(prefix) {
+		return fmt.Errorf("%w: %q, use at most %d letters, digits, '_' or '-'",
+			constants.ErrInvalidToolPrefix, prefix, constants.ToolNamePrefixMaxLen)
+	}
+
+	return nil
+}
+
+// Legacy returns the deprecated alias of the tool with the given published name.
+// It reports false when the tool has none, or its legacy name is the published one.
+func Legacy(name string) (string, bool) {
+	for _, entry := range constants.ToolCatalogue {
+		if Name(entry.Name) == name {
+			return entry.Legacy, entry.Legacy != "" && entry.Legacy != name
+		}
+	}
+
+	return "", false
+}
+
+// Catalogued reports whether the published name belongs to a tool of the catalogue
+func Catalogued(name string) bool {
+	for _, entry := range constants.ToolCatalogue {
+		if Name(entry.Name) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// CorridorRequirementsHint returns the hint pointing the payout and beneficiary
+// tools at the published requirements tool
+func CorridorRequirementsHint() string {
+	return fmt.Sprintf(constants.CorridorRequirementsHint, Name(constants.DescribeRequirementsToolName))
+}
@@ -0,0 +1,81 @@
+package toolname
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name   string
+		prefix *string
+		want   string
+	}{
+		{"default prefix", nil, "tazapay_create_payout_tool"},
+		{"custom prefix", ptr("tzp_"), "tzp_create_payout_tool"},
+		{"empty prefix", ptr(""), "create_payout_tool"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			viper.Reset()
+			t.Cleanup(viper.Reset)
+
+			if test.prefix != nil {
+				viper.Set(constants.StrTAZAPAYToolPrefix, *test.prefix)
+			}
+
+			if got := Name(constants.CreatePayoutToolName); got != test.want {
+				t.Errorf("Name() = %q; want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestLegacy(t *testing.T) {
+	viper.Reset()
+
+	if alias, ok := Legacy("tazapay_create_payout_tool"); !ok || alias != "create_payout_tool" {
+		t.Errorf("Legacy() = %q, %v; want create_payout_tool, true", alias, ok)
+	}
+
+	// tools already published under the scheme keep a single name
+	if alias, ok := Legacy("tazapay_fetch_fx_tool"); ok {
+		t.Errorf("Legacy() = %q, true; want no alias", alias)
+	}
+
+	// with another prefix the old tazapay_ name becomes the alias
+	viper.Set(constants.StrTAZAPAYToolPrefix, "tzp_")
+	t.Cleanup(viper.Reset)
+
+	if alias, ok := Legacy("tzp_fetch_fx_tool"); !ok || alias != "tazapay_fetch_fx_tool" {
+		t.Errorf("Legacy() = %q, %v; want tazapay_fetch_fx_tool, true", alias, ok)
+	}
+}
+
+func TestValidatePrefix(t *testing.T) {
+	tests := []struct {
+		prefix string
+		valid  bool
+	}{
+		{"tazapay_", true},
+		{"", true},
+		{"acme-pay_", true},
+		{"tazapay.", false},
+		{"taza pay_", false},
+		{"a_very_long_prefix_that_goes_beyond_the_limit_", false},
+	}
+
+	for _, test := range tests {
+		if err := ValidatePrefix(test.prefix); (err == nil) != test.valid {
+			t.Errorf("ValidatePrefix(%q) = %v; want valid %v", test.prefix, err, test.valid)
+		}
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,91 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Span attributes of a tool call
+const (
+	attrMethod    = attribute.Key("mcp.method.name")
+	attrTool      = attribute.Key("gen_ai.tool.name")
+	attrSession   = attribute.Key("mcp.session.id")
+	attrObjectIDs = attribute.Key("tazapay.object_ids")
+	attrNotRun    = attribute.Key("tazapay.not_executed")
+	methodCall    = "tools/call"
+)
+
+// Middleware returns a tool middleware that runs every tool call in a span
+// carrying the tool name, the MCP session and the Tazapay object IDs found in the
+// result by objectIDs. A trace context sent by the client in the request _meta
+// becomes the parent of the span. It must wrap the policy, dry run and
+// confirmation middlewares so that denials are recorded on the span and those
+// middlewares run, and log, inside it.
+func Middleware(objectIDs func(text string) []string) types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		name := tool.Definition().Name
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ctx = extractMeta(ctx, req.Params.Meta)
+
+			ctx, span := Start(ctx, methodCall+" "+name, trace.WithSpanKind(trace.SpanKindServer),
+				trace.WithAttributes(attrMethod.String(methodCall), attrTool.String(name)))
+			defer span.End()
+
+			if session := server.ClientSessionFromContext(ctx); session != nil {
+				span.SetAttributes(attrSession.String(session.SessionID()))
+			}
+
+			result, err := next(ctx, req)
+
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				return result, err
+			}
+
+			if result != nil {
+				text := types.ResultText(result)
+				if result.IsError {
+					span.SetStatus(codes.Error, text)
+				}
+
+				// dry runs and calls awaiting confirmation sent nothing to Tazapay
+				span.SetAttributes(attrNotRun.Bool(types.IsNotExecuted(result)))
+
+				if ids := objectIDs(text); len(ids) > 0 {
+					span.SetAttributes(attrObjectIDs.StringSlice(ids))
+				}
+			}
+
+			return result, nil
+		}
+	}
+}
+
+// extractMeta continues the trace whose context the client sent in the
+// traceparent and tracestate fields of the request _meta
+func extractMeta(ctx context.Context, meta *mcp.Meta) context.Context {
+	if meta == nil || len(meta.AdditionalFields) == 0 {
+		return ctx
+	}
+
+	carrier := propagation.MapCarrier{}
+	for key, value := range meta.AdditionalFields {
+		if s, ok := value.(string); ok {
+			carrier[key] = s
+		}
+	}
+
+	return otel.GetTextMapPropagator().Extract(ctx, carrier)
+}
@@ -0,0 +1,111 @@
+// Package tracing exports OpenTelemetry traces of tool calls and Tazapay API
+// requests over OTLP. Tracing stays a no-op unless an OTLP endpoint is configured
+// with the standard OTEL_EXPORTER_OTLP_* environment variables.
+package tracing
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/version"
+)
+
+// Environment variables of the OpenTelemetry SDK read to decide whether to export
+const (
+	envSDKDisabled      = "OTEL_SDK_DISABLED"
+	envTracesExporter   = "OTEL_TRACES_EXPORTER"
+	envEndpoint         = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	envTracesEndpoint   = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+	envProtocol         = "OTEL_EXPORTER_OTLP_PROTOCOL"
+	envTracesProtocol   = "OTEL_EXPORTER_OTLP_TRACES_PROTOCOL"
+	envServiceName      = "OTEL_SERVICE_NAME"
+	protocolGRPC        = "grpc"
+	defaultServiceName  = "tazapay-mcp-server"
+	instrumentationName = "github.com/tazapay/tazapay-mcp-server"
+)
+
+// Setup installs the global tracer provider and the W3C trace context propagator.
+// Spans are exported over OTLP, HTTP/protobuf by default or gRPC when
+// OTEL_EXPORTER_OTLP_PROTOCOL is grpc, to the endpoint, headers and timeout given
+// by the OTEL_EXPORTER_OTLP_* variables. Without an endpoint, or when
+// OTEL_SDK_DISABLED is true or OTEL_TRACES_EXPORTER is none, nothing is exported.
+// The returned function flushes and stops the exporter.
+func Setup(ctx context.Context) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+
+	if !Enabled() {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporter, err := newExporter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(serviceName()),
+		semconv.ServiceVersion(version.Version),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// Enabled reports whether an OTLP endpoint is configured and tracing is not turned off
+func Enabled() bool {
+	if strings.EqualFold(os.Getenv(envSDKDisabled), "true") ||
+		strings.EqualFold(os.Getenv(envTracesExporter), "none") {
+		return false
+	}
+
+	return os.Getenv(envEndpoint) != "" || os.Getenv(envTracesEndpoint) != ""
+}
+
+// newExporter creates the OTLP exporter for the configured protocol
+func newExporter(ctx context.Context) (*otlptrace.Exporter, error) {
+	protocol := os.Getenv(envTracesProtocol)
+	if protocol == "" {
+		protocol = os.Getenv(envProtocol)
+	}
+
+	if protocol == protocolGRPC {
+		return otlptracegrpc.New(ctx)
+	}
+
+	return otlptracehttp.New(ctx)
+}
+
+// serviceName returns OTEL_SERVICE_NAME or the name of the server
+func serviceName() string {
+	if name := os.Getenv(envServiceName); name != "" {
+		return name
+	}
+
+	return defaultServiceName
+}
+
+// Start starts a span named name as a child of the span in ctx. The tracer comes
+// from the global provider, a no-op until Setup installs an exporting one.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, opts...)
+}
@@ -0,0 +1,215 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// testTool is a tool answering with a fixed result
+type testTool struct {
+	result *mcp.CallToolResult
+	next   func(ctx context.Context)
+}
+
+func (t testTool) Definition() mcp.Tool         { return mcp.NewTool("tazapay_get_payout_tool") }
+func (t testTool) Metadata() types.ToolMetadata { return types.ToolMetadata{ReadOnly: true} }
+
+func (t testTool) Handle(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if t.next != nil {
+		t.next(ctx)
+	}
+
+	return t.result, nil
+}
+
+// record installs a tracer provider keeping the ended spans in memory
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	return recorder
+}
+
+func findIDs(text string) []string {
+	return regexp.MustCompile(`pot_[a-z0-9]+`).FindAllString(text, -1)
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		status codes.Code
+		ids    []string
+	}{
+		{"success with object ID", mcp.NewToolResultText("payout pot_abc created"), codes.Unset, []string{"pot_abc"}},
+		{"error result", mcp.NewToolResultError("payout not found"), codes.Error, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recorder := record(t)
+
+			tool := testTool{result: test.result}
+			handler := Middleware(findIDs)(tool, tool.Handle)
+
+			if _, err := handler(context.Background(), mcp.CallToolRequest{}); err != nil {
+				t.Fatal(err)
+			}
+
+			spans := recorder.Ended()
+			if len(spans) != 1 {
+				t.Fatalf("got %d spans; want 1", len(spans))
+			}
+
+			span := spans[0]
+			if span.Name() != "tools/call tazapay_get_payout_tool" {
+				t.Errorf("span name = %q", span.Name())
+			}
+
+			if span.Status().Code != test.status {
+				t.Errorf("span status = %v; want %v", span.Status().Code, test.status)
+			}
+
+			got := attributes(span.Attributes())
+			if got[attrTool] != "tazapay_get_payout_tool" {
+				t.Errorf("tool attribute = %q", got[attrTool])
+			}
+
+			if test.ids != nil && got[attrObjectIDs] != `["pot_abc"]` {
+				t.Errorf("object IDs attribute = %q; want %v", got[attrObjectIDs], test.ids)
+			}
+		})
+	}
+}
+
+func TestMiddlewareContinuesClientTrace(t *testing.T) {
+	recorder := record(t)
+
+	const traceparent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+	req := mcp.CallToolRequest{}
+	req.Params.Meta = &mcp.Meta{AdditionalFields: map[string]any{"traceparent": traceparent}}
+
+	tool := testTool{result: mcp.NewToolResultText("ok")}
+	if _, err := Middleware(findIDs)(tool, tool.Handle)(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+
+	span := recorder.Ended()[0]
+	if got := span.SpanContext().TraceID().String(); got != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Errorf("trace ID = %s; want the client's", got)
+	}
+
+	if got := span.Parent().SpanID().String(); got != "00f067aa0ba902b7" {
+		t.Errorf("parent span ID = %s; want the client's", got)
+	}
+}
+
+func TestTransport(t *testing.T) {
+	recorder := record(t)
+
+	var traceparent string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("traceparent")
+		w.Header().Set(constants.HeaderRequestID, "req_1")
+	}))
+	defer upstream.Close()
+
+	client := &http.Client{Transport: Transport(http.DefaultTransport)}
+
+	// the request is sent from inside a tool call span
+	tool := testTool{result: mcp.NewToolResultText("ok"), next: func(ctx context.Context) {
+		req, _ := http.NewRequestWithContext(ctx, http.MethodGet, upstream.URL+"/v3/payout/pot_1", http.NoBody)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}}
+
+	if _, err := Middleware(findIDs)(tool, tool.Handle)(context.Background(), mcp.CallToolRequest{}); err != nil {
+		t.Fatal(err)
+	}
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans; want 2", len(spans))
+	}
+
+	outbound, parent := spans[0], spans[1]
+	if outbound.Name() != "GET /v3/payout/{id}" || outbound.SpanKind() != trace.SpanKindClient {
+		t.Errorf("client span = %q %v", outbound.Name(), outbound.SpanKind())
+	}
+
+	if outbound.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Error("client span is not a child of the tool call span")
+	}
+
+	want := "00-" + outbound.SpanContext().TraceID().String() + "-" + outbound.SpanContext().SpanID().String() + "-01"
+	if traceparent != want {
+		t.Errorf("traceparent header = %q; want %q", traceparent, want)
+	}
+
+	if got := attributes(outbound.Attributes())[attrRequestID]; got != "req_1" {
+		t.Errorf("request ID attribute = %q; want req_1", got)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		env  map[string]string
+		want bool
+	}{
+		{"no endpoint", nil, false},
+		{"endpoint", map[string]string{envEndpoint: "http://collector:4318"}, true},
+		{"traces endpoint", map[string]string{envTracesEndpoint: "http://collector:4318/v1/traces"}, true},
+		{"sdk disabled", map[string]string{envEndpoint: "http://collector:4318", envSDKDisabled: "true"}, false},
+		{"exporter none", map[string]string{envEndpoint: "http://collector:4318", envTracesExporter: "none"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, key := range []string{envEndpoint, envTracesEndpoint, envSDKDisabled, envTracesExporter} {
+				t.Setenv(key, test.env[key])
+			}
+
+			if got := Enabled(); got != test.want {
+				t.Errorf("Enabled() = %v; want %v", got, test.want)
+			}
+		})
+	}
+}
+
+// attributes maps the span attributes to their string form
+func attributes(kvs []attribute.KeyValue) map[attribute.Key]string {
+	out := make(map[attribute.Key]string, len(kvs))
+	for _, kv := range kvs {
+		out[kv.Key] = kv.Value.Emit()
+	}
+
+	return out
+}
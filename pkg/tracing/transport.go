@@ -0,0 +1,67 @@
+package tracing
+
+import (
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/endpoint"
+)
+
+// attrRequestID is the request ID Tazapay returns, to find the request on their side
+const attrRequestID = attribute.Key("tazapay.request_id")
+
+// transport traces the requests sent to Tazapay
+type transport struct {
+	next http.RoundTripper
+}
+
+// Transport wraps next, running every request in a client span named after its
+// endpoint template and propagating the W3C trace context in the request headers
+func Transport(next http.RoundTripper) http.RoundTripper {
+	return transport{next: next}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	route := endpoint.Template(req.URL.Path)
+
+	ctx, span := Start(req.Context(), req.Method+" "+route, trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.HTTPRoute(route),
+			semconv.ServerAddress(req.URL.Hostname()),
+			semconv.URLFull(req.URL.Redacted()),
+		))
+	defer span.End()
+
+	// a RoundTripper must not modify the request it was given
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return resp, err
+	}
+
+	span.SetAttributes(semconv.HTTPResponseStatusCode(resp.StatusCode))
+
+	if id := resp.Header.Get(constants.HeaderRequestID); id != "" {
+		span.SetAttributes(attrRequestID.String(id))
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,17 @@
+package utils
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// accountIDSize is the number of hash bytes kept in an account ID
+const accountIDSize = 8
+
+// AccountID identifies the Tazapay account by a short hash of the auth token,
+// so that limits and audit records never store the credentials themselves
+func AccountID(ctx context.Context) string {
+	sum := sha256.Sum256([]byte(AuthToken(ctx)))
+	return hex.EncodeToString(sum[:accountIDSize])
+}
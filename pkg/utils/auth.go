@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+type authTokenKey struct{}
+
+// WithAuthToken returns a context in which requests to Tazapay authenticate with
+// token. Each HTTP request carries its own token, so concurrent sessions of
+// different accounts never share credentials.
+func WithAuthToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, authTokenKey{}, token)
+}
+
+// AuthToken returns the token set with WithAuthToken. Without one, as over stdio,
+// it returns TAZAPAY_AUTH_TOKEN from the server configuration.
+func AuthToken(ctx context.Context) string {
+	if token, ok := ctx.Value(authTokenKey{}).(string); ok {
+		return token
+	}
+
+	return viper.GetString(constants.StrTAZAPAYAuthToken)
+}
+
+// ConfiguredAuthToken returns the token of the server's own credentials,
+// TAZAPAY_API_KEY and TAZAPAY_API_SECRET, or TAZAPAY_AUTH_TOKEN when they are
+// not set. Per-request credentials are not considered.
+func ConfiguredAuthToken() (string, error) {
+	key := viper.GetString(constants.StrTAZAPAYAPIKey)
+	secret := viper.GetString(constants.StrTAZAPAYAPISecret)
+
+	if key != "" && secret != "" {
+		return base64.StdEncoding.EncodeToString([]byte(key + ":" + secret)), nil
+	}
+
+	if token := viper.GetString(constants.StrTAZAPAYAuthToken); token != "" {
+		return token, nil
+	}
+
+	return "", constants.ErrMissingAuthKeys
+}
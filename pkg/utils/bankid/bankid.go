@@ -0,0 +1,221 @@
+// Package bankid validates bank identifiers: IBAN, ABA routing numbers,
+// SWIFT/BIC, IFSC, UK sort codes, Australian BSB and CNAPS codes.
+package bankid
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/iso"
+)
+
+// Validator checks a bank identifier
+type Validator func(string) error
+
+// bankCodes lists the fields of destination_details.bank.bank_codes with their validators
+var bankCodes = []struct {
+	field    string
+	validate Validator
+}{
+	{"swift_code", BIC},
+	{"bic_code", BIC},
+	{"ifsc_code", IFSC},
+	{"aba_code", ABA},
+	{"sort_code", SortCode},
+	{"bsb_code", BSB},
+	{"cnaps", CNAPS},
+}
+
+// countries is the set of ISO 3166-1 alpha-2 codes
+var countries = func() map[string]bool {
+	set := make(map[string]bool, len(iso.Countries))
+	for _, c := range iso.Countries {
+		set[c.Code] = true
+	}
+
+	return set
+}()
+
+// FieldError is an invalid bank identifier in a request
+type FieldError struct {
+	Field  string
+	Value  string
+	Reason string
+}
+
+// Error implements error
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s %q: %s", constants.ErrInvalidBankIdentifier, e.Field, e.Value, e.Reason)
+}
+
+// Unwrap makes errors.Is match constants.ErrInvalidBankIdentifier
+func (*FieldError) Unwrap() error {
+	return constants.ErrInvalidBankIdentifier
+}
+
+// reasonError is the error of a validator, the reason of a FieldError
+type reasonError string
+
+// Error implements error
+func (r reasonError) Error() string { return string(r) }
+
+// Unwrap makes errors.Is match constants.ErrInvalidBankIdentifier
+func (reasonError) Unwrap() error { return constants.ErrInvalidBankIdentifier }
+
+// invalid returns the error of a validator
+func invalid(why string) error {
+	return reasonError(why)
+}
+
+// ValidateBank checks the IBAN and bank codes of destination_details.bank,
+// with the codes under bank_codes. Every invalid field is reported, with the
+// path under prefix.
+func ValidateBank(bank map[string]any, prefix string) error {
+	var errs []error
+
+	check := func(field string, value any, validate Validator) {
+		s, ok := value.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			return
+		}
+
+		if err := validate(s); err != nil {
+			errs = append(errs, &FieldError{Field: prefix + field, Value: s, Reason: err.Error()})
+		}
+	}
+
+	check("iban", bank["iban"], IBAN)
+
+	if codes, ok := bank["bank_codes"].(map[string]any); ok {
+		for _, code := range bankCodes {
+			check("bank_codes."+code.field, codes[code.field], code.validate)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Normalize upper-cases an identifier and removes spaces, dashes and dots
+func Normalize(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '\t':
+			return -1
+		}
+
+		return r
+	}, strings.ToUpper(strings.TrimSpace(s)))
+}
+
+// ABA checks a US ABA routing number: 9 digits with the 3-7-1 checksum
+func ABA(s string) error {
+	aba := Normalize(s)
+	if len(aba) != 9 || !allDigits(aba) {
+		return invalid("must be 9 digits")
+	}
+
+	sum := 0
+	for i, weight := range []int{3, 7, 1, 3, 7, 1, 3, 7, 1} {
+		sum += int(aba[i]-'0') * weight
+	}
+
+	if sum%10 != 0 {
+		return invalid("checksum does not match, the routing number has a typo")
+	}
+
+	return nil
+}
+
+// BIC checks the structure of a SWIFT/BIC code: 4 letter bank code, ISO
+// country code, 2 character location and optional 3 character branch
+func BIC(s string) error {
+	bic := Normalize(s)
+	if len(bic) != 8 && len(bic) != 11 {
+		return invalid(fmt.Sprintf("must be 8 or 11 characters, got %d", len(bic)))
+	}
+
+	for i := range 4 {
+		if !isLetter(bic[i]) {
+			return invalid("first 4 characters must be letters (bank code)")
+		}
+	}
+
+	if !countries[bic[4:6]] {
+		return invalid(fmt.Sprintf("characters 5 and 6 must be an ISO country code, got %s", bic[4:6]))
+	}
+
+	for i := 6; i < len(bic); i++ {
+		if !isLetter(bic[i]) && !isDigit(bic[i]) {
+			return invalid("location and branch codes must be letters or digits")
+		}
+	}
+
+	return nil
+}
+
+// IFSC checks an Indian Financial System Code: 4 letter bank code, a zero and
+// a 6 character branch code
+func IFSC(s string) error {
+	ifsc := Normalize(s)
+	if len(ifsc) != 11 {
+		return invalid(fmt.Sprintf("must be 11 characters, got %d", len(ifsc)))
+	}
+
+	for i := range 4 {
+		if !isLetter(ifsc[i]) {
+			return invalid("first 4 characters must be letters (bank code)")
+		}
+	}
+
+	if ifsc[4] != '0' {
+		return invalid("fifth character must be 0")
+	}
+
+	for i := 5; i < len(ifsc); i++ {
+		if !isLetter(ifsc[i]) && !isDigit(ifsc[i]) {
+			return invalid("branch code must be letters or digits")
+		}
+	}
+
+	return nil
+}
+
+// SortCode checks a UK sort code: 6 digits, dashes allowed
+func SortCode(s string) error {
+	return digits(s, 6)
+}
+
+// BSB checks an Australian Bank State Branch code: 6 digits, dash allowed
+func BSB(s string) error {
+	return digits(s, 6)
+}
+
+// CNAPS checks a China National Advanced Payment System code: 12 digits
+func CNAPS(s string) error {
+	return digits(s, 12)
+}
+
+// digits checks that the identifier is n digits
+func digits(s string, n int) error {
+	if id := Normalize(s); len(id) != n || !allDigits(id) {
+		return invalid(fmt.Sprintf("must be %d digits", n))
+	}
+
+	return nil
+}
+
+func allDigits(s string) bool {
+	for i := range len(s) {
+		if !isDigit(s[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+func isLetter(c byte) bool { return c >= 'A' && c <= 'Z' }
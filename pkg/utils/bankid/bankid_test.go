@@ -0,0 +1,205 @@
+package bankid
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestValidators(t *testing.T) {
+	tests := []struct {
+		name     string
+		validate Validator
+		value    string
+		reason   string // substring of the error, empty when valid
+	}{
+		{"iban gb", IBAN, "GB82WEST12345698765432", ""},
+		{"iban de spaced", IBAN, "de89 3704 0044 0532 0130 00", ""},
+		{"iban fr letters", IBAN, "FR1420041010050500013M02606", ""},
+		{"iban nl", IBAN, "NL91ABNA0417164300", ""},
+		{"iban no shortest", IBAN, "NO9386011117947", ""},
+		{"iban mt longest letters", IBAN, "MT84MALT011000012345MTLCAST001S", ""},
+		{"iban bad check digits", IBAN, "GB81WEST12345698765432", "check digits"},
+		{"iban transposed", IBAN, "GB82WEST12345698765423", "check digits"},
+		{"iban wrong length", IBAN, "DE8937040044053201300", "22 characters"},
+		{"iban unknown country", IBAN, "US64SVBKUS6S3300958879", "does not use IBANs"},
+		{"iban no check digits", IBAN, "GBXXWEST12345698765432", "check digits"},
+		{"iban symbol", IBAN, "GB82WEST1234569876543#", "unexpected character"},
+		{"iban empty", IBAN, "", "country code"},
+
+		{"aba", ABA, "021000021", ""},
+		{"aba dashed", ABA, "0110-0001-5", ""},
+		{"aba checksum", ABA, "123456789", "checksum"},
+		{"aba short", ABA, "02100002", "9 digits"},
+		{"aba letters", ABA, "02100002A", "9 digits"},
+
+		{"bic 8", BIC, "DEUTDEFF", ""},
+		{"bic 11", BIC, "hdfcinbbxxx", ""},
+		{"bic length", BIC, "DEUTDEF", "8 or 11"},
+		{"bic digit in bank code", BIC, "DEU1DEFF", "letters"},
+		{"bic unknown country", BIC, "DEUTXXFF", "country code"},
+		{"bic symbol in branch", BIC, "DEUTDEFF50_", "letters or digits"},
+
+		{"ifsc", IFSC, "HDFC0001234", ""},
+		{"ifsc lower", IFSC, "sbin0a12b34", ""},
+		{"ifsc fifth char", IFSC, "HDFC1001234", "fifth character"},
+		{"ifsc length", IFSC, "HDFC000123", "11 characters"},
+		{"ifsc digit bank code", IFSC, "HD1C0001234", "letters"},
+
+		{"sort code", SortCode, "60-16-13", ""},
+		{"sort code short", SortCode, "6016", "6 digits"},
+		{"bsb", BSB, "062-000", ""},
+		{"bsb letters", BSB, "06200A", "6 digits"},
+		{"cnaps", CNAPS, "102100099996", ""},
+		{"cnaps short", CNAPS, "10210009999", "12 digits"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.validate(test.value)
+			if test.reason == "" {
+				if err != nil {
+					t.Errorf("validate(%q) = %v; want nil", test.value, err)
+				}
+
+				return
+			}
+
+			if !errors.Is(err, constants.ErrInvalidBankIdentifier) || !strings.Contains(err.Error(), test.reason) {
+				t.Errorf("validate(%q) = %v; want an ErrInvalidBankIdentifier mentioning %q", test.value, err, test.reason)
+			}
+		})
+	}
+}
+
+// TestIBANSingleSubstitution checks that mod-97 catches every single character typo
+func TestIBANSingleSubstitution(t *testing.T) {
+	const valid = "GB82WEST12345698765432"
+
+	for i := 2; i < len(valid); i++ {
+		for _, c := range "0123456789" {
+			if byte(c) == valid[i] || (i >= 4 && i < 8) {
+				continue
+			}
+
+			typo := valid[:i] + string(c) + valid[i+1:]
+			if IBAN(typo) == nil {
+				t.Errorf("IBAN(%q) = nil; want the typo at %d detected", typo, i)
+			}
+		}
+	}
+}
+
+func TestValidateBank(t *testing.T) {
+	bank := map[string]any{
+		"iban":           "GB82WEST12345698765433",
+		"account_number": "not checked",
+		"bank_codes": map[string]any{
+			"swift_code": "DEUTDEFF",
+			"aba_code":   "123456789",
+			"sort_code":  "",
+			"bank_code":  "anything",
+		},
+	}
+
+	err := ValidateBank(bank, "destination_details.bank.")
+	if !errors.Is(err, constants.ErrInvalidBankIdentifier) {
+		t.Fatalf("ValidateBank() = %v; want ErrInvalidBankIdentifier", err)
+	}
+
+	var fields []string
+
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var fe *FieldError
+		if !errors.As(e, &fe) {
+			t.Fatalf("ValidateBank() error %v is not a FieldError", e)
+		}
+
+		fields = append(fields, fe.Field)
+	}
+
+	want := "destination_details.bank.iban destination_details.bank.bank_codes.aba_code"
+	if strings.Join(fields, " ") != want {
+		t.Errorf("ValidateBank() fields = %v; want %s", fields, want)
+	}
+
+	if err := ValidateBank(map[string]any{"iban": "DE89370400440532013000"}, ""); err != nil {
+		t.Errorf("ValidateBank() = %v; want nil", err)
+	}
+}
+
+func FuzzIBAN(f *testing.F) {
+	for _, seed := range []string{"GB82WEST12345698765432", "DE89370400440532013000", "NO9386011117947", "", "GB", "ÄÖ12"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		err := IBAN(s)
+		if err != nil {
+			if !errors.Is(err, constants.ErrInvalidBankIdentifier) {
+				t.Fatalf("IBAN(%q) = %v; want ErrInvalidBankIdentifier", s, err)
+			}
+
+			return
+		}
+
+		iban := Normalize(s)
+		if len(iban) != ibanLengths[iban[:2]] || mod97(iban[4:]+iban[:4]) != 1 {
+			t.Fatalf("IBAN(%q) accepted %q", s, iban)
+		}
+
+		if IBAN(iban) != nil {
+			t.Fatalf("IBAN(%q) rejects its normalized form %q", s, iban)
+		}
+	})
+}
+
+func FuzzABA(f *testing.F) {
+	for _, seed := range []string{"021000021", "011000015", "123456789", "", "0"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		if ABA(s) != nil {
+			return
+		}
+
+		aba := Normalize(s)
+		if len(aba) != 9 || !allDigits(aba) {
+			t.Fatalf("ABA(%q) accepted %q", s, aba)
+		}
+
+		// changing any single digit breaks the checksum
+		for i := range 9 {
+			typo := []byte(aba)
+			typo[i] = '0' + (typo[i]-'0'+1)%10
+
+			if ABA(string(typo)) == nil {
+				t.Fatalf("ABA(%q) = nil; want the typo at %d detected", typo, i)
+			}
+		}
+	})
+}
+
+func FuzzCodes(f *testing.F) {
+	for _, seed := range []string{"DEUTDEFF", "HDFCINBBXXX", "HDFC0001234", "60-16-13", "062-000", "102100099996", "", "\xff"} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, s string) {
+		for name, validate := range map[string]Validator{
+			"BIC": BIC, "IFSC": IFSC, "SortCode": SortCode, "BSB": BSB, "CNAPS": CNAPS,
+		} {
+			err := validate(s)
+			if err != nil && !errors.Is(err, constants.ErrInvalidBankIdentifier) {
+				t.Fatalf("%s(%q) = %v; want ErrInvalidBankIdentifier", name, s, err)
+			}
+
+			if err == nil && validate(Normalize(s)) != nil {
+				t.Fatalf("%s(%q) rejects its normalized form", name, s)
+			}
+		}
+	})
+}
@@ -0,0 +1,71 @@
+package bankid
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// ibanLengths is the IBAN length of every country of the SWIFT IBAN registry
+var ibanLengths = map[string]int{
+	"AD": 24, "AE": 23, "AL": 28, "AT": 20, "AZ": 28, "BA": 20, "BE": 16, "BG": 22, "BH": 22, "BI": 27,
+	"BR": 29, "BY": 28, "CH": 21, "CR": 22, "CY": 28, "CZ": 24, "DE": 22, "DJ": 27, "DK": 18, "DO": 28,
+	"EE": 20, "EG": 29, "ES": 24, "FI": 18, "FK": 18, "FO": 18, "FR": 27, "GB": 22, "GE": 22, "GI": 23,
+	"GL": 18, "GR": 27, "GT": 28, "HR": 21, "HU": 28, "IE": 22, "IL": 23, "IQ": 23, "IS": 26, "IT": 27,
+	"JO": 30, "KW": 30, "KZ": 20, "LB": 28, "LC": 32, "LI": 21, "LT": 20, "LU": 20, "LV": 21, "LY": 25,
+	"MC": 27, "MD": 24, "ME": 22, "MK": 19, "MN": 20, "MR": 27, "MT": 31, "MU": 30, "NI": 28, "NL": 18,
+	"NO": 15, "OM": 23, "PK": 24, "PL": 28, "PS": 29, "PT": 25, "QA": 29, "RO": 24, "RS": 22, "RU": 33,
+	"SA": 24, "SC": 31, "SD": 18, "SE": 24, "SI": 19, "SK": 24, "SM": 27, "SO": 23, "ST": 25, "SV": 28,
+	"TL": 23, "TN": 24, "TR": 26, "UA": 29, "VA": 22, "VG": 24, "XK": 20, "YE": 30,
+}
+
+// IBAN checks an International Bank Account Number: its characters, the
+// length of its country and the mod-97 check digits
+func IBAN(s string) error {
+	iban := Normalize(s)
+
+	if len(iban) < 5 || !isLetter(iban[0]) || !isLetter(iban[1]) || !isDigit(iban[2]) || !isDigit(iban[3]) {
+		return invalid("must start with a 2 letter country code and 2 check digits")
+	}
+
+	for i := range len(iban) {
+		if !isLetter(iban[i]) && !isDigit(iban[i]) {
+			return invalid(fmt.Sprintf("unexpected character %q", iban[i]))
+		}
+	}
+
+	length, ok := ibanLengths[iban[:2]]
+	if !ok {
+		return invalid(fmt.Sprintf("%s does not use IBANs", iban[:2]))
+	}
+
+	if len(iban) != length {
+		return invalid(fmt.Sprintf("%s IBANs have %d characters, got %d", iban[:2], length, len(iban)))
+	}
+
+	if mod97(iban[4:]+iban[:4]) != 1 {
+		return invalid("check digits do not match, the IBAN has a typo")
+	}
+
+	return nil
+}
+
+// mod97 computes the ISO 7064 MOD 97-10 remainder of an alphanumeric string,
+// letters counting as 10 to 35
+func mod97(s string) int {
+	remainder := 0
+
+	for i := range len(s) {
+		c := s[i]
+		if isLetter(c) {
+			for _, d := range strconv.Itoa(int(c-'A') + 10) {
+				remainder = (remainder*10 + int(d-'0')) % 97
+			}
+
+			continue
+		}
+
+		remainder = (remainder*10 + int(c-'0')) % 97
+	}
+
+	return remainder
+}
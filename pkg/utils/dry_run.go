@@ -0,0 +1,154 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sync"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// sensitiveFields are payload fields masked in dry-run output and audit records
+var sensitiveFields = map[string]bool{
+	"confirmation_token":             true,
+	"account_number":                 true,
+	"iban":                           true,
+	"tax_id":                         true,
+	"national_identification_number": true,
+	"deposit_address":                true,
+	"deposit_key":                    true,
+	"name":                           true,
+	"email":                          true,
+}
+
+// sensitiveObjects are payload objects, such as the phone and address of a
+// beneficiary, whose fields are all masked
+var sensitiveObjects = map[string]bool{
+	"phone":   true,
+	"address": true,
+}
+
+type dryRunKey struct{}
+
+// DryRunRequest is a request that would have been sent to Tazapay
+type DryRunRequest struct {
+	Payload any    `json:"payload,omitempty"`
+	Method  string `json:"method"`
+	URL     string `json:"url"`
+}
+
+// DryRun collects the requests made while a tool runs in dry-run mode
+type DryRun struct {
+	requests []DryRunRequest
+	mu       sync.Mutex
+}
+
+// WithDryRun returns a context in which every non-GET request to Tazapay is
+// recorded in the returned DryRun instead of being sent
+func WithDryRun(ctx context.Context) (context.Context, *DryRun) {
+	run := &DryRun{}
+	return context.WithValue(ctx, dryRunKey{}, run), run
+}
+
+// IsDryRun reports whether ctx was created by WithDryRun
+func IsDryRun(ctx context.Context) bool {
+	_, ok := ctx.Value(dryRunKey{}).(*DryRun)
+	return ok
+}
+
+// Requests returns the recorded requests
+func (r *DryRun) Requests() []DryRunRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]DryRunRequest(nil), r.requests...)
+}
+
+// recordDryRun records the request and returns constants.ErrDryRun when ctx is
+// in dry-run mode, so that the caller stops before sending it
+func recordDryRun(ctx context.Context, logger *slog.Logger, method, url string, payload any) error {
+	run, ok := ctx.Value(dryRunKey{}).(*DryRun)
+	if !ok || method == http.MethodGet {
+		return nil
+	}
+
+	masked, err := MaskPayload(payload)
+	if err != nil {
+		logger.ErrorContext(ctx, constants.StrFailedToCreateHTTPRequest, slog.Any(constants.Error, err))
+		return err
+	}
+
+	logger.InfoContext(ctx, "Dry run, request not sent", slog.String("method", method), slog.String("url", url))
+
+	run.mu.Lock()
+	run.requests = append(run.requests, DryRunRequest{Method: method, URL: url, Payload: masked})
+	run.mu.Unlock()
+
+	return constants.ErrDryRun
+}
+
+// MaskPayload returns the JSON form of payload with account numbers, identity
+// numbers and personal details masked
+func MaskPayload(payload any) (any, error) {
+	if payload == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var decoded any
+	if err := json.Unmarshal(raw, &decoded); err != nil {
+		return nil, err
+	}
+
+	return maskValue(decoded), nil
+}
+
+// maskValue walks a decoded JSON value and masks the sensitive fields
+func maskValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, field := range v {
+			if s, ok := field.(string); ok && sensitiveFields[key] {
+				v[key] = MaskAccount(s)
+				continue
+			}
+
+			if sensitiveObjects[key] {
+				v[key] = maskAll(field)
+				continue
+			}
+
+			v[key] = maskValue(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = maskValue(item)
+		}
+	}
+
+	return value
+}
+
+// maskAll masks every string in a decoded JSON value
+func maskAll(value any) any {
+	switch v := value.(type) {
+	case string:
+		return MaskAccount(v)
+	case map[string]any:
+		for key, field := range v {
+			v[key] = maskAll(field)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = maskAll(item)
+		}
+	}
+
+	return value
+}
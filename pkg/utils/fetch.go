@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// FetchObject reads a Tazapay object of the given kind, e.g. payout or
+// beneficiary, and returns the data of the response
+func FetchObject(ctx context.Context, logger *slog.Logger, kind, id string) (map[string]any, error) {
+	resp, err := HandleGETHttpRequest(ctx, logger,
+		fmt.Sprintf("%s/%s/%s", constants.ProdBaseURL, kind, id), constants.GetHTTPMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	data, ok := resp[constants.KeyData].(map[string]any)
+	if !ok {
+		return nil, constants.ErrNoDataInResponse
+	}
+
+	return data, nil
+}
+
+// FetchPayout reads a payout
+func FetchPayout(ctx context.Context, logger *slog.Logger, id string) (map[string]any, error) {
+	return FetchObject(ctx, logger, "payout", id)
+}
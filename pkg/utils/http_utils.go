@@ -14,14 +14,31 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/metrics"
+	"github.com/tazapay/tazapay-mcp-server/pkg/tracing"
 )
 
+// httpClient sends the requests to Tazapay, tracing them and recording their metrics
+var httpClient = &http.Client{Transport: tracing.Transport(metrics.Transport(http.DefaultTransport))}
+
 func HandlePOSTHttpRequest(ctx context.Context, logger *slog.Logger, url string,
 	payload any, method string,
 ) (map[string]any, error) {
+	if err := checkReadOnly(ctx, logger, method); err != nil {
+		return nil, err
+	}
+
+	if err := recordDryRun(ctx, logger, method, url, payload); err != nil {
+		return nil, err
+	}
+
 	headers := map[string]string{
 		constants.HeaderAccept:        constants.AcceptJSON,
-		constants.HeaderAuthorization: constants.AuthSchemeBasic + viper.GetString(constants.StrTAZAPAYAuthToken),
+		constants.HeaderAuthorization: constants.AuthSchemeBasic + AuthToken(ctx),
+	}
+
+	if key := IdempotencyKey(ctx); key != "" {
+		headers[constants.HeaderIdempotencyKey] = key
 	}
 
 	var reqBody io.Reader
@@ -54,7 +71,7 @@ func HandlePOSTHttpRequest(ctx context.Context, logger *slog.Logger, url string,
 		req.Header.Set(k, v)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		logger.ErrorContext(ctx, constants.StrHTTPRequestFailed, slog.Any(constants.Error, err))
 		return nil, fmt.Errorf(constants.StrErrorMakingRequest, err)
@@ -90,9 +107,13 @@ func HandlePOSTHttpRequest(ctx context.Context, logger *slog.Logger, url string,
 func HandleGETHttpRequest(ctx context.Context, logger *slog.Logger,
 	url, method string,
 ) (map[string]any, error) {
+	if err := checkReadOnly(ctx, logger, method); err != nil {
+		return nil, err
+	}
+
 	headers := map[string]string{
 		constants.HeaderAccept:        constants.AcceptJSON,
-		constants.HeaderAuthorization: constants.AuthSchemeBasic + viper.GetString(constants.StrTAZAPAYAuthToken),
+		constants.HeaderAuthorization: constants.AuthSchemeBasic + AuthToken(ctx),
 	}
 
 	logger.InfoContext(ctx, "Sending GET request", 
@@ -110,7 +131,7 @@ func HandleGETHttpRequest(ctx context.Context, logger *slog.Logger,
 		req.Header.Set(k, v)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		logger.ErrorContext(ctx, constants.StrHTTPRequestFailed, slog.Any(constants.Error, err))
 		return nil, fmt.Errorf(constants.StrErrorMakingRequest, err)
@@ -154,9 +175,17 @@ func HandleGETHttpRequest(ctx context.Context, logger *slog.Logger,
 func HandlePUTHttpRequest(ctx context.Context, logger *slog.Logger,
 	url string, payload any, method string,
 ) (map[string]any, error) {
+	if err := checkReadOnly(ctx, logger, method); err != nil {
+		return nil, err
+	}
+
+	if err := recordDryRun(ctx, logger, method, url, payload); err != nil {
+		return nil, err
+	}
+
 	headers := map[string]string{
 		constants.HeaderAccept:        constants.AcceptJSON,
-		constants.HeaderAuthorization: constants.AuthSchemeBasic + viper.GetString(constants.StrTAZAPAYAuthToken),
+		constants.HeaderAuthorization: constants.AuthSchemeBasic + AuthToken(ctx),
 	}
 
 	jsonBody, err := json.Marshal(payload)
@@ -181,7 +210,7 @@ func HandlePUTHttpRequest(ctx context.Context, logger *slog.Logger,
 		req.Header.Set(k, v)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		logger.ErrorContext(ctx, constants.StrHTTPRequestFailed, slog.Any(constants.Error, err))
 		return nil, fmt.Errorf(constants.StrErrorMakingRequest, err)
@@ -221,9 +250,17 @@ func HandlePUTHttpRequest(ctx context.Context, logger *slog.Logger,
 func HandleDELETEHttpRequest(ctx context.Context, logger *slog.Logger,
 	url, method string,
 ) (map[string]any, error) {
+	if err := checkReadOnly(ctx, logger, method); err != nil {
+		return nil, err
+	}
+
+	if err := recordDryRun(ctx, logger, method, url, nil); err != nil {
+		return nil, err
+	}
+
 	headers := map[string]string{
 		constants.HeaderAccept:        constants.AcceptJSON,
-		constants.HeaderAuthorization: constants.AuthSchemeBasic + viper.GetString(constants.StrTAZAPAYAuthToken),
+		constants.HeaderAuthorization: constants.AuthSchemeBasic + AuthToken(ctx),
 	}
 
 	logger.InfoContext(ctx, "Sending DELETE request")
@@ -240,7 +277,7 @@ func HandleDELETEHttpRequest(ctx context.Context, logger *slog.Logger,
 		req.Header.Set(k, v)
 	}
 
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient.Do(req)
 	if err != nil {
 		logger.ErrorContext(ctx, constants.StrHTTPRequestFailed, slog.Any(constants.Error, err))
 		return nil, fmt.Errorf(constants.StrErrorMakingRequest, err)
@@ -274,16 +311,27 @@ func HandleDELETEHttpRequest(ctx context.Context, logger *slog.Logger,
 }
 
 
+// checkReadOnly refuses every non-GET request when the server runs in read-only mode.
+// It backs up the tool filtering done at registration time.
+func checkReadOnly(ctx context.Context, logger *slog.Logger, method string) error {
+	if method == http.MethodGet || !viper.GetBool(constants.StrTAZAPAYReadOnly) {
+		return nil
+	}
+
+	logger.ErrorContext(ctx, "Refusing request in read-only mode", slog.String("method", method))
+
+	return constants.ErrReadOnlyMode
+}
+
 // AuthHeaderHTTPContextFunc is a function that adds the authorization header to the context from the incoming requests.
+// A request without the header carries an empty token, it never falls back to the server credentials.
 func AuthHeaderHTTPContextFunc(ctx context.Context, r *http.Request) context.Context {
-    authHeader := r.Header.Get(constants.HeaderAuthorization)
-    var basicToken string
-    if after, ok :=strings.CutPrefix(authHeader, "Bearer Basic "); ok  {
-        basicToken = after
-    } else if after, ok :=strings.CutPrefix(authHeader, "Basic "); ok  {
-        basicToken = after
-    }
-    viper.Set(constants.StrTAZAPAYAuthToken, basicToken)
-    return r.Context()
+	authHeader := r.Header.Get(constants.HeaderAuthorization)
+	var basicToken string
+	if after, ok := strings.CutPrefix(authHeader, "Bearer Basic "); ok {
+		basicToken = after
+	} else if after, ok := strings.CutPrefix(authHeader, "Basic "); ok {
+		basicToken = after
+	}
+	return WithAuthToken(ctx, basicToken)
 }
-
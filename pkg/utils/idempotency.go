@@ -0,0 +1,18 @@
+package utils
+
+import "context"
+
+type idempotencyKey struct{}
+
+// WithIdempotencyKey returns a context in which POST requests to Tazapay carry
+// the key in the Idempotency-Key header, so that a retried request is not
+// executed twice
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKey{}, key)
+}
+
+// IdempotencyKey returns the key set with WithIdempotencyKey, if any
+func IdempotencyKey(ctx context.Context) string {
+	key, _ := ctx.Value(idempotencyKey{}).(string)
+	return key
+}
@@ -13,26 +13,38 @@ import (
 	"github.com/tazapay/tazapay-mcp-server/constants"
 )
 
-// InitConfig initializes configuration using viper and sets up auth token
-func InitConfig(logger *slog.Logger) error {
+// ReadConfigFile enables environment overrides and reads the optional
+// `.tazapay-mcp-server.yaml` config file from the home directory
+func ReadConfigFile(logger *slog.Logger) error {
 	viper.AutomaticEnv()
 
 	home, err := os.UserHomeDir()
-	if err == nil {
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".tazapay-mcp-server")
-		viper.SetConfigType("yaml")
-
-		readErr := viper.ReadInConfig()
-		if readErr != nil {
-			var notFoundErr viper.ConfigFileNotFoundError
-			if !errors.As(readErr, &notFoundErr) {
-				logger.ErrorContext(context.Background(), "Config read error", "error", readErr)
-				return readErr
-			}
+	if err != nil {
+		return nil
+	}
+
+	viper.AddConfigPath(home)
+	viper.SetConfigName(".tazapay-mcp-server")
+	viper.SetConfigType("yaml")
+
+	readErr := viper.ReadInConfig()
+	if readErr != nil {
+		var notFoundErr viper.ConfigFileNotFoundError
+		if !errors.As(readErr, &notFoundErr) {
+			logger.ErrorContext(context.Background(), "Config read error", "error", readErr)
+			return readErr
 		}
 	}
 
+	return nil
+}
+
+// InitConfig initializes configuration using viper and sets up auth token
+func InitConfig(logger *slog.Logger) error {
+	if err := ReadConfigFile(logger); err != nil {
+		return err
+	}
+
 	accessKey := viper.GetString("TAZAPAY_API_KEY")
 	secretKey := viper.GetString("TAZAPAY_API_SECRET")
 
@@ -0,0 +1,16 @@
+package utils
+
+import "strings"
+
+// visibleDigits is the number of trailing characters MaskAccount leaves readable
+const visibleDigits = 4
+
+// MaskAccount hides all but the last four characters of an account number, IBAN or address
+func MaskAccount(account string) string {
+	account = strings.ReplaceAll(account, " ", "")
+	if len(account) <= visibleDigits {
+		return strings.Repeat("*", len(account))
+	}
+
+	return "****" + account[len(account)-visibleDigits:]
+}
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// objectIDPattern matches Tazapay object IDs: payouts, beneficiaries, checkouts,
+// payins, customers and payment attempts
+var objectIDPattern = regexp.MustCompile(`\b(?:pot|bnf|chk|pay|cus|pat)_[A-Za-z0-9]+\b`)
+
+// objectKinds maps object ID prefixes to the API path of the object kind
+var objectKinds = map[string]string{
+	"pot": "payout",
+	"bnf": "beneficiary",
+	"chk": "checkout",
+	"pay": "payin",
+	"cus": "customer",
+	"pat": "payment_attempt",
+}
+
+// FindObjectIDs returns the Tazapay object IDs mentioned in text, in order of appearance
+func FindObjectIDs(text string) []string {
+	return objectIDPattern.FindAllString(text, -1)
+}
+
+// ObjectKind returns the kind of a Tazapay object ID as named in API paths,
+// e.g. payout for pot_ IDs
+func ObjectKind(id string) (string, bool) {
+	prefix, _, found := strings.Cut(id, "_")
+	if !found {
+		return "", false
+	}
+
+	kind, ok := objectKinds[prefix]
+
+	return kind, ok
+}
@@ -9,25 +9,23 @@ import (
 	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
-// GetBalances parses balance data and returns specific or all available balances.
-// - If a currency is passed, it returns balance for that currency.
-// - If no currency is passed, it returns all available balances.
-func GetBalances(data map[string]any, currency string) (string, error) {
-	// Marshal map to JSON bytes
-	raw, err := json.Marshal(data)
+// GetBalances parses balance data and returns specific or all available
+// balances, with a summary for the user.
+// If currency is provided, only that balance is returned.
+func GetBalances(data map[string]any, currency string) (types.BalanceOutput, string, error) {
+	result, err := parseBalances(data)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal balance data: %w", err)
+		return types.BalanceOutput{}, "", err
 	}
 
-	var result types.BalanceResponse
-	// Unmarshal into the BalanceResponse struct
-	if unmarshalErr := json.Unmarshal(raw, &result); unmarshalErr != nil {
-		return "", fmt.Errorf("failed to parse balance response: %w", unmarshalErr)
+	output := types.BalanceOutput{
+		UpdatedAt: result.Data.UpdatedAt,
+		Balances:  []types.BalanceAmount{},
 	}
 
 	// Ensure data is available
 	if len(result.Data.Available) == 0 {
-		return "No balances found.", nil
+		return output, "No balances found.", nil
 	}
 	// Normalize currency if provided
 	if currency != "" {
@@ -35,21 +33,59 @@ func GetBalances(data map[string]any, currency string) (string, error) {
 		for _, balance := range result.Data.Available {
 			if strings.EqualFold(balance.Currency, currencyCode) {
 				amountFloat := money.Int64ToDecimal2(balance.Amount)
-				return fmt.Sprintf("%s balance: %.2f", balance.Currency, amountFloat), nil
+				output.Balances = append(output.Balances, types.BalanceAmount{Currency: balance.Currency, Amount: amountFloat})
+
+				return output, fmt.Sprintf("%s balance: %.2f", balance.Currency, amountFloat), nil
 			}
 		}
 
-		return "No balance found for currency: " + currencyCode, nil
+		return output, "No balance found for currency: " + currencyCode, nil
 	}
 	// Format all balances
-	output := "Available account balances:\n"
+	text := "Available account balances:\n"
 
 	for _, balance := range result.Data.Available {
 		amountFloat := money.Int64ToDecimal2(balance.Amount)
-		output += fmt.Sprintf("- %s: %.2f\n", balance.Currency, amountFloat)
+		output.Balances = append(output.Balances, types.BalanceAmount{Currency: balance.Currency, Amount: amountFloat})
+		text += fmt.Sprintf("- %s: %.2f\n", balance.Currency, amountFloat)
+	}
+
+	return output, text, nil
+}
+
+// AvailableBalance returns the available balance in cents in the currency from
+// balance data, and whether the account has a balance in that currency
+func AvailableBalance(data map[string]any, currency string) (int64, bool, error) {
+	result, err := parseBalances(data)
+	if err != nil {
+		return 0, false, err
+	}
+
+	for _, balance := range result.Data.Available {
+		if strings.EqualFold(balance.Currency, currency) {
+			return balance.Amount, true, nil
+		}
+	}
+
+	return 0, false, nil
+}
+
+// parseBalances decodes the balance API response
+func parseBalances(data map[string]any) (types.BalanceResponse, error) {
+	var result types.BalanceResponse
+
+	// Marshal map to JSON bytes
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return result, fmt.Errorf("failed to marshal balance data: %w", err)
+	}
+
+	// Unmarshal into the BalanceResponse struct
+	if unmarshalErr := json.Unmarshal(raw, &result); unmarshalErr != nil {
+		return result, fmt.Errorf("failed to parse balance response: %w", unmarshalErr)
 	}
 
-	return output, nil
+	return result, nil
 }
 
 // MapToStruct converts map[string]any to any struct using JSON marshaling.
@@ -5,24 +5,52 @@ import (
 	"strings"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/iso"
 )
 
-// ValidateCurrency checks if the currency is 3 uppercase letters (ISO 4217)
+// ValidateCurrency checks that currency is an ISO 4217 code, in any case
 func ValidateCurrency(currency string) error {
-	if len(currency) != constants.Num3 || currency != strings.ToUpper(currency) {
-		return fmt.Errorf("%w: must be 3 uppercase letters (e.g., 'USD')", constants.ErrInvalidCurrencyFormat)
+	_, err := NormalizeCurrency(currency)
+	return err
+}
+
+// ValidateCountry checks that country is an ISO 3166-1 alpha-2 code, in any case
+func ValidateCountry(country string) error {
+	_, err := NormalizeCountry(country)
+	return err
+}
+
+// NormalizeCurrency returns the upper case ISO 4217 code of currency, or an
+// error suggesting the closest codes
+func NormalizeCurrency(currency string) (string, error) {
+	c, ok := iso.LookupCurrency(currency)
+	if !ok {
+		return "", fmt.Errorf("%w: %q is not an ISO 4217 currency code%s", constants.ErrInvalidCurrencyFormat,
+			currency, didYouMean(iso.SuggestCurrency(currency)))
 	}
 
-	return nil
+	return c.Code, nil
 }
 
-// ValidateCountry checks if the country is 2 uppercase letters (ISO 3166 alpha-2)
-func ValidateCountry(country string) error {
-	if len(country) != constants.Num2 || country != strings.ToUpper(country) {
-		return fmt.Errorf("%w: must be 2 uppercase letters (e.g., 'US')", constants.ErrInvalidCountryFormat)
+// NormalizeCountry returns the upper case ISO 3166-1 alpha-2 code of country,
+// or an error suggesting the closest codes
+func NormalizeCountry(country string) (string, error) {
+	c, ok := iso.LookupCountry(country)
+	if !ok {
+		return "", fmt.Errorf("%w: %q is not an ISO 3166-1 alpha-2 country code%s", constants.ErrInvalidCountryFormat,
+			country, didYouMean(iso.SuggestCountry(country)))
 	}
 
-	return nil
+	return c.Code, nil
+}
+
+// didYouMean renders suggested codes at the end of an error message
+func didYouMean(codes []string) string {
+	if len(codes) == 0 {
+		return ""
+	}
+
+	return ", did you mean " + strings.Join(codes, " or ") + "?"
 }
 
 // ValidatePrefixID checks if the id starts with the given prefix
@@ -0,0 +1,43 @@
+// Package version reports the build version of the server.
+package version
+
+import (
+	"runtime"
+	"runtime/debug"
+)
+
+// Version and Commit are set at build time, e.g.
+//
+//	go build -ldflags "-X github.com/tazapay/tazapay-mcp-server/pkg/version.Version=1.2.3 \
+//	  -X github.com/tazapay/tazapay-mcp-server/pkg/version.Commit=$(git rev-parse HEAD)"
+var (
+	Version = "0.1.2"
+	Commit  = ""
+)
+
+// Info describes the running build
+type Info struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	GoVersion string `json:"go_version"`
+}
+
+// Get returns the build info. Without a Commit set at build time, the VCS
+// revision recorded by the Go toolchain is used.
+func Get() Info {
+	info := Info{Version: Version, Commit: Commit, GoVersion: runtime.Version()}
+
+	if info.Commit == "" {
+		info.Commit = "unknown"
+
+		if build, ok := debug.ReadBuildInfo(); ok {
+			for _, setting := range build.Settings {
+				if setting.Key == "vcs.revision" {
+					info.Commit = setting.Value
+				}
+			}
+		}
+	}
+
+	return info
+}
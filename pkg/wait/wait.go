@@ -0,0 +1,141 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Default polling settings
+const (
+	DefaultTimeout  = 5 * time.Minute
+	MaxTimeout      = 30 * time.Minute
+	InitialInterval = 2 * time.Second
+	MaxInterval     = 30 * time.Second
+)
+
+// errTimeout is the cause of the cancellation of the wait when the timeout expires
+var errTimeout = errors.New("wait timed out")
+
+// FetchFunc returns the current status of the object being waited on
+type FetchFunc func(ctx context.Context) (string, error)
+
+// Change is a status observed while polling
+type Change struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// Options controls Until. Zero durations use the defaults.
+type Options struct {
+	// Targets are the statuses that end the wait, compared case-insensitively
+	Targets []string
+	Timeout time.Duration
+	// Interval is the first delay between polls, doubled after each poll up to MaxInterval
+	Interval    time.Duration
+	MaxInterval time.Duration
+	// OnChange is called with every change of the status, starting with the first one
+	OnChange func(Change)
+}
+
+// Result is the outcome of a wait
+type Result struct {
+	Status   string   `json:"status"`
+	Reached  bool     `json:"reached"`
+	TimedOut bool     `json:"timed_out"`
+	Polls    int      `json:"polls"`
+	Elapsed  string   `json:"elapsed"`
+	Changes  []Change `json:"changes"`
+}
+
+// Until polls fetch with exponential backoff until it returns one of the target
+// statuses or the timeout expires. A timeout is not an error, it is reported in
+// the result. Fetch errors and the cancellation of ctx end the wait with an error.
+func Until(ctx context.Context, fetch FetchFunc, opts Options) (Result, error) {
+	opts = opts.withDefaults()
+	start := time.Now()
+
+	ctx, cancel := context.WithTimeoutCause(ctx, opts.Timeout, errTimeout)
+	defer cancel()
+
+	var res Result
+
+	interval := opts.Interval
+
+	for {
+		status, err := fetch(ctx)
+		if err != nil {
+			return res.end(ctx, start, err)
+		}
+
+		res.Polls++
+
+		if res.Polls == 1 || status != res.Status {
+			change := Change{Status: status, At: time.Now().UTC()}
+			res.Status = status
+			res.Changes = append(res.Changes, change)
+
+			if opts.OnChange != nil {
+				opts.OnChange(change)
+			}
+		}
+
+		if isTarget(opts.Targets, status) {
+			res.Reached = true
+			return res.end(ctx, start, nil)
+		}
+
+		timer := time.NewTimer(interval)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return res.end(ctx, start, ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = min(interval*2, opts.MaxInterval)
+	}
+}
+
+// withDefaults fills the zero durations
+func (o Options) withDefaults() Options {
+	if o.Timeout <= 0 {
+		o.Timeout = DefaultTimeout
+	}
+
+	o.Timeout = min(o.Timeout, MaxTimeout)
+
+	if o.Interval <= 0 {
+		o.Interval = InitialInterval
+	}
+
+	if o.MaxInterval <= 0 {
+		o.MaxInterval = MaxInterval
+	}
+
+	return o
+}
+
+// end records the elapsed time and reports an expired timeout in the result
+// rather than as an error. The cancellation of the caller's context is still
+// returned as an error.
+func (r Result) end(ctx context.Context, start time.Time, err error) (Result, error) {
+	r.Elapsed = time.Since(start).Round(time.Millisecond).String()
+
+	if err != nil && errors.Is(context.Cause(ctx), errTimeout) {
+		r.TimedOut = true
+		return r, nil
+	}
+
+	return r, err
+}
+
+// isTarget reports whether status is one of the targets
+func isTarget(targets []string, status string) bool {
+	return slices.ContainsFunc(targets, func(target string) bool {
+		return strings.EqualFold(target, status)
+	})
+}
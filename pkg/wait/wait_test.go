@@ -0,0 +1,80 @@
+package wait
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// sequence returns a FetchFunc returning the statuses in turn, repeating the last one
+func sequence(statuses ...string) FetchFunc {
+	i := 0
+
+	return func(context.Context) (string, error) {
+		status := statuses[min(i, len(statuses)-1)]
+		i++
+
+		return status, nil
+	}
+}
+
+func TestUntil(t *testing.T) {
+	tests := []struct {
+		name     string
+		fetch    FetchFunc
+		targets  []string
+		reached  bool
+		timedOut bool
+		status   string
+		changes  int
+	}{
+		{"already there", sequence("succeeded"), []string{"succeeded"}, true, false, "succeeded", 1},
+		{"reaches target", sequence("processing", "processing", "succeeded"), []string{"succeeded", "failed"}, true, false, "succeeded", 2},
+		{"case insensitive", sequence("pending", "FAILED"), []string{"failed"}, true, false, "FAILED", 2},
+		{"times out", sequence("processing"), []string{"succeeded"}, false, true, "processing", 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var notified []Change
+
+			res, err := Until(context.Background(), test.fetch, Options{
+				Targets:     test.targets,
+				Timeout:     50 * time.Millisecond,
+				Interval:    time.Millisecond,
+				MaxInterval: 2 * time.Millisecond,
+				OnChange:    func(c Change) { notified = append(notified, c) },
+			})
+			if err != nil {
+				t.Fatalf("Until() error: %v", err)
+			}
+
+			if res.Reached != test.reached || res.TimedOut != test.timedOut || res.Status != test.status {
+				t.Errorf("Until() = %+v; want reached %v, timed out %v, status %q",
+					res, test.reached, test.timedOut, test.status)
+			}
+
+			if len(res.Changes) != test.changes || len(notified) != test.changes {
+				t.Errorf("got %d changes and %d notifications; want %d", len(res.Changes), len(notified), test.changes)
+			}
+		})
+	}
+}
+
+func TestUntilErrors(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	_, err := Until(context.Background(), func(context.Context) (string, error) { return "", errFetch }, Options{})
+	if !errors.Is(err, errFetch) {
+		t.Errorf("Until() with failing fetch = %v; want %v", err, errFetch)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(10*time.Millisecond, cancel)
+
+	res, err := Until(ctx, sequence("processing"), Options{Targets: []string{"succeeded"}, Interval: time.Millisecond})
+	if !errors.Is(err, context.Canceled) || res.TimedOut {
+		t.Errorf("Until() after cancel = %+v, %v; want context.Canceled", res, err)
+	}
+}
@@ -0,0 +1,151 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Event is a Tazapay webhook event, e.g. payin.succeeded or payout.failed
+type Event struct {
+	ReceivedAt time.Time       `json:"received_at"`
+	Data       json.RawMessage `json:"data"`
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	ObjectID   string          `json:"object_id"`
+	Status     string          `json:"status,omitempty"`
+}
+
+// ParseEvent decodes a webhook body. The object ID and status are taken from
+// the object in data, the event ID falls back to a hash of the body.
+func ParseEvent(body []byte, receivedAt time.Time) (Event, error) {
+	var raw struct {
+		Data json.RawMessage `json:"data"`
+		ID   string          `json:"id"`
+		Type string          `json:"type"`
+	}
+
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return Event{}, fmt.Errorf("%w: %w", constants.ErrInvalidWebhookEvent, err)
+	}
+
+	if raw.Type == "" || len(raw.Data) == 0 {
+		return Event{}, fmt.Errorf("%w: missing type or data", constants.ErrInvalidWebhookEvent)
+	}
+
+	var object struct {
+		ID     string `json:"id"`
+		Status string `json:"status"`
+	}
+
+	if err := json.Unmarshal(raw.Data, &object); err != nil {
+		return Event{}, fmt.Errorf("%w: data: %w", constants.ErrInvalidWebhookEvent, err)
+	}
+
+	id := raw.ID
+	if id == "" {
+		sum := sha256.Sum256(body)
+		id = "sha256:" + hex.EncodeToString(sum[:])
+	}
+
+	return Event{
+		ReceivedAt: receivedAt.UTC(),
+		Data:       raw.Data,
+		ID:         id,
+		Type:       raw.Type,
+		ObjectID:   object.ID,
+		Status:     object.Status,
+	}, nil
+}
+
+// Failed reports whether the event tells about a failed or reversed object
+func (e Event) Failed() bool {
+	for _, word := range []string{"fail", "revers", "reject", "cancel", "expire"} {
+		if strings.Contains(e.Type, word) || strings.Contains(e.Status, word) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// replayWindow is how far the timestamp of a delivery may be from the current
+// time, so that a captured delivery cannot be replayed later
+const replayWindow = 5 * time.Minute
+
+// Sign returns the hex encoded HMAC-SHA256 of a delivery sent at timestamp,
+// in Unix seconds. An empty timestamp signs the body alone.
+func Sign(secret []byte, timestamp string, body []byte) string {
+	return hex.EncodeToString(signature(secret, timestamp, body))
+}
+
+// signature is the HMAC-SHA256 of "<timestamp>.<body>", or of the body alone
+// without timestamp
+func signature(secret []byte, timestamp string, body []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	if timestamp != "" {
+		mac.Write([]byte(timestamp + "."))
+	}
+
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
+
+// checkSignature compares a hex or base64 encoded signature header, optionally
+// prefixed with "sha256=", with the signature of the timestamp and body
+func checkSignature(secret, body []byte, header, timestamp string) error {
+	signed := strings.TrimPrefix(strings.TrimSpace(header), "sha256=")
+	if signed == "" {
+		return constants.ErrInvalidWebhookSignature
+	}
+
+	got, err := hex.DecodeString(signed)
+	if err != nil {
+		if got, err = base64.StdEncoding.DecodeString(signed); err != nil {
+			return constants.ErrInvalidWebhookSignature
+		}
+	}
+
+	if !hmac.Equal(got, signature(secret, timestamp, body)) {
+		return constants.ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+// VerifySignature checks the signature and timestamp headers of a webhook
+// body. The signature header holds the HMAC-SHA256 of the timestamp, a dot and
+// the raw body keyed with the webhook secret, hex or base64 encoded, optionally
+// prefixed with "sha256=". The timestamp, in Unix seconds, must be within
+// replayWindow of now.
+func VerifySignature(secret, body []byte, header, timestamp string, now time.Time) error {
+	timestamp = strings.TrimSpace(timestamp)
+	if timestamp == "" {
+		return fmt.Errorf("%w: no timestamp", constants.ErrStaleWebhook)
+	}
+
+	if err := checkSignature(secret, body, header, timestamp); err != nil {
+		return err
+	}
+
+	// checked once signed, so that the timestamp cannot be forged
+	sent, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: %q", constants.ErrStaleWebhook, timestamp)
+	}
+
+	if age := now.Sub(time.Unix(sent, 0)); age > replayWindow || age < -replayWindow {
+		return fmt.Errorf("%w: sent %s ago", constants.ErrStaleWebhook, age.Round(time.Second))
+	}
+
+	return nil
+}
@@ -0,0 +1,76 @@
+package webhook
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Handler returns the HTTP handler receiving Tazapay webhooks. Requests must be
+// signed with secret following scheme, see Scheme. New events are stored and
+// published to the watching sessions; redeliveries are acknowledged and dropped.
+func (h *Hub) Handler(secret []byte, scheme Scheme) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", http.MethodPost)
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, constants.WebhookMaxBodySize))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		if err := scheme.Verify(secret, body, r.Header, time.Now()); err != nil {
+			h.logger.WarnContext(r.Context(), "Rejected webhook", "remote", r.RemoteAddr, constants.KeyError, err)
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+
+			return
+		}
+
+		event, err := ParseEvent(body, time.Now())
+		if err != nil {
+			h.logger.WarnContext(r.Context(), "Rejected webhook", constants.KeyError, err)
+			http.Error(w, err.Error(), http.StatusBadRequest)
+
+			return
+		}
+
+		h.mu.Lock()
+		store := h.store
+		h.mu.Unlock()
+
+		if store == nil {
+			http.Error(w, constants.ErrWebhookDisabled.Error(), http.StatusServiceUnavailable)
+			return
+		}
+
+		added, err := store.Add(event)
+		if err != nil {
+			// not acknowledged, so that Tazapay delivers the event again
+			h.logger.ErrorContext(r.Context(), "Failed to store webhook event", "event_id", event.ID, constants.KeyError, err)
+			http.Error(w, "failed to store event", http.StatusInternalServerError)
+
+			return
+		}
+
+		h.logger.InfoContext(r.Context(), "Received webhook event", "event_id", event.ID, "type", event.Type,
+			"object_id", event.ObjectID, "status", event.Status, "duplicate", !added)
+
+		if added {
+			h.Publish(event)
+		}
+
+		w.Header().Set(constants.HeaderContentType, constants.ContentTypeJSON)
+
+		if err := json.NewEncoder(w).Encode(map[string]any{"received": true, "duplicate": !added}); err != nil {
+			h.logger.WarnContext(r.Context(), "Failed to write webhook response", constants.KeyError, err)
+		}
+	})
+}
@@ -0,0 +1,345 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Resource URIs of the events received for a Tazapay object
+const (
+	EventsURIPrefix   = "tazapay://events/"
+	EventsURITemplate = EventsURIPrefix + "{object_id}"
+)
+
+// notificationLogger is the logger name of the log notifications sent for events
+const notificationLogger = "tazapay.webhook"
+
+// resourceEventsLimit is the number of events returned when reading an events resource
+const resourceEventsLimit = 50
+
+// maxOwned bounds the remembered object owners, the oldest is forgotten first
+const maxOwned = maxEvents
+
+// subscription is what a session watches for an account: object IDs and event
+// type patterns
+type subscription struct {
+	account    string
+	objectIDs  map[string]bool
+	eventTypes []string
+}
+
+// ownership is an object known to belong to an account
+type ownership struct {
+	account  string
+	objectID string
+}
+
+// Hub delivers received events to the MCP sessions watching them. Sessions only
+// see the events of objects their Tazapay account can read. It is safe for
+// concurrent use.
+type Hub struct {
+	server *server.MCPServer
+	store  *Store
+	logger *slog.Logger
+	subs   map[string]*subscription
+	// owned remembers the objects checked to belong to an account, in order
+	owned map[ownership]bool
+	order []ownership
+	// fetch reads an object with the credentials of ctx
+	fetch func(ctx context.Context, logger *slog.Logger, kind, id string) (map[string]any, error)
+	mu    sync.Mutex
+}
+
+// NewHub returns a Hub that is not attached to a server yet
+func NewHub() *Hub {
+	return &Hub{subs: make(map[string]*subscription), owned: make(map[ownership]bool), fetch: utils.FetchObject}
+}
+
+// Default is the process wide hub used by the event tools
+var Default = NewHub()
+
+// Attach enables the hub: events are stored in store and notified through s.
+// It also registers the events resource template with s.
+func (h *Hub) Attach(s *server.MCPServer, store *Store, logger *slog.Logger) {
+	h.mu.Lock()
+	h.server, h.store, h.logger = s, store, logger
+	h.mu.Unlock()
+
+	s.AddResourceTemplate(
+		mcp.NewResourceTemplate(EventsURITemplate, "Tazapay object events",
+			mcp.WithTemplateDescription("Webhook events received for a Tazapay object, newest first"),
+			mcp.WithTemplateMIMEType("application/json"),
+		),
+		h.readResource,
+	)
+}
+
+// Enabled reports whether the hub is attached to a server
+func (h *Hub) Enabled() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return h.store != nil
+}
+
+// Watch subscribes the session of ctx to the events of the object IDs and of
+// the event types, which may be glob patterns such as payout.*. Object IDs must
+// belong to the account of ctx, and event types only match the events of
+// objects that do.
+func (h *Hub) Watch(ctx context.Context, objectIDs, eventTypes []string) error {
+	if !h.Enabled() {
+		return constants.ErrWebhookDisabled
+	}
+
+	session := server.ClientSessionFromContext(ctx)
+	if session == nil {
+		return server.ErrNoActiveSession
+	}
+
+	for _, pattern := range eventTypes {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return err
+		}
+	}
+
+	// checked before taking the lock, as it may call Tazapay
+	for _, id := range objectIDs {
+		if !h.owns(ctx, id) {
+			return fmt.Errorf("%w: %s", constants.ErrObjectNotOwned, id)
+		}
+	}
+
+	account := utils.AccountID(ctx)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	sub, ok := h.subs[session.SessionID()]
+	if !ok || sub.account != account {
+		sub = &subscription{account: account, objectIDs: make(map[string]bool)}
+		h.subs[session.SessionID()] = sub
+	}
+
+	for _, id := range objectIDs {
+		sub.objectIDs[id] = true
+	}
+
+	sub.eventTypes = append(sub.eventTypes, eventTypes...)
+
+	return nil
+}
+
+// Events returns up to limit received events of objects belonging to the
+// account of ctx, newest first. An object ID not belonging to it is refused.
+func (h *Hub) Events(ctx context.Context, objectID, eventType string, limit int) ([]Event, error) {
+	h.mu.Lock()
+	store := h.store
+	h.mu.Unlock()
+
+	if store == nil {
+		return nil, constants.ErrWebhookDisabled
+	}
+
+	if objectID != "" && !h.owns(ctx, objectID) {
+		return nil, fmt.Errorf("%w: %s", constants.ErrObjectNotOwned, objectID)
+	}
+
+	account := utils.AccountID(ctx)
+
+	return store.Events(objectID, eventType, limit, func(id string) bool {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+
+		return h.owned[ownership{account, id}]
+	}), nil
+}
+
+// owns reports whether the object belongs to the account of ctx. Unknown
+// objects are fetched with the credentials of ctx, Tazapay only returning the
+// objects of the account they authenticate.
+func (h *Hub) owns(ctx context.Context, objectID string) bool {
+	key := ownership{utils.AccountID(ctx), objectID}
+
+	h.mu.Lock()
+	known, logger := h.owned[key], h.logger
+	h.mu.Unlock()
+
+	if known {
+		return true
+	}
+
+	kind, ok := utils.ObjectKind(objectID)
+	if !ok {
+		return false
+	}
+
+	if _, err := h.fetch(ctx, logger, kind, objectID); err != nil {
+		return false
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if !h.owned[key] {
+		h.owned[key] = true
+		h.order = append(h.order, key)
+
+		if len(h.order) > maxOwned {
+			delete(h.owned, h.order[0])
+			h.order = h.order[1:]
+		}
+	}
+
+	return true
+}
+
+// matches reports whether the subscription watches the event. h.mu must be held.
+func (h *Hub) matches(sub *subscription, event Event) bool {
+	if sub.objectIDs[event.ObjectID] {
+		return true
+	}
+
+	if !h.owned[ownership{sub.account, event.ObjectID}] {
+		return false
+	}
+
+	for _, pattern := range sub.eventTypes {
+		if ok, _ := path.Match(pattern, event.Type); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Publish notifies the sessions watching the event with a resource updated
+// notification for its events resource and a log message describing it. The
+// notifications are sent without holding the lock, so a slow session does not
+// hold up the other calls of the hub.
+func (h *Hub) Publish(event Event) {
+	h.mu.Lock()
+	srv, logger := h.server, h.logger
+
+	sessions := make([]string, 0)
+
+	for session, sub := range h.subs {
+		if h.matches(sub, event) {
+			sessions = append(sessions, session)
+		}
+	}
+	h.mu.Unlock()
+
+	level := mcp.LoggingLevelInfo
+	if event.Failed() {
+		level = mcp.LoggingLevelWarning
+	}
+
+	message := mcp.NewLoggingMessageNotification(level, notificationLogger, map[string]any{
+		"event_id":  event.ID,
+		"type":      event.Type,
+		"object_id": event.ObjectID,
+		"status":    event.Status,
+		"resource":  EventsURIPrefix + event.ObjectID,
+	})
+
+	for _, session := range sessions {
+		err := srv.SendNotificationToSpecificClient(session, mcp.MethodNotificationResourceUpdated,
+			map[string]any{"uri": EventsURIPrefix + event.ObjectID})
+		if errors.Is(err, server.ErrSessionNotFound) {
+			h.forget(session)
+			continue
+		}
+
+		if logErr := srv.SendLogMessageToSpecificClient(session, message); logErr != nil {
+			err = errors.Join(err, logErr)
+		}
+
+		if err != nil {
+			logger.Warn("Failed to notify session of webhook event", "session", session,
+				"event_id", event.ID, constants.KeyError, err)
+		}
+	}
+}
+
+// forget drops the subscription of a session that has gone
+func (h *Hub) forget(session string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(h.subs, session)
+}
+
+// Middleware returns a tool middleware that subscribes the calling session to
+// the Tazapay objects created or changed by mutating tools, once checked to
+// belong to its account
+func (h *Hub) Middleware() types.ToolMiddleware {
+	return func(tool types.Tool, next types.ToolHandlerFunc) types.ToolHandlerFunc {
+		if tool.Metadata().ReadOnly {
+			return next
+		}
+
+		return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			result, err := next(ctx, req)
+			if err != nil || result == nil || result.IsError || types.IsNotExecuted(result) || !h.Enabled() {
+				return result, err
+			}
+
+			if server.ClientSessionFromContext(ctx) == nil {
+				return result, err
+			}
+
+			// IDs echoed from the arguments may be of other accounts, so each is
+			// checked, off the request path as checking may call Tazapay
+			go h.watchAll(context.WithoutCancel(ctx), utils.FindObjectIDs(types.ResultText(result)))
+
+			return result, err
+		}
+	}
+}
+
+// watchAll subscribes the session of ctx to each of the objects belonging to
+// its account
+func (h *Hub) watchAll(ctx context.Context, objectIDs []string) {
+	h.mu.Lock()
+	logger := h.logger
+	h.mu.Unlock()
+
+	for _, id := range objectIDs {
+		if err := h.Watch(ctx, []string{id}, nil); err != nil {
+			logger.DebugContext(ctx, "Not watching object of tool result", "id", id, constants.KeyError, err)
+		}
+	}
+}
+
+// readResource returns the events of the object named in the resource URI,
+// when it belongs to the account of the session
+func (h *Hub) readResource(ctx context.Context, req mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+	objectID := strings.TrimPrefix(req.Params.URI, EventsURIPrefix)
+
+	events, err := h.Events(ctx, objectID, "", resourceEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	return []mcp.ResourceContents{
+		mcp.TextResourceContents{URI: req.Params.URI, MIMEType: "application/json", Text: string(body)},
+	}, nil
+}
@@ -0,0 +1,62 @@
+package webhook
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// Scheme names the headers a webhook delivery is signed with. The signature
+// is an HMAC-SHA256 keyed with the webhook secret, see VerifySignature. This
+// is the server's own scheme rather than one defined by Tazapay, so the
+// headers are configurable to match whatever signs the deliveries, such as a
+// relay in front of the server.
+type Scheme struct {
+	// SignatureHeader carries the signature
+	SignatureHeader string
+	// TimestampHeader carries the time the delivery was signed at, signed
+	// along with the body. Without it the body alone is signed and replayed
+	// deliveries cannot be told apart.
+	TimestampHeader string
+}
+
+// DefaultScheme signs deliveries in X-Tazapay-Signature with the timestamp in
+// X-Tazapay-Timestamp
+var DefaultScheme = Scheme{
+	SignatureHeader: constants.WebhookSignatureHeader,
+	TimestampHeader: constants.WebhookTimestampHeader,
+}
+
+// SchemeFromViper returns the DefaultScheme with the headers set in
+// TAZAPAY_WEBHOOK_SIGNATURE_HEADER and TAZAPAY_WEBHOOK_TIMESTAMP_HEADER. A
+// timestamp header of "none" signs the body alone.
+func SchemeFromViper() Scheme {
+	scheme := DefaultScheme
+
+	if header := strings.TrimSpace(viper.GetString(constants.StrTAZAPAYWebhookSigHeader)); header != "" {
+		scheme.SignatureHeader = header
+	}
+
+	switch header := strings.TrimSpace(viper.GetString(constants.StrTAZAPAYWebhookTSHeader)); {
+	case strings.EqualFold(header, constants.WebhookNoTimestamp):
+		scheme.TimestampHeader = ""
+	case header != "":
+		scheme.TimestampHeader = header
+	}
+
+	return scheme
+}
+
+// Verify checks the signature of a delivery, and its timestamp when the
+// scheme has one
+func (s Scheme) Verify(secret, body []byte, header http.Header, now time.Time) error {
+	if s.TimestampHeader == "" {
+		return checkSignature(secret, body, header.Get(s.SignatureHeader), "")
+	}
+
+	return VerifySignature(secret, body, header.Get(s.SignatureHeader), header.Get(s.TimestampHeader), now)
+}
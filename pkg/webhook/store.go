@@ -0,0 +1,157 @@
+package webhook
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+const (
+	// maxLineSize bounds the size of a stored event when loading the store
+	maxLineSize = 4 << 20
+	// maxEvents is the number of recent events kept in memory for queries
+	maxEvents = 10000
+)
+
+// Store persists received events to a JSONL file and remembers their IDs to
+// drop redeliveries. It is safe for concurrent use.
+type Store struct {
+	file   *os.File
+	seen   map[string]bool
+	events []Event
+	mu     sync.Mutex
+}
+
+// Path returns the configured events file, by default
+// `.tazapay-mcp-server-events.jsonl` in the home directory
+func Path() string {
+	if path := viper.GetString(constants.StrTAZAPAYWebhookEventsPath); path != "" {
+		return path
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return constants.WebhookEventsFileName
+	}
+
+	return filepath.Join(home, constants.WebhookEventsFileName)
+}
+
+// OpenStore loads the events already stored at path and opens it for appending
+func OpenStore(path string) (*Store, error) {
+	s := &Store{seen: make(map[string]bool)}
+
+	if err := s.load(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, constants.AuditLogFileMode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.file = file
+
+	return s, nil
+}
+
+// load reads the stored events
+func (s *Store) load(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), maxLineSize)
+
+	for scanner.Scan() {
+		var event Event
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			return fmt.Errorf("%w: %s: %w", constants.ErrInvalidWebhookEvent, path, err)
+		}
+
+		s.remember(event)
+	}
+
+	return scanner.Err()
+}
+
+// Add stores the event and reports whether it is new. Events already stored
+// are ignored, Tazapay retries deliveries that were not acknowledged.
+func (s *Store) Add(event Event) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen[event.ID] {
+		return false, nil
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return false, err
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return false, err
+	}
+
+	s.remember(event)
+
+	return true, nil
+}
+
+// remember records the event ID and keeps the event in the recent events
+func (s *Store) remember(event Event) {
+	s.seen[event.ID] = true
+
+	s.events = append(s.events, event)
+	if len(s.events) > maxEvents {
+		s.events = slices.Delete(s.events, 0, len(s.events)-maxEvents)
+	}
+}
+
+// Events returns up to limit stored events for the object ID and event type,
+// newest first. Empty filters match everything. Events of objects for which
+// owned returns false are skipped, unless owned is nil.
+func (s *Store) Events(objectID, eventType string, limit int, owned func(objectID string) bool) []Event {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	out := make([]Event, 0)
+
+	for _, event := range slices.Backward(s.events) {
+		if len(out) == limit {
+			break
+		}
+
+		if (objectID == "" || event.ObjectID == objectID) && (eventType == "" || event.Type == eventType) &&
+			(owned == nil || owned(event.ObjectID)) {
+			out = append(out, event)
+		}
+	}
+
+	return out
+}
+
+// Close closes the events file
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
@@ -0,0 +1,280 @@
+package webhook
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+var secret = []byte("whsec_test")
+
+const payoutFailed = `{"id":"evt_1","type":"payout.failed","data":{"id":"pot_1","status":"failed"}}`
+
+func TestVerifySignature(t *testing.T) {
+	body := []byte(payoutFailed)
+	now := time.Unix(1700000000, 0)
+	sent := strconv.FormatInt(now.Unix(), 10)
+	signature := Sign(secret, sent, body)
+	raw, _ := hex.DecodeString(signature)
+
+	// at signs the body as sent at now plus offset
+	at := func(offset time.Duration) (string, string) {
+		timestamp := strconv.FormatInt(now.Add(offset).Unix(), 10)
+		return Sign(secret, timestamp, body), timestamp
+	}
+
+	old, oldSent := at(-replayWindow - time.Second)
+	future, futureSent := at(replayWindow + time.Second)
+	late, lateSent := at(-replayWindow + time.Second)
+
+	tests := []struct {
+		name      string
+		header    string
+		timestamp string
+		want      error
+	}{
+		{"hex", signature, sent, nil},
+		{"prefixed hex", "sha256=" + signature, sent, nil},
+		{"base64", base64.StdEncoding.EncodeToString(raw), sent, nil},
+		{"within the window", late, lateSent, nil},
+		{"missing", "", sent, constants.ErrInvalidWebhookSignature},
+		{"other secret", Sign([]byte("other"), sent, body), sent, constants.ErrInvalidWebhookSignature},
+		{"garbage", "not a signature", sent, constants.ErrInvalidWebhookSignature},
+		{"timestamp changed", signature, lateSent, constants.ErrInvalidWebhookSignature},
+		{"no timestamp", Sign(secret, "", body), "", constants.ErrStaleWebhook},
+		{"replayed", old, oldSent, constants.ErrStaleWebhook},
+		{"from the future", future, futureSent, constants.ErrStaleWebhook},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := VerifySignature(secret, body, test.header, test.timestamp, now)
+			if test.want == nil && err != nil {
+				t.Errorf("VerifySignature() = %v; want valid", err)
+			}
+
+			if test.want != nil && !errors.Is(err, test.want) {
+				t.Errorf("VerifySignature() = %v; want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestScheme(t *testing.T) {
+	body := []byte(payoutFailed)
+	now := time.Now()
+	sent := strconv.FormatInt(now.Unix(), 10)
+
+	t.Cleanup(viper.Reset)
+	viper.Set(constants.StrTAZAPAYWebhookSigHeader, "X-Relay-Signature")
+	viper.Set(constants.StrTAZAPAYWebhookTSHeader, "none")
+
+	bodyOnly := SchemeFromViper()
+	if bodyOnly != (Scheme{SignatureHeader: "X-Relay-Signature"}) {
+		t.Fatalf("SchemeFromViper() = %+v; want X-Relay-Signature without timestamp", bodyOnly)
+	}
+
+	tests := []struct {
+		name    string
+		scheme  Scheme
+		headers map[string]string
+		valid   bool
+	}{
+		{"default", DefaultScheme, map[string]string{
+			constants.WebhookSignatureHeader: Sign(secret, sent, body), constants.WebhookTimestampHeader: sent,
+		}, true},
+		{"default without timestamp", DefaultScheme, map[string]string{
+			constants.WebhookSignatureHeader: Sign(secret, "", body),
+		}, false},
+		{"body only", bodyOnly, map[string]string{"X-Relay-Signature": Sign(secret, "", body)}, true},
+		{"body only in the default header", bodyOnly, map[string]string{
+			constants.WebhookSignatureHeader: Sign(secret, "", body),
+		}, false},
+		{"body only signed with a timestamp", bodyOnly, map[string]string{
+			"X-Relay-Signature": Sign(secret, sent, body),
+		}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			header := make(http.Header)
+			for key, value := range test.headers {
+				header.Set(key, value)
+			}
+
+			if err := test.scheme.Verify(secret, body, header, now); (err == nil) != test.valid {
+				t.Errorf("Verify() = %v; want valid %v", err, test.valid)
+			}
+		})
+	}
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(payoutFailed), time.Now())
+	if err != nil {
+		t.Fatalf("ParseEvent() error: %v", err)
+	}
+
+	if event.ID != "evt_1" || event.Type != "payout.failed" || event.ObjectID != "pot_1" || event.Status != "failed" {
+		t.Errorf("ParseEvent() = %+v", event)
+	}
+
+	if !event.Failed() {
+		t.Error("Failed() = false for a payout.failed event")
+	}
+
+	if _, err := ParseEvent([]byte(`{"id":"evt_2"}`), time.Now()); !errors.Is(err, constants.ErrInvalidWebhookEvent) {
+		t.Errorf("ParseEvent() without type = %v; want ErrInvalidWebhookEvent", err)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+
+	store, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	defer store.Close()
+
+	hub := NewHub()
+	hub.Attach(server.NewMCPServer("test", "0"), store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	handler := hub.Handler(secret, DefaultScheme)
+
+	sent := strconv.FormatInt(time.Now().Unix(), 10)
+
+	post := func(body, signature string) int {
+		req := httptest.NewRequest(http.MethodPost, constants.WebhookPath, strings.NewReader(body))
+		req.Header.Set(constants.WebhookSignatureHeader, signature)
+		req.Header.Set(constants.WebhookTimestampHeader, sent)
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	if code := post(payoutFailed, "bad"); code != http.StatusUnauthorized {
+		t.Errorf("unsigned webhook status = %d; want 401", code)
+	}
+
+	if code := post(`{}`, Sign(secret, sent, []byte(`{}`))); code != http.StatusBadRequest {
+		t.Errorf("invalid event status = %d; want 400", code)
+	}
+
+	for range 2 {
+		if code := post(payoutFailed, Sign(secret, sent, []byte(payoutFailed))); code != http.StatusOK {
+			t.Errorf("signed webhook status = %d; want 200", code)
+		}
+	}
+
+	if events := store.Events("pot_1", "", 10, nil); len(events) != 1 {
+		t.Errorf("stored %d events for pot_1; want 1 after a redelivery", len(events))
+	}
+
+	// IDs already stored are remembered across restarts
+	reopened, err := OpenStore(path)
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	defer reopened.Close()
+
+	event, _ := ParseEvent([]byte(payoutFailed), time.Now())
+	if added, err := reopened.Add(event); err != nil || added {
+		t.Errorf("Add() after reopen = %v, %v; want false, nil", added, err)
+	}
+}
+
+// session is an MCP session known by its ID
+type session string
+
+func (session) Initialize()                                         {}
+func (session) Initialized() bool                                   { return true }
+func (session) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s session) SessionID() string                                 { return string(s) }
+
+func TestHubScopesEventsToAccount(t *testing.T) {
+	store, err := OpenStore(filepath.Join(t.TempDir(), "events.jsonl"))
+	if err != nil {
+		t.Fatalf("OpenStore() error: %v", err)
+	}
+	defer store.Close()
+
+	srv := server.NewMCPServer("test", "0")
+
+	hub := NewHub()
+	hub.Attach(srv, store, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	// each account can only fetch its own objects
+	owners := map[string]string{"pot_alice": "alice", "pot_bob": "bob"}
+	hub.fetch = func(ctx context.Context, _ *slog.Logger, _, id string) (map[string]any, error) {
+		if owners[id] != utils.AuthToken(ctx) {
+			return nil, constants.ErrNonSuccessStatus
+		}
+
+		return map[string]any{"id": id}, nil
+	}
+
+	for _, id := range []string{"pot_alice", "pot_bob"} {
+		event, _ := ParseEvent([]byte(`{"id":"evt_`+id+`","type":"payout.failed","data":{"id":"`+id+`"}}`), time.Now())
+		if _, err := store.Add(event); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	alice := srv.WithContext(utils.WithAuthToken(context.Background(), "alice"), session("s1"))
+
+	if err := hub.Watch(alice, []string{"pot_bob"}, nil); !errors.Is(err, constants.ErrObjectNotOwned) {
+		t.Errorf("Watch(pot_bob) = %v; want ErrObjectNotOwned", err)
+	}
+
+	if err := hub.Watch(alice, []string{"pot_alice"}, []string{"*"}); err != nil {
+		t.Fatalf("Watch(pot_alice) error: %v", err)
+	}
+
+	sub := hub.subs["s1"]
+	for id, want := range map[string]bool{"pot_alice": true, "pot_bob": false, "pot_other": false} {
+		if got := hub.matches(sub, Event{ObjectID: id, Type: "payout.failed"}); got != want {
+			t.Errorf("watching * matches %s = %v; want %v", id, got, want)
+		}
+	}
+
+	events, err := hub.Events(alice, "", "", 10)
+	if err != nil || len(events) != 1 || events[0].ObjectID != "pot_alice" {
+		t.Errorf("Events() = %+v, %v; want the pot_alice event only", events, err)
+	}
+
+	if _, err := hub.Events(alice, "pot_bob", "", 10); !errors.Is(err, constants.ErrObjectNotOwned) {
+		t.Errorf("Events(pot_bob) = %v; want ErrObjectNotOwned", err)
+	}
+
+	req := mcp.ReadResourceRequest{}
+	req.Params.URI = EventsURIPrefix + "pot_bob"
+
+	if _, err := hub.readResource(alice, req); !errors.Is(err, constants.ErrObjectNotOwned) {
+		t.Errorf("reading the events of pot_bob = %v; want ErrObjectNotOwned", err)
+	}
+
+	// objects checked for alice are not shared with other accounts
+	bob := srv.WithContext(utils.WithAuthToken(context.Background(), "bob"), session("s2"))
+	if events, _ := hub.Events(bob, "", "", 10); len(events) != 0 {
+		t.Errorf("Events() for bob = %+v; want none before bob uses pot_bob", events)
+	}
+}
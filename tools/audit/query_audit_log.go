@@ -0,0 +1,127 @@
+package audittool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/audit"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// AuditLogParams are the arguments of the query audit log tool
+type AuditLogParams struct {
+	Tool     string `json:"tool,omitempty" description:"Only calls of this tool name"`
+	Session  string `json:"session,omitempty" description:"Only calls from this MCP session ID"`
+	Outcome  string `json:"outcome,omitempty" schema:"enum=success|error|denied|not_executed" description:"Only calls with this outcome"`
+	ObjectID string `json:"object_id,omitempty" description:"Only calls involving this Tazapay object ID, e.g. pot_..."`
+	Since    string `json:"since,omitempty" description:"Only calls after this time, as a duration before now (e.g. 24h) or an RFC 3339 time"`
+	Limit    int    `json:"limit,omitempty" description:"Maximum number of records to return (default 20, at most 500)"`
+	Verify   bool   `json:"verify,omitempty" description:"Also verify the hash chain of the whole log"`
+}
+
+// AuditLogOutput is the structured output of the query audit log tool
+type AuditLogOutput struct {
+	Verified *bool          `json:"verified,omitempty" description:"Result of the hash chain verification, when asked for"`
+	Records  []audit.Record `json:"records" description:"Matching records, newest first"`
+}
+
+// QueryAuditLogTool searches the audit log of tool calls
+type QueryAuditLogTool struct {
+	logger *slog.Logger
+}
+
+// NewQueryAuditLogTool returns a new instance of the QueryAuditLogTool
+func NewQueryAuditLogTool(logger *slog.Logger) *QueryAuditLogTool {
+	logger.Info("Registering Query_Audit_Log_Tool")
+	return &QueryAuditLogTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*QueryAuditLogTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.QueryAuditLogToolName),
+		mcp.WithDescription(constants.QueryAuditLogToolDesc),
+		schema.Input[AuditLogParams](),
+		schema.Output[AuditLogOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*QueryAuditLogTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *QueryAuditLogTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	t.logger.InfoContext(ctx, "Handling QueryAuditLogTool request", "args", req.GetArguments())
+
+	params := AuditLogParams{Limit: audit.DefaultLimit}
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	since, err := audit.ParseSince(params.Since, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	filter := audit.Filter{
+		Since:    since,
+		Tool:     params.Tool,
+		Session:  params.Session,
+		Outcome:  params.Outcome,
+		ObjectID: params.ObjectID,
+		Limit:    params.Limit,
+	}
+
+	path := audit.Path()
+
+	var (
+		b      strings.Builder
+		output AuditLogOutput
+	)
+
+	if params.Verify {
+		key, err := audit.Key(path)
+		count := 0
+
+		if err == nil {
+			count, err = audit.Verify(path, key)
+		}
+
+		verified := err == nil
+		output.Verified = &verified
+
+		if err != nil {
+			fmt.Fprintf(&b, "Hash chain verification FAILED after %d records: %v\n", count, err)
+		} else {
+			fmt.Fprintf(&b, "Hash chain verified, %d records.\n", count)
+		}
+	}
+
+	records, err := audit.Query(path, filter)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Failed to query audit log", constants.KeyError, err)
+		return nil, err
+	}
+
+	if records == nil {
+		records = []audit.Record{}
+	}
+
+	output.Records = records
+	fmt.Fprintf(&b, "%d matching records, newest first", len(records))
+
+	return types.NewResult(output, b.String())
+}
@@ -0,0 +1,200 @@
+package completion
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/iso"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// balanceCacheTTL bounds how often completion hits the balance API
+const balanceCacheTTL = 5 * time.Minute
+
+// labelSeparator separates a code from its description in completion values
+const labelSeparator = " - "
+
+// Provider completes prompt and resource template arguments.
+// Values are chosen by argument name, so every prompt gets the same suggestions
+// for currency, country, purpose code and ID arguments. Codes are completed as
+// "<code> - <description>"; Code gives back the code.
+type Provider struct {
+	logger *slog.Logger
+	url    string
+	now    func() time.Time
+
+	mu sync.Mutex
+	// holdings caches the balance currencies per account
+	holdings map[string]holdings
+}
+
+// holdings are the balance currencies of an account
+type holdings struct {
+	currencies []string
+	fetchedAt  time.Time
+}
+
+// NewProvider returns a new completion Provider
+func NewProvider(logger *slog.Logger) *Provider {
+	return &Provider{
+		logger:   logger,
+		url:      constants.BalanceBaseURLProd,
+		now:      time.Now,
+		holdings: make(map[string]holdings),
+	}
+}
+
+// Code returns the code of a completion value, which may carry a description
+func Code(value string) string {
+	code, _, _ := strings.Cut(value, labelSeparator)
+	return strings.TrimSpace(code)
+}
+
+// CompletePromptArgument implements server.PromptCompletionProvider
+func (p *Provider) CompletePromptArgument(ctx context.Context, _ string,
+	argument mcp.CompleteArgument, _ mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	return p.complete(ctx, argument), nil
+}
+
+// CompleteResourceArgument implements server.ResourceCompletionProvider
+func (p *Provider) CompleteResourceArgument(ctx context.Context, _ string,
+	argument mcp.CompleteArgument, _ mcp.CompleteContext,
+) (*mcp.Completion, error) {
+	return p.complete(ctx, argument), nil
+}
+
+// complete returns the suggestions for a single argument
+func (p *Provider) complete(ctx context.Context, argument mcp.CompleteArgument) *mcp.Completion {
+	var values []string
+
+	switch argument.Name {
+	case constants.ArgCurrency, constants.ArgHoldingCurrency, constants.ArgInvoiceCurrency,
+		constants.FXFromField, constants.FXToField:
+		values = currencies(p.holdingCurrencies(ctx), argument.Value)
+	case constants.ArgCountry, constants.CustomerCountryField:
+		values = countries(argument.Value)
+	case constants.ArgPurpose, constants.ArgPurposeCode:
+		values = purposeCodes(argument.Value)
+	case constants.ArgBeneficiary:
+		values = recent.List(ctx, recent.KindBeneficiary, argument.Value)
+	case constants.ArgCustomer:
+		values = recent.List(ctx, recent.KindCustomer, argument.Value)
+	}
+
+	return newCompletion(values)
+}
+
+// holdingCurrencies returns the currencies of the balances of the account of
+// ctx, cached for balanceCacheTTL. The balance API is called without holding
+// the lock, so a slow call does not hold up other accounts.
+func (p *Provider) holdingCurrencies(ctx context.Context) []string {
+	account := utils.AccountID(ctx)
+
+	p.mu.Lock()
+	cached, ok := p.holdings[account]
+	p.mu.Unlock()
+
+	if ok && p.now().Sub(cached.fetchedAt) < balanceCacheTTL {
+		return cached.currencies
+	}
+
+	resp, err := utils.HandleGETHttpRequest(ctx, p.logger, p.url, constants.GetHTTPMethod)
+	if err != nil {
+		p.logger.WarnContext(ctx, "Failed to fetch balances for completion", constants.KeyError, err)
+		return cached.currencies
+	}
+
+	var balances types.BalanceResponse
+	if err := utils.MapToStruct(resp, &balances); err != nil {
+		p.logger.WarnContext(ctx, "Failed to parse balances for completion", constants.KeyError, err)
+		return cached.currencies
+	}
+
+	currencies := make([]string, 0, len(balances.Data.Available))
+	for _, balance := range balances.Data.Available {
+		currencies = append(currencies, strings.ToUpper(balance.Currency))
+	}
+
+	sort.Strings(currencies)
+
+	p.mu.Lock()
+	p.holdings[account] = holdings{currencies: currencies, fetchedAt: p.now()}
+	p.mu.Unlock()
+
+	return currencies
+}
+
+// currencies returns the labels of the codes starting with value
+func currencies(codes []string, value string) []string {
+	out := make([]string, 0, len(codes))
+	for _, code := range codes {
+		label := code
+		if currency, ok := iso.LookupCurrency(code); ok {
+			label = code + labelSeparator + currency.Name
+		}
+
+		if hasPrefixFold(label, value) {
+			out = append(out, label)
+		}
+	}
+
+	return out
+}
+
+// countries returns the labels of the ISO 3166 countries whose code or name starts with value
+func countries(value string) []string {
+	out := make([]string, 0)
+	for _, country := range iso.Countries {
+		label := country.Code + labelSeparator + country.Name
+		if hasPrefixFold(label, value) || hasPrefixFold(country.Name, value) {
+			out = append(out, label)
+		}
+	}
+
+	return out
+}
+
+// purposeCodes returns the labels of the purpose codes whose code starts with,
+// or description contains, value
+func purposeCodes(value string) []string {
+	out := make([]string, 0)
+	for _, purpose := range constants.PayoutPurposeCodes {
+		label := purpose.Code + labelSeparator + purpose.Description
+		if hasPrefixFold(label, value) ||
+			strings.Contains(strings.ToLower(purpose.Description), strings.ToLower(value)) {
+			out = append(out, label)
+		}
+	}
+
+	return out
+}
+
+// hasPrefixFold reports whether s starts with prefix, ignoring case
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
+
+// newCompletion builds a completion capped at the protocol limit of 100 values
+func newCompletion(values []string) *mcp.Completion {
+	if values == nil {
+		values = []string{}
+	}
+
+	completion := &mcp.Completion{Values: values, Total: len(values)}
+	if len(values) > constants.MaxCompletionValues {
+		completion.Values = values[:constants.MaxCompletionValues]
+		completion.HasMore = true
+	}
+
+	return completion
+}
@@ -0,0 +1,209 @@
+package completion
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// session is an MCP session known by its ID
+type session string
+
+func (session) Initialize()                                         {}
+func (session) Initialized() bool                                   { return true }
+func (session) NotificationChannel() chan<- mcp.JSONRPCNotification { return nil }
+func (s session) SessionID() string                                 { return string(s) }
+
+// sessionContext returns the context of a request of session authenticated with token
+func sessionContext(id, token string) context.Context {
+	ctx := utils.WithAuthToken(context.Background(), token)
+	return server.NewMCPServer("test", "1").WithContext(ctx, session(id))
+}
+
+// balances serves the balances of each auth token, given as comma separated currencies
+func balances(t *testing.T, byToken map[string]string, calls *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		token := strings.TrimPrefix(r.Header.Get(constants.HeaderAuthorization), constants.AuthSchemeBasic)
+
+		available := make([]string, 0)
+		for _, currency := range strings.Split(byToken[token], ",") {
+			available = append(available, fmt.Sprintf(`{"currency":%q,"amount":100}`, currency))
+		}
+
+		_, _ = fmt.Fprintf(w, `{"status":"success","data":{"available":[%s]}}`, strings.Join(available, ","))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+// newProvider returns a provider fetching the balances from url
+func newProvider(url string) *Provider {
+	p := NewProvider(slog.New(slog.NewTextHandler(io.Discard, nil)))
+	p.url = url
+
+	return p
+}
+
+// complete completes the argument name from value
+func complete(ctx context.Context, p *Provider, name, value string) []string {
+	completion, _ := p.CompletePromptArgument(ctx, "", mcp.CompleteArgument{Name: name, Value: value},
+		mcp.CompleteContext{})
+
+	return completion.Values
+}
+
+func TestComplete(t *testing.T) {
+	var calls atomic.Int32
+
+	p := newProvider(balances(t, map[string]string{"alice": "usd,SGD"}, &calls).URL)
+	ctx := sessionContext("s1", "alice")
+
+	tests := []struct {
+		argument, value string
+		want            []string
+	}{
+		{constants.ArgCurrency, "", []string{"SGD - Singapore Dollar", "USD - US Dollar"}},
+		{constants.ArgHoldingCurrency, "us", []string{"USD - US Dollar"}},
+		{constants.ArgInvoiceCurrency, "EUR", []string{}},
+		{constants.ArgCountry, "singa", []string{"SG - Singapore"}},
+		{constants.ArgCountry, "sg", []string{"SG - Singapore"}},
+		{constants.ArgPurpose, "pyr007", []string{"PYR007 - Salary payment"}},
+		{constants.ArgPurposeCode, "salary", []string{"PYR007 - Salary payment"}},
+		{constants.ArgAmount, "1", []string{}},
+	}
+
+	for _, test := range tests {
+		if got := complete(ctx, p, test.argument, test.value); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("complete(%s, %q) = %q; want %q", test.argument, test.value, got, test.want)
+		}
+	}
+
+	if calls.Load() != 1 {
+		t.Errorf("balance API called %d times; want 1", calls.Load())
+	}
+}
+
+func TestCode(t *testing.T) {
+	tests := map[string]string{
+		"PYR007 - Salary payment": "PYR007",
+		"USD - US Dollar":         "USD",
+		"SG":                      "SG",
+		" INR ":                   "INR",
+	}
+
+	for value, want := range tests {
+		if got := Code(value); got != want {
+			t.Errorf("Code(%q) = %q; want %q", value, got, want)
+		}
+	}
+}
+
+func TestHoldingCurrenciesPerAccount(t *testing.T) {
+	var calls atomic.Int32
+
+	p := newProvider(balances(t, map[string]string{"alice": "SGD", "bob": "INR"}, &calls).URL)
+
+	now := time.Unix(0, 0)
+	p.now = func() time.Time { return now }
+
+	alice, bob := sessionContext("s1", "alice"), sessionContext("s2", "bob")
+
+	for range 2 {
+		if got := p.holdingCurrencies(alice); !reflect.DeepEqual(got, []string{"SGD"}) {
+			t.Errorf("alice holds %v; want [SGD]", got)
+		}
+
+		if got := p.holdingCurrencies(bob); !reflect.DeepEqual(got, []string{"INR"}) {
+			t.Errorf("bob holds %v; want [INR]", got)
+		}
+	}
+
+	if calls.Load() != 2 {
+		t.Errorf("balance API called %d times; want once per account", calls.Load())
+	}
+
+	now = now.Add(balanceCacheTTL)
+	p.holdingCurrencies(alice)
+
+	if calls.Load() != 3 {
+		t.Errorf("balance API called %d times; want the expired cache refreshed", calls.Load())
+	}
+}
+
+func TestHoldingCurrenciesDoNotWaitForOtherAccounts(t *testing.T) {
+	started, release := make(chan struct{}), make(chan struct{})
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(constants.HeaderAuthorization) == constants.AuthSchemeBasic+"slow" {
+			close(started)
+			<-release
+		}
+
+		_, _ = io.WriteString(w, `{"data":{"available":[{"currency":"USD"}]}}`)
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	p := newProvider(srv.URL)
+
+	go p.holdingCurrencies(sessionContext("s1", "slow"))
+	<-started
+
+	done := make(chan []string)
+	go func() { done <- p.holdingCurrencies(sessionContext("s2", "fast")) }()
+
+	select {
+	case got := <-done:
+		if !reflect.DeepEqual(got, []string{"USD"}) {
+			t.Errorf("holdingCurrencies() = %v; want [USD]", got)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("completion waited for the balance call of another account")
+	}
+}
+
+func TestRecentIDsPerSession(t *testing.T) {
+	p := newProvider("")
+
+	alice := sessionContext("completion-s1", "alice")
+	recent.Add(alice, recent.KindBeneficiary, "bnf_alice")
+	recent.Add(alice, recent.KindCustomer, "cus_alice")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		argument string
+		want     []string
+	}{
+		{"same session", alice, constants.ArgBeneficiary, []string{"bnf_alice"}},
+		{"customer", alice, constants.ArgCustomer, []string{"cus_alice"}},
+		{"other session", sessionContext("completion-s2", "alice"), constants.ArgBeneficiary, []string{}},
+		{"other account", sessionContext("completion-s1", "bob"), constants.ArgBeneficiary, []string{}},
+	}
+
+	for _, test := range tests {
+		if got := complete(test.ctx, p, test.argument, ""); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s: complete(%s) = %v; want %v", test.name, test.argument, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package eventstool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/pkg/webhook"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// WatchParams are the arguments of the watch events tool
+type WatchParams struct {
+	ObjectIDs  []string `json:"object_ids,omitempty" description:"IDs of Tazapay objects to watch, e.g. pot_... or chk_..."`
+	EventTypes []string `json:"event_types,omitempty" description:"Event types to watch, glob patterns allowed, e.g. payout.* or payin.succeeded"`
+}
+
+// EventsParams are the arguments of the list events tool
+type EventsParams struct {
+	ObjectID string `json:"object_id,omitempty" description:"Only events for this Tazapay object ID"`
+	Type     string `json:"type,omitempty" description:"Only events of this type, e.g. payout.failed"`
+	Limit    int    `json:"limit,omitempty" description:"Maximum number of events to return (default 20)"`
+}
+
+// WatchOutput is the structured output of the watch events tool
+type WatchOutput struct {
+	ObjectIDs   []string `json:"object_ids,omitempty"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	ResourceURI string   `json:"resource_uri" description:"Resource updated with every matching event"`
+}
+
+// EventsOutput is the structured output of the list events tool
+type EventsOutput struct {
+	Events []webhook.Event `json:"events"`
+}
+
+// WatchEventsTool subscribes the session to webhook events
+type WatchEventsTool struct {
+	logger *slog.Logger
+}
+
+// NewWatchEventsTool returns a new instance of the WatchEventsTool
+func NewWatchEventsTool(logger *slog.Logger) *WatchEventsTool {
+	logger.Info("Registering Watch_Events_Tool")
+	return &WatchEventsTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*WatchEventsTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.WatchEventsToolName),
+		mcp.WithDescription(constants.WatchEventsToolDesc),
+		schema.Input[WatchParams](),
+		schema.Output[WatchOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*WatchEventsTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *WatchEventsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	t.logger.InfoContext(ctx, "Handling WatchEventsTool request", "args", req.GetArguments())
+
+	var params WatchParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	if err := webhook.Default.Watch(ctx, params.ObjectIDs, params.EventTypes); err != nil {
+		t.logger.ErrorContext(ctx, "Failed to watch events", constants.KeyError, err)
+		return nil, err
+	}
+
+	return types.NewResult(WatchOutput{
+		ObjectIDs:   params.ObjectIDs,
+		EventTypes:  params.EventTypes,
+		ResourceURI: webhook.EventsURITemplate,
+	}, "Watching webhook events. Matching events are sent to this session as "+
+		"log notifications and as updates of the "+webhook.EventsURITemplate+" resource.")
+}
+
+// ListEventsTool lists the received webhook events
+type ListEventsTool struct {
+	logger *slog.Logger
+}
+
+// NewListEventsTool returns a new instance of the ListEventsTool
+func NewListEventsTool(logger *slog.Logger) *ListEventsTool {
+	logger.Info("Registering List_Events_Tool")
+	return &ListEventsTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*ListEventsTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.ListEventsToolName),
+		mcp.WithDescription(constants.ListEventsToolDesc),
+		schema.Input[EventsParams](),
+		schema.Output[EventsOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*ListEventsTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *ListEventsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	t.logger.InfoContext(ctx, "Handling ListEventsTool request", "args", req.GetArguments())
+
+	params := EventsParams{Limit: constants.EventsDefaultLimit}
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	events, err := webhook.Default.Events(ctx, params.ObjectID, params.Type, params.Limit)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Failed to list events", constants.KeyError, err)
+		return nil, err
+	}
+
+	if events == nil {
+		events = []webhook.Event{}
+	}
+
+	return types.NewResult(EventsOutput{Events: events}, fmt.Sprintf("%d events received", len(events)))
+}
@@ -0,0 +1,148 @@
+package prompts
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/tools/completion"
+)
+
+// codeArgs are the arguments completed as a code followed by its description
+var codeArgs = []string{
+	constants.ArgCurrency, constants.ArgHoldingCurrency, constants.ArgInvoiceCurrency,
+	constants.ArgCountry, constants.ArgPurpose,
+}
+
+// PayBeneficiaryPrompt guides the model through paying an existing beneficiary
+type PayBeneficiaryPrompt struct {
+	logger *slog.Logger
+}
+
+// NewPayBeneficiaryPrompt returns a new instance of the PayBeneficiaryPrompt
+func NewPayBeneficiaryPrompt(logger *slog.Logger) *PayBeneficiaryPrompt {
+	logger.Info("Registering Pay_Beneficiary_Prompt")
+	return &PayBeneficiaryPrompt{logger: logger}
+}
+
+// Definition returns the prompt definition
+func (*PayBeneficiaryPrompt) Definition() mcp.Prompt {
+	return mcp.NewPrompt(
+		constants.PayBeneficiaryPromptName,
+		mcp.WithPromptDescription(constants.PayBeneficiaryPromptDesc),
+		mcp.WithArgument(constants.ArgBeneficiary, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("ID of the beneficiary, starting with bnf_")),
+		mcp.WithArgument(constants.ArgAmount, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Amount to pay, in major units (e.g. 10.50)")),
+		mcp.WithArgument(constants.ArgCurrency, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Payout currency (ISO 4217)")),
+		mcp.WithArgument(constants.ArgHoldingCurrency,
+			mcp.ArgumentDescription("Balance currency funding the payout (ISO 4217)")),
+		mcp.WithArgument(constants.ArgPurpose,
+			mcp.ArgumentDescription("Payout purpose code (PYR001 to PYR028)")),
+	)
+}
+
+// Handle renders the prompt
+func (p *PayBeneficiaryPrompt) Handle(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := arguments(req)
+	p.logger.InfoContext(ctx, "Handling PayBeneficiaryPrompt request", "args", args)
+
+	text := fmt.Sprintf("Pay beneficiary %s %s %s",
+		args[constants.ArgBeneficiary], args[constants.ArgAmount], args[constants.ArgCurrency])
+
+	// without a purpose code the model picks one rather than defaulting to PYR001
+	if purpose := args[constants.ArgPurpose]; purpose != "" {
+		text += fmt.Sprintf(" with purpose code %s%s.", purpose, purposeDescription(purpose))
+	} else {
+		text += ". Ask me what the payment is for and pick the purpose code with " +
+			toolname.Name(constants.RecommendPurposeCodeToolName) + "."
+	}
+
+	if holding := args[constants.ArgHoldingCurrency]; holding != "" {
+		text += fmt.Sprintf(" Fund it from the %s balance.", holding)
+	}
+
+	text += " Check the balance first, create the payout and then fund it." +
+		" Show me each payout summary and wait for my approval before confirming it."
+
+	return mcp.NewGetPromptResult(constants.PayBeneficiaryPromptDesc, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	}), nil
+}
+
+// CollectPaymentPrompt guides the model through creating a payment link
+type CollectPaymentPrompt struct {
+	logger *slog.Logger
+}
+
+// NewCollectPaymentPrompt returns a new instance of the CollectPaymentPrompt
+func NewCollectPaymentPrompt(logger *slog.Logger) *CollectPaymentPrompt {
+	logger.Info("Registering Collect_Payment_Prompt")
+	return &CollectPaymentPrompt{logger: logger}
+}
+
+// Definition returns the prompt definition
+func (*CollectPaymentPrompt) Definition() mcp.Prompt {
+	return mcp.NewPrompt(
+		constants.PaymentLinkPromptName,
+		mcp.WithPromptDescription(constants.PaymentLinkPromptDesc),
+		mcp.WithArgument(constants.ArgAmount, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Amount to collect, in major units (e.g. 10.50)")),
+		mcp.WithArgument(constants.ArgInvoiceCurrency, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Invoice currency (ISO 4217)")),
+		mcp.WithArgument(constants.ArgCountry, mcp.RequiredArgument(),
+			mcp.ArgumentDescription("Customer country (ISO 3166-1 alpha-2)")),
+		mcp.WithArgument(constants.ArgCustomer,
+			mcp.ArgumentDescription("ID of an existing customer, starting with cus_")),
+	)
+}
+
+// Handle renders the prompt
+func (p *CollectPaymentPrompt) Handle(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+	args := arguments(req)
+	p.logger.InfoContext(ctx, "Handling CollectPaymentPrompt request", "args", args)
+
+	text := fmt.Sprintf(
+		"Create a payment link for %s %s for a customer in %s.",
+		args[constants.ArgAmount], args[constants.ArgInvoiceCurrency], args[constants.ArgCountry],
+	)
+	if customer := args[constants.ArgCustomer]; customer != "" {
+		text += fmt.Sprintf(" Use the name and email of customer %s.", customer)
+	}
+
+	return mcp.NewGetPromptResult(constants.PaymentLinkPromptDesc, []mcp.PromptMessage{
+		mcp.NewPromptMessage(mcp.RoleUser, mcp.NewTextContent(text)),
+	}), nil
+}
+
+// arguments returns the prompt arguments, without the description completion
+// adds after a code
+func arguments(req mcp.GetPromptRequest) map[string]string {
+	args := make(map[string]string, len(req.Params.Arguments))
+	for name, value := range req.Params.Arguments {
+		if slices.Contains(codeArgs, name) {
+			value = completion.Code(value)
+		}
+
+		args[name] = value
+	}
+
+	return args
+}
+
+// purposeDescription returns " (<description>)" for a known purpose code, or an empty string
+func purposeDescription(code string) string {
+	for _, purpose := range constants.PayoutPurposeCodes {
+		if purpose.Code == code {
+			return " (" + purpose.Description + ")"
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,81 @@
+package referencetool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/iso"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Kinds of codes looked up
+const (
+	kindCountry  = "country"
+	kindCurrency = "currency"
+)
+
+// LookupCodesTool maps country and currency names to ISO codes
+type LookupCodesTool struct {
+	logger *slog.Logger
+}
+
+// NewLookupCodesTool returns a new instance of the LookupCodesTool
+func NewLookupCodesTool(logger *slog.Logger) *LookupCodesTool {
+	logger.Info("Registering Lookup_Codes_Tool")
+	return &LookupCodesTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*LookupCodesTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.LookupCodesToolName),
+		mcp.WithDescription(constants.LookupCodesToolDesc),
+		schema.Input[types.LookupCodesParams](),
+		schema.Output[types.CodesOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*LookupCodesTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *LookupCodesTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params types.LookupCodesParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	query, kind := params.Query, params.Kind
+	t.logger.InfoContext(ctx, "Handling LookupCodesTool request", "query", query, "kind", kind)
+
+	var result types.CodesOutput
+
+	if kind != kindCurrency {
+		result.Countries = make([]types.CountryCode, 0)
+		for _, c := range iso.SearchCountries(query) {
+			result.Countries = append(result.Countries, types.CountryCode{Code: c.Code, Name: c.Name})
+		}
+	}
+
+	if kind != kindCountry {
+		result.Currencies = make([]types.CurrencyCode, 0)
+		for _, c := range iso.SearchCurrencies(query) {
+			result.Currencies = append(result.Currencies,
+				types.CurrencyCode{Code: c.Code, Name: c.Name, MinorUnits: c.MinorUnits})
+		}
+	}
+
+	return types.NewResult(result, fmt.Sprintf("%d countries and %d currencies match %q",
+		len(result.Countries), len(result.Currencies), query))
+}
@@ -0,0 +1,74 @@
+package referencetool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/purpose"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// RecommendPurposeCodeTool recommends payout purpose codes from a description
+type RecommendPurposeCodeTool struct {
+	logger *slog.Logger
+}
+
+// NewRecommendPurposeCodeTool returns a new instance of the RecommendPurposeCodeTool
+func NewRecommendPurposeCodeTool(logger *slog.Logger) *RecommendPurposeCodeTool {
+	logger.Info("Registering Recommend_Purpose_Code_Tool")
+	return &RecommendPurposeCodeTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*RecommendPurposeCodeTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.RecommendPurposeCodeToolName),
+		mcp.WithDescription(constants.RecommendPurposeCodeToolDesc),
+		schema.Input[types.PurposeCodeParams](),
+		schema.Output[types.PurposeCodesOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*RecommendPurposeCodeTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *RecommendPurposeCodeTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params types.PurposeCodeParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	description := params.Description
+	t.logger.InfoContext(ctx, "Handling RecommendPurposeCodeTool request", "description", description)
+
+	if description == "" {
+		codes := make([]purpose.Recommendation, 0, len(constants.PayoutPurposeCodes))
+		for _, p := range constants.PayoutPurposeCodes {
+			codes = append(codes, purpose.Recommendation{Code: p.Code, Description: p.Description})
+		}
+
+		return types.NewResult(types.PurposeCodesOutput{PurposeCodes: codes},
+			fmt.Sprintf("%d purpose codes accepted", len(codes)))
+	}
+
+	recommendations := purpose.Recommend(description, constants.RecommendLimit)
+
+	return types.NewResult(types.PurposeCodesOutput{
+		Recommended:     recommendations[0].Code,
+		Recommendations: recommendations,
+		Note: "Pass the recommended code as purpose. Check the alternatives when the description is ambiguous, " +
+			"and ask the user when none fits.",
+	}, fmt.Sprintf("Recommended purpose code: %s (%s)", recommendations[0].Code, recommendations[0].Description))
+}
@@ -0,0 +1,62 @@
+package registertool
+
+import (
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+)
+
+// TestToolCatalogue checks that every tool follows the naming scheme and that
+// the deprecated aliases are registered next to them
+func TestToolCatalogue(t *testing.T) {
+	s := server.NewMCPServer("test", "0")
+
+	registered, err := RegisterTools(s, slog.New(slog.DiscardHandler), Config{Aliases: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	listed := s.ListTools()
+
+	for _, tool := range registered {
+		if !toolname.Catalogued(tool.Name) {
+			t.Errorf("%s is not a <prefix><name>%s name of the catalogue", tool.Name, constants.ToolNameSuffix)
+		}
+
+		if tool.Alias == "" {
+			continue
+		}
+
+		alias, ok := listed[tool.Alias]
+		if !ok {
+			t.Errorf("alias %s of %s is not registered", tool.Alias, tool.Name)
+			continue
+		}
+
+		if alias.Tool.Annotations != listed[tool.Name].Tool.Annotations {
+			t.Errorf("alias %s does not carry the annotations of %s", tool.Alias, tool.Name)
+		}
+	}
+}
+
+func TestCheckDuplicates(t *testing.T) {
+	tools := []server.ServerTool{
+		{Tool: mcp.NewTool("tazapay_fund_payout_tool")},
+		{Tool: mcp.NewTool("fund_payout_tool")},
+	}
+
+	if err := checkDuplicates(tools); err != nil {
+		t.Errorf("checkDuplicates() = %v; want nil", err)
+	}
+
+	tools = append(tools, server.ServerTool{Tool: mcp.NewTool("fund_payout_tool")})
+	if err := checkDuplicates(tools); !errors.Is(err, constants.ErrDuplicateToolName) {
+		t.Errorf("checkDuplicates() = %v; want %v", err, constants.ErrDuplicateToolName)
+	}
+}
@@ -0,0 +1,110 @@
+package registertool
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+)
+
+// Config controls which tools are registered.
+//
+// Allow and Deny hold tool names or glob patterns (see path.Match). A pattern is
+// matched against the tool name, its deprecated alias and "<group>.<name>", so
+// "payout.*" selects every payout tool. When Allow is empty every tool is allowed.
+// Deny always wins.
+type Config struct {
+	Allow []string
+	Deny  []string
+
+	// ReadOnly registers only tools whose metadata marks them read-only
+	ReadOnly bool
+
+	// Aliases also registers every tool under its deprecated legacy name
+	Aliases bool
+}
+
+// ConfigFromViper builds the registration config from the TAZAPAY_READ_ONLY,
+// TAZAPAY_TOOLS_ALLOW, TAZAPAY_TOOLS_DENY and TAZAPAY_TOOL_ALIASES settings (env or
+// YAML config file). Aliases are registered unless TAZAPAY_TOOL_ALIASES is false.
+// The TAZAPAY_TOOL_PREFIX setting is checked too.
+func ConfigFromViper() (Config, error) {
+	cfg := Config{
+		ReadOnly: viper.GetBool(constants.StrTAZAPAYReadOnly),
+		Allow:    patternList(viper.Get(constants.StrTAZAPAYToolsAllow)),
+		Deny:     patternList(viper.Get(constants.StrTAZAPAYToolsDeny)),
+		Aliases:  !viper.IsSet(constants.StrTAZAPAYToolAliases) || viper.GetBool(constants.StrTAZAPAYToolAliases),
+	}
+
+	if err := toolname.ValidatePrefix(toolname.Prefix()); err != nil {
+		return cfg, err
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// Validate reports malformed glob patterns
+func (c Config) Validate() error {
+	for _, pattern := range append(append([]string{}, c.Allow...), c.Deny...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("%w: %q", constants.ErrInvalidToolPattern, pattern)
+		}
+	}
+
+	return nil
+}
+
+// allows reports whether the tool, known by any of names, passes the allowlist and denylist
+func (c Config) allows(group string, names ...string) bool {
+	if len(c.Allow) > 0 && !matchAny(c.Allow, group, names) {
+		return false
+	}
+
+	return !matchAny(c.Deny, group, names)
+}
+
+// matchAny reports whether any pattern matches one of the tool names or its qualified name
+func matchAny(patterns []string, group string, names []string) bool {
+	for _, pattern := range patterns {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+
+			if ok, _ := path.Match(pattern, group+"."+name); ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// patternList accepts a YAML list or a comma separated env value
+func patternList(raw any) []string {
+	var items []string
+
+	switch v := raw.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []string:
+		items = v
+	case []any:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	}
+
+	out := make([]string, 0, len(items))
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,65 @@
+package registertool
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestConfigAllows(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		group string
+		tool  string
+		want  bool
+	}{
+		{"empty config allows everything", Config{}, "payout", "fund_payout_tool", true},
+		{"group glob in allowlist", Config{Allow: []string{"payout.*"}}, "payout", "fund_payout_tool", true},
+		{"tool outside allowlist", Config{Allow: []string{"payout.*"}}, "checkout", "fetch_checkout_tool", false},
+		{"exact name in allowlist", Config{Allow: []string{"fetch_checkout_tool"}}, "checkout", "fetch_checkout_tool", true},
+		{"name glob in denylist", Config{Deny: []string{"*fund*"}}, "payout", "fund_payout_tool", false},
+		{
+			"deny wins over allow",
+			Config{Allow: []string{"payout.*"}, Deny: []string{"fund_payout_tool"}},
+			"payout", "fund_payout_tool", false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.cfg.allows(test.group, test.tool); got != test.want {
+				t.Errorf("allows(%q, %q) = %v; want %v", test.group, test.tool, got, test.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	if err := (Config{Allow: []string{"payout.*"}}).Validate(); err != nil {
+		t.Errorf("Validate() returned error for valid pattern: %v", err)
+	}
+
+	if err := (Config{Deny: []string{"["}}).Validate(); err == nil {
+		t.Error("Validate() returned nil for malformed pattern")
+	}
+}
+
+func TestPatternList(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  any
+		want []string
+	}{
+		{"comma separated env", "payout.*, checkout.* ,", []string{"payout.*", "checkout.*"}},
+		{"yaml list", []any{"payout.*", "fetch_checkout_tool"}, []string{"payout.*", "fetch_checkout_tool"}},
+		{"unset", nil, []string{}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := patternList(test.raw); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("patternList(%v) = %v; want %v", test.raw, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package registertool
+
+import (
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// TestInputSchemas checks that every tool declares an object input schema whose
+// required arguments are all properties
+func TestInputSchemas(t *testing.T) {
+	s := server.NewMCPServer("test", "0")
+	if _, err := RegisterTools(s, slog.New(slog.DiscardHandler), Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tool := range s.ListTools() {
+		t.Run(name, func(t *testing.T) {
+			schema := tool.Tool.InputSchema
+			if schema.Type != "object" {
+				t.Fatalf("input schema type %q; want object", schema.Type)
+			}
+
+			for _, required := range schema.Required {
+				if _, ok := schema.Properties[required]; !ok {
+					t.Errorf("required argument %s is not a property", required)
+				}
+			}
+		})
+	}
+}
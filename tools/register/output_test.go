@@ -0,0 +1,58 @@
+package registertool
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestOutputSchemas checks that every tool declares an output schema and that
+// the schemas match testdata/output_schemas. Run with -update after an
+// intended change of the output shape.
+func TestOutputSchemas(t *testing.T) {
+	s := server.NewMCPServer("test", "0")
+	if _, err := RegisterTools(s, slog.New(slog.DiscardHandler), Config{}); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tool := range s.ListTools() {
+		t.Run(name, func(t *testing.T) {
+			if tool.Tool.OutputSchema.Type == "" {
+				t.Fatal("tool declares no output schema")
+			}
+
+			got, err := json.MarshalIndent(tool.Tool.OutputSchema, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", "output_schemas", name+".golden")
+			if *update {
+				if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+					t.Fatal(err)
+				}
+
+				if err := os.WriteFile(path, append(got, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v; run go test -update to create it", err)
+			}
+
+			if !bytes.Equal(bytes.TrimSpace(want), got) {
+				t.Errorf("output schema changed, run go test -update if intended\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package registertool
+
+import (
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/tools/prompts"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// RegisterPrompts registers all prompts with the server.
+// Prompt arguments are completed by the completion provider passed to the server.
+func RegisterPrompts(s *server.MCPServer, logger *slog.Logger) {
+	list := []types.Prompt{
+		prompts.NewPayBeneficiaryPrompt(logger),
+		prompts.NewCollectPaymentPrompt(logger),
+	}
+
+	for _, prompt := range list {
+		s.AddPrompt(prompt.Definition(), prompt.Handle)
+	}
+}
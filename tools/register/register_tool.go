@@ -2,11 +2,19 @@ package registertool
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/dryrun"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	audittool "github.com/tazapay/tazapay-mcp-server/tools/audit"
+	eventstool "github.com/tazapay/tazapay-mcp-server/tools/events"
+	referencetool "github.com/tazapay/tazapay-mcp-server/tools/reference"
+	statustool "github.com/tazapay/tazapay-mcp-server/tools/status"
 	"github.com/tazapay/tazapay-mcp-server/tools/tazapay/balance"
 	"github.com/tazapay/tazapay-mcp-server/tools/tazapay/beneficiary"
 	"github.com/tazapay/tazapay-mcp-server/tools/tazapay/checkout"
@@ -17,47 +25,188 @@ import (
 	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
-// RegisterTools registers all tools with the server
+// ToolInfo describes a registered tool
+type ToolInfo struct {
+	Group string
+	Name  string
+	// Alias is the deprecated name the tool is also registered under, if any
+	Alias    string
+	Metadata types.ToolMetadata
+}
+
+// toolGroup is a set of tools sharing a package, used for "<group>.*" patterns
+type toolGroup struct {
+	name  string
+	tools []types.Tool
+}
+
+// RegisterTools registers all tools allowed by cfg with the server and returns them.
+// Every tool handler is wrapped by the middlewares, the first one being the outermost.
+// Nothing is registered when a tool is missing from the tool catalogue or two tools
+// or aliases share a name.
 
 // NOTE: All tool constructors (e.g., NewFXTool, NewCreatePayinTool, etc.) must be lightweight.
 // They should NOT perform any blocking or heavy operations (network calls, file I/O, etc.).
 // Only assign struct fields and log. Any heavy setup should be deferred to the handler or background goroutines.
-func RegisterTools(s *server.MCPServer, logger *slog.Logger) {
-	tools := []types.Tool{
-		balance.NewFXTool(logger),
-		balance.NewBalanceTool(logger),
-		payout.NewGetPayoutTool(logger),
-		payout.NewFundPayoutTool(logger),
-		payout.NewCreatePayoutTool(logger),
-		payin.NewGetPayinTool(logger),
-		payin.NewCreatePayinTool(logger),
-		payin.NewUpdatePayinTool(logger),
-		payin.NewCancelPayinTool(logger),
-		//payin.NewConfirmPayinTool(logger),
-		checkout.NewPaymentLinkTool(logger),
-		checkout.NewFetchCheckoutTool(logger),
-		checkout.NewExpireCheckoutTool(logger),
-		beneficiary.NewGetBeneficiaryTool(logger),
-		beneficiary.NewCreateBeneficiaryTool(logger),
-		beneficiary.NewUpdateBeneficiaryTool(logger),
-		paymentattempt.NewGetPaymentAttemptTool(logger),
-		customer.NewCreateCustomerTool(logger),
-		customer.NewFetchCustomerTool(logger),
+func RegisterTools(s *server.MCPServer, logger *slog.Logger, cfg Config,
+	middlewares ...types.ToolMiddleware,
+) ([]ToolInfo, error) {
+	groups := []toolGroup{
+		{"balance", []types.Tool{
+			balance.NewFXTool(logger),
+			balance.NewBalanceTool(logger),
+		}},
+		{"payout", []types.Tool{
+			payout.NewGetPayoutTool(logger),
+			payout.NewFundPayoutTool(logger),
+			payout.NewCreatePayoutTool(logger),
+			payout.NewPayVendorTool(logger),
+			payout.NewBatchPayoutTool(logger),
+		}},
+		{"payin", []types.Tool{
+			payin.NewGetPayinTool(logger),
+			payin.NewCreatePayinTool(logger),
+			payin.NewUpdatePayinTool(logger),
+			payin.NewCancelPayinTool(logger),
+			//payin.NewConfirmPayinTool(logger),
+		}},
+		{"checkout", []types.Tool{
+			checkout.NewPaymentLinkTool(logger),
+			checkout.NewFetchCheckoutTool(logger),
+			checkout.NewExpireCheckoutTool(logger),
+		}},
+		{"beneficiary", []types.Tool{
+			beneficiary.NewGetBeneficiaryTool(logger),
+			beneficiary.NewCreateBeneficiaryTool(logger),
+			beneficiary.NewUpdateBeneficiaryTool(logger),
+			beneficiary.NewDescribeRequirementsTool(logger),
+		}},
+		{"paymentattempt", []types.Tool{
+			paymentattempt.NewGetPaymentAttemptTool(logger),
+		}},
+		{"customer", []types.Tool{
+			customer.NewCreateCustomerTool(logger),
+			customer.NewFetchCustomerTool(logger),
+		}},
+		{"audit", []types.Tool{
+			audittool.NewQueryAuditLogTool(logger),
+		}},
+		{"events", []types.Tool{
+			eventstool.NewWatchEventsTool(logger),
+			eventstool.NewListEventsTool(logger),
+		}},
+		{"status", []types.Tool{
+			statustool.NewWaitForStatusTool(logger),
+		}},
+		{"reference", []types.Tool{
+			referencetool.NewLookupCodesTool(logger),
+			referencetool.NewRecommendPurposeCodeTool(logger),
+		}},
 	}
 
-	for _, tool := range tools {
-		registerTool(s, tool)
+	registered := make([]ToolInfo, 0)
+	names := make([]string, 0)
+	tools := make([]server.ServerTool, 0)
+
+	for _, group := range groups {
+		for _, tool := range group.tools {
+			meta := tool.Metadata()
+			if cfg.ReadOnly && !meta.ReadOnly {
+				continue
+			}
+
+			// The tool metadata is published to clients as MCP tool annotations
+			def := meta.Apply(tool.Definition())
+			if !toolname.Catalogued(def.Name) {
+				return nil, fmt.Errorf("%w: %s", constants.ErrUnknownToolName, def.Name)
+			}
+
+			legacy, hasLegacy := toolname.Legacy(def.Name)
+			if !cfg.allows(group.name, def.Name, legacy) {
+				continue
+			}
+
+			// every mutating tool can be simulated with dry_run
+			if !meta.ReadOnly {
+				def = dryrun.WithParameter(def)
+			}
+
+			handler := createHandler(tool, middlewares)
+			tools = append(tools, server.ServerTool{Tool: def, Handler: handler})
+
+			info := ToolInfo{Group: group.name, Name: def.Name, Metadata: meta}
+			if cfg.Aliases && hasLegacy {
+				tools = append(tools, aliasTool(logger, def, legacy, handler))
+				info.Alias = legacy
+			}
+
+			registered = append(registered, info)
+			names = append(names, def.Name)
+		}
+	}
+
+	if err := checkDuplicates(tools); err != nil {
+		return nil, err
+	}
+
+	s.AddTools(tools...)
+
+	logger.InfoContext(context.Background(), "Registered tools",
+		"read_only", cfg.ReadOnly,
+		"allow", cfg.Allow,
+		"deny", cfg.Deny,
+		"prefix", toolname.Prefix(),
+		"aliases", cfg.Aliases,
+		"count", len(registered),
+		"tools", names,
+	)
+
+	return registered, nil
+}
+
+// aliasTool registers the tool under its deprecated name, logging every call made with it
+func aliasTool(logger *slog.Logger, def mcp.Tool, alias string,
+	handler server.ToolHandlerFunc,
+) server.ServerTool {
+	name := def.Name
+	def.Name = alias
+	def.Description = fmt.Sprintf(constants.DeprecatedToolDesc, name, name) + def.Description
+
+	return server.ServerTool{
+		Tool: def,
+		Handler: func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			logger.WarnContext(ctx, "Deprecated tool name called", "alias", alias, "tool", name)
+			return handler(ctx, req)
+		},
 	}
 }
 
-// registerTool registers a single tool with the server
-func registerTool(s *server.MCPServer, tool types.Tool) {
-	s.AddTool(tool.Definition(), createHandler(tool))
+// checkDuplicates reports the first name shared by two tools or aliases
+func checkDuplicates(tools []server.ServerTool) error {
+	seen := make(map[string]bool, len(tools))
+
+	for _, tool := range tools {
+		if seen[tool.Tool.Name] {
+			return fmt.Errorf("%w: %s", constants.ErrDuplicateToolName, tool.Tool.Name)
+		}
+
+		seen[tool.Tool.Name] = true
+	}
+
+	return nil
 }
 
-// createHandler creates a handler function for a tool
-func createHandler(tool types.Tool) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// createHandler creates a handler function for a tool wrapped by the middlewares
+func createHandler(tool types.Tool,
+	middlewares []types.ToolMiddleware,
+) func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	handler := types.ToolHandlerFunc(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		return tool.Handle(ctx, req)
+	})
+
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		handler = middlewares[i](tool, handler)
 	}
+
+	return handler
 }
@@ -0,0 +1,171 @@
+package statustool
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/wait"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// objectKind describes how to read the status of a kind of Tazapay object
+type objectKind struct {
+	path    string
+	targets []string
+	status  func(data map[string]any) string
+}
+
+// kinds maps ID prefixes to the object kinds that can be waited on
+var kinds = map[string]objectKind{
+	"pot_": {"payout", []string{"succeeded", "failed", "reversed", "rejected", "cancelled"}, field("status")},
+	"pay_": {"payin", []string{"succeeded", "cancelled", "expired"}, field("status")},
+	"chk_": {"checkout", []string{"paid", "expired", "cancelled"}, checkoutStatus},
+}
+
+// field reads a string field of the object
+func field(name string) func(map[string]any) string {
+	return func(data map[string]any) string {
+		status, _ := data[name].(string)
+		return status
+	}
+}
+
+// checkoutStatus is the payment status of an active checkout, otherwise its status
+func checkoutStatus(data map[string]any) string {
+	if status := field("status")(data); status != "" && status != "active" {
+		return status
+	}
+
+	return field("payment_status")(data)
+}
+
+// WaitForStatusTool waits for a Tazapay object to reach a status
+type WaitForStatusTool struct {
+	logger *slog.Logger
+}
+
+// NewWaitForStatusTool returns a new instance of the WaitForStatusTool
+func NewWaitForStatusTool(logger *slog.Logger) *WaitForStatusTool {
+	logger.Info("Registering Wait_For_Status_Tool")
+	return &WaitForStatusTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*WaitForStatusTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.WaitForStatusToolName),
+		mcp.WithDescription(constants.WaitForStatusToolDesc),
+		schema.Input[types.WaitParams](),
+		schema.Output[types.WaitOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*WaitForStatusTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
+// Handle processes the tool request
+func (t *WaitForStatusTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	t.logger.InfoContext(ctx, "Handling WaitForStatusTool request", "args", req.GetArguments())
+
+	var params types.WaitParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	id := params.ID
+
+	kind, ok := kinds[prefix(id)]
+	if !ok {
+		t.logger.ErrorContext(ctx, constants.ErrUnsupportedWaitObject.Error(), "id", id)
+		return nil, constants.ErrUnsupportedWaitObject
+	}
+
+	targets := params.TargetStatuses
+	if len(targets) == 0 {
+		targets = kind.targets
+	}
+
+	fetch := func(ctx context.Context) (string, error) {
+		data, err := utils.FetchObject(ctx, t.logger, kind.path, id)
+		if err != nil {
+			return "", err
+		}
+
+		return kind.status(data), nil
+	}
+
+	res, err := wait.Until(ctx, fetch, wait.Options{
+		Targets:  targets,
+		Timeout:  time.Duration(params.TimeoutSeconds) * time.Second,
+		OnChange: t.progress(ctx, req, id),
+	})
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Failed to wait for status", "id", id, constants.KeyError, err)
+		return nil, err
+	}
+
+	t.logger.InfoContext(ctx, "Finished waiting for status", "id", id, "status", res.Status,
+		"reached", res.Reached, "timed_out", res.TimedOut, "polls", res.Polls)
+
+	summary := fmt.Sprintf("%s is %s after %d polls", id, res.Status, res.Polls)
+	if res.TimedOut {
+		summary += ", timed out waiting for " + strings.Join(targets, " or ")
+	}
+
+	return types.NewResult(types.WaitOutput{ID: id, Targets: targets, Result: res}, summary)
+}
+
+// progress returns the callback sending a progress notification for every
+// status change, when the client asked for progress with a progress token
+func (t *WaitForStatusTool) progress(ctx context.Context, req mcp.CallToolRequest, id string) func(wait.Change) {
+	if req.Params.Meta == nil || req.Params.Meta.ProgressToken == nil {
+		return nil
+	}
+
+	token := req.Params.Meta.ProgressToken
+	changes := 0
+
+	return func(change wait.Change) {
+		s := server.ServerFromContext(ctx)
+		if s == nil {
+			return
+		}
+
+		changes++
+
+		err := s.SendNotificationToClient(ctx, "notifications/progress", map[string]any{
+			"progressToken": token,
+			"progress":      changes,
+			"message":       fmt.Sprintf("%s is %s", id, change.Status),
+		})
+		if err != nil {
+			t.logger.WarnContext(ctx, "Failed to send progress notification", "id", id, constants.KeyError, err)
+		}
+	}
+}
+
+// prefix returns the prefix of a Tazapay object ID, e.g. pot_
+func prefix(id string) string {
+	before, _, found := strings.Cut(id, "_")
+	if !found {
+		return ""
+	}
+
+	return before + "_"
+}
@@ -2,15 +2,16 @@ package balance
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // BalanceTool represents the balance tool
@@ -29,54 +30,41 @@ func NewBalanceTool(logger *slog.Logger) *BalanceTool {
 
 // Definition returns the tool definition
 func (t *BalanceTool) Definition() mcp.Tool {
-
 	return mcp.NewTool(
-		constants.BalanceToolName,
+		toolname.Name(constants.BalanceToolName),
 		mcp.WithDescription(constants.BalanceToolDesc),
-		mcp.WithString(constants.BalanceCurrencyField, mcp.Description(constants.BalanceCurrencyDesc)),
+		schema.Input[types.BalanceRequest](),
+		schema.Output[types.BalanceOutput](),
 	)
 }
 
-// Handle processes tool requests
-func (t *BalanceTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		return nil, errors.New("invalid arguments type")
-	}
-
-	currency, ok := args["currency"].(string)
-	if !ok {
-		return nil, errors.New("currency parameter missing or not a string")
+// Metadata describes the side effects of the tool
+func (*BalanceTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
 	}
+}
 
-	// If empty string, fetch all balances
-	if len(currency) == 0 {
-		currency = ""
-	} else if len(currency) == 3 {
-		// Convert to uppercase if needed
-		currency = strings.ToUpper(currency)
-	} else {
-		return nil, errors.New("currency must be 3 letters (e.g., USD, INR) or empty to fetch all balances")
+// Handle processes tool requests
+func (t *BalanceTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params types.BalanceRequest
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
 	}
 
-	t.logger.Info("handling balance tool request", slog.Any("args", args))
+	t.logger.InfoContext(ctx, "handling balance tool request", slog.String("currency", params.Currency))
 
 	resp, err := utils.HandleGETHttpRequest(ctx, t.logger, constants.BalanceBaseURLProd, constants.GetHTTPMethod)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get balance: %w", err)
 	}
 
-	text, err := utils.GetBalances(resp, currency)
+	output, text, err := utils.GetBalances(resp, params.Currency)
 	if err != nil {
 		return nil, err
 	}
 
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{
-				Type: "text",
-				Text: text,
-			},
-		},
-	}, nil
+	return types.NewResult(output, text)
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
 	fmath "github.com/tazapay/tazapay-mcp-server/pkg/utils/math"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
@@ -31,23 +33,29 @@ func NewFXTool(logger *slog.Logger) *FXTool {
 // Definition registers this tool with the MCP platform
 func (*FXTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		constants.FXToolName,
+		toolname.Name(constants.FXToolName),
 		mcp.WithDescription(constants.FXToolDescription),
-		mcp.WithString(constants.FXFromField, mcp.Required(), mcp.Description(constants.FXFromDescription)),
-		mcp.WithString(constants.FXToField, mcp.Required(), mcp.Description(constants.FXToDescription)),
-		mcp.WithNumber(constants.FXAmountField, mcp.Required(), mcp.Description(constants.FXAmountDescription)),
+		schema.Input[types.FXParams](),
+		schema.Output[types.FXOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*FXTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 // Handle processes the tool request and returns a result
 func (t *FXTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	t.logger.InfoContext(ctx, "Handling FXTool request", slog.Any("params", req.Params.Arguments))
 
-	args := req.Params.Arguments.(map[string]any)
-
-	// validate and extract arguments
-	params, err := validateAndExtractFXArgs(t, ctx, args)
-	if err != nil {
+	// validate and decode arguments
+	var params types.FXParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
 		t.logger.Error("Argument validation failed", slog.String("error", err.Error()))
 		return nil, err
 	}
@@ -109,32 +117,11 @@ func (t *FXTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.Call
 	t.logger.InfoContext(ctx, "FXTool result ready", slog.String("result", result))
 
 	// return result
-	return &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{
-				Type: "text",
-				Text: result,
-			},
-		},
-	}, nil
-}
-
-// validateAndExtractFXArgs validates request arguments and returns structured parameters
-func validateAndExtractFXArgs(t *FXTool, ctx context.Context, args map[string]any) (types.FXParams, error) {
-	var p types.FXParams
-	var ok bool
-
-	if p.Amount, ok = args[constants.FXAmountField].(float64); !ok {
-		return p, utils.WrapFieldTypeError(ctx, t.logger, constants.FXAmountField)
-	}
-
-	if p.From, ok = args[constants.FXFromField].(string); !ok {
-		return p, utils.WrapFieldTypeError(ctx, t.logger, constants.FXFromField)
-	}
-
-	if p.To, ok = args[constants.FXToField].(string); !ok {
-		return p, utils.WrapFieldTypeError(ctx, t.logger, constants.FXToField)
-	}
-
-	return p, nil
+	return types.NewResult(types.FXOutput{
+		From:            fromCurrency,
+		To:              toCurrency,
+		Amount:          params.Amount,
+		ExchangeRate:    formattedExRate,
+		ConvertedAmount: formattedConvertedAmount,
+	}, result)
 }
@@ -0,0 +1,83 @@
+package beneficiary
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/corridor"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// DescribeRequirementsTool describes the bank details required by a corridor
+type DescribeRequirementsTool struct {
+	logger *slog.Logger
+}
+
+// NewDescribeRequirementsTool returns a new instance of the DescribeRequirementsTool
+func NewDescribeRequirementsTool(logger *slog.Logger) *DescribeRequirementsTool {
+	logger.Info("Registering Describe_Requirements_Tool")
+	return &DescribeRequirementsTool{logger: logger}
+}
+
+// Definition returns the tool definition
+func (*DescribeRequirementsTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.DescribeRequirementsToolName),
+		mcp.WithDescription(constants.DescribeRequirementsToolDesc),
+		schema.Input[types.RequirementsParams](),
+		schema.Output[types.RequirementsOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*DescribeRequirementsTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+	}
+}
+
+// Handle processes the tool request
+func (t *DescribeRequirementsTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	var params types.RequirementsParams
+	if err := schema.Decode(req.GetArguments(), &params); err != nil {
+		return nil, err
+	}
+
+	country, currency, transfer := params.Country, params.Currency, params.TransferType
+	t.logger.InfoContext(ctx, "Handling DescribeRequirementsTool request",
+		"country", country, "currency", currency, "transfer_type", transfer)
+
+	transfers := []string{corridor.TransferLocal, corridor.TransferSwift}
+	if transfer != "" {
+		transfers = []string{transfer}
+	}
+
+	requirements := make([]corridor.Rule, 0, len(transfers))
+
+	for _, transfer := range transfers {
+		if rule, ok := corridor.Lookup(country, currency, transfer); ok {
+			// report the corridor asked about, not the wildcard the rule is keyed by
+			rule.Country, rule.Currency = country, currency
+			requirements = append(requirements, rule)
+		}
+	}
+
+	summary := fmt.Sprintf("%d transfer types available for %s %s", len(requirements), country, currency)
+
+	return types.NewResult(types.RequirementsOutput{
+		Country:      country,
+		Currency:     currency,
+		Requirements: requirements,
+		Notes: "Fields are inside beneficiary_details.destination_details.bank, along with country and currency. " +
+			"Codes given as bank_codes.x may also be passed directly on bank. A field listing several paths " +
+			"is satisfied by any one of them. Formats are regular expressions matched after removing spaces " +
+			"and upper-casing the value.",
+	}, summary)
+}
@@ -2,14 +2,15 @@ package beneficiary
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
 	"github.com/tazapay/tazapay-mcp-server/types"
 )
@@ -27,14 +28,24 @@ func NewGetBeneficiaryTool(logger *slog.Logger) *GetBeneficiaryTool {
 
 func (t *GetBeneficiaryTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		constants.GetBeneficiaryToolName,
+		toolname.Name(constants.GetBeneficiaryToolName),
 		mcp.WithDescription(constants.GetBeneficiaryToolDesc),
-		mcp.WithString(constants.GetBeneficiaryIDField, mcp.Required(), mcp.Description(constants.GetBeneficiaryIDDesc)),
+		schema.Input[types.GetBeneficiaryParams](),
+		schema.Output[types.BeneficiaryOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*GetBeneficiaryTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 func (t *GetBeneficiaryTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling GetBeneficiaryTool request", "args", args)
 
 	defer func() {
@@ -43,23 +54,14 @@ func (t *GetBeneficiaryTool) Handle(ctx context.Context, req mcp.CallToolRequest
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		err := errors.New("missing or invalid beneficiary id")
+	var params types.GetBeneficiaryParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	t.logger.Debug("Fetching beneficiary", "id", id)
-
-	// Validate beneficiary id prefix using ValidatePrefixId
-	if err := utils.ValidatePrefixID("bnf_", id); err != nil {
-		t.logger.ErrorContext(ctx, err.Error())
-		return nil, err
-	}
-
-	url := fmt.Sprintf("%s/beneficiary/%s", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/beneficiary/%s", constants.ProdBaseURL, params.ID)
 
 	t.logger.Debug("URL", "url", url)
 
@@ -75,28 +77,15 @@ func (t *GetBeneficiaryTool) Handle(ctx context.Context, req mcp.CallToolRequest
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	// Use the BeneficiaryDetails struct from types and MapToStruct utility
-	var beneficiary types.Beneficiary
+	recent.Add(ctx, recent.KindBeneficiary, params.ID)
 
-	err = utils.MapToStruct(data, &beneficiary)
+	output := types.NewBeneficiaryOutput(data)
+
+	result, err := types.NewResult(output, fmt.Sprintf("Beneficiary %s: %s", output.ID, output.Name))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "Failed to map data to BeneficiaryDetails struct", "error", err)
 		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			// Marshal beneficiary struct to JSON for human-readable output
-			func() mcp.TextContent {
-				jsonBytes, err := json.MarshalIndent(beneficiary, "", "  ")
-				if err != nil {
-					return mcp.TextContent{Type: "text", Text: fmt.Sprintf("Beneficiary: %+v", beneficiary)}
-				}
-
-				return mcp.TextContent{Type: "text", Text: string(jsonBytes)}
-			}(),
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled GetBeneficiaryTool request", "result", result)
 
 	return result, nil
@@ -2,14 +2,16 @@ package checkout
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // ExpireCheckoutTool expires a checkout session by ID
@@ -25,14 +27,24 @@ func NewExpireCheckoutTool(logger *slog.Logger) *ExpireCheckoutTool {
 
 func (t *ExpireCheckoutTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		"expire_checkout_tool",
+		toolname.Name(constants.ExpireCheckoutToolName),
 		mcp.WithDescription("Expire a checkout session by ID on Tazapay"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the checkout session to expire")),
+		schema.Input[types.CheckoutParams](),
+		schema.Output[types.CheckoutOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*ExpireCheckoutTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		Idempotent:  true,
+		OpenWorld:   true,
+	}
+}
+
 func (t *ExpireCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling ExpireCheckoutTool request", "args", args)
 
 	defer func() {
@@ -41,15 +53,14 @@ func (t *ExpireCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		err := errors.New("missing or invalid checkout session id")
+	var params types.CheckoutParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/checkout/%s/expire", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/checkout/%s/expire", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandlePOSTHttpRequest(ctx, t.logger, url, nil, constants.PostHTTPMethod)
 	if err != nil {
@@ -63,14 +74,13 @@ func (t *ExpireCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	status, _ := data["status"].(string)
-	resultText := "Checkout session expired. Status: " + status
+	output := types.NewCheckoutOutput(data)
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: resultText},
-		},
+	result, err := types.NewResult(output, "Checkout session expired. Status: "+output.Status)
+	if err != nil {
+		return nil, err
 	}
+
 	t.logger.InfoContext(ctx, "Successfully handled ExpireCheckoutTool request", "result", result)
 
 	return result, nil
@@ -2,16 +2,16 @@ package checkout
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // FetchCheckoutTool fetches the details of a checkout session by ID
@@ -27,14 +27,24 @@ func NewFetchCheckoutTool(logger *slog.Logger) *FetchCheckoutTool {
 
 func (t *FetchCheckoutTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		"fetch_checkout_tool",
+		toolname.Name(constants.FetchCheckoutToolName),
 		mcp.WithDescription("Fetch the details of a checkout session by ID from Tazapay"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the checkout session")),
+		schema.Input[types.CheckoutParams](),
+		schema.Output[types.CheckoutOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*FetchCheckoutTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 func (t *FetchCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling FetchCheckoutTool request", "args", args)
 
 	defer func() {
@@ -43,15 +53,14 @@ func (t *FetchCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		err := errors.New("missing or invalid checkout session id")
+	var params types.CheckoutParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/checkout/%s", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/checkout/%s", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandleGETHttpRequest(ctx, t.logger, url, constants.GetHTTPMethod)
 	if err != nil {
@@ -65,23 +74,14 @@ func (t *FetchCheckoutTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	// Convert amount from cents to decimal value if present
-	if amount, exists := data["amount"].(float64); exists {
-		data["amount"] = money.Int64ToDecimal2(int64(amount))
-		data["amount_original"] = amount
-	}
+	output := types.NewCheckoutOutput(data)
 
-	fullDataJSON, err := json.MarshalIndent(data, "", "  ")
+	result, err := types.NewResult(output, fmt.Sprintf("Checkout session %s is %s: %s %.2f",
+		output.ID, output.Status, output.Currency, output.Amount))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "failed to marshal full data for output", "error", err.Error())
-		fullDataJSON = []byte("<failed to marshal data>")
+		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: "Checkout session data: " + string(fullDataJSON)},
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled FetchCheckoutTool request", "result", result)
 
 	return result, nil
@@ -2,14 +2,15 @@ package customer
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
 	"github.com/tazapay/tazapay-mcp-server/types"
 )
@@ -27,14 +28,24 @@ func NewFetchCustomerTool(logger *slog.Logger) *FetchCustomerTool {
 
 func (t *FetchCustomerTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		"tazapay_fetch_customer_tool",
+		toolname.Name(constants.FetchCustomerToolName),
 		mcp.WithDescription("Fetch Customer Details by ID from Tazapay. ID must start with cus_."),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the customer (must start with cus_).")),
+		schema.Input[types.GetCustomerParams](),
+		schema.Output[types.CustomerOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*FetchCustomerTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 func (t *FetchCustomerTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling FetchCustomerTool request", "args", args)
 
 	defer func() {
@@ -43,15 +54,14 @@ func (t *FetchCustomerTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" || utils.ValidatePrefixID("cus_", id) != nil {
-		err := errors.New("missing or invalid customer id, should be starting with cus_")
+	var params types.GetCustomerParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/customer/%s", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/customer/%s", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandleGETHttpRequest(ctx, t.logger, url, "GET")
 	if err != nil {
@@ -65,26 +75,15 @@ func (t *FetchCustomerTool) Handle(ctx context.Context, req mcp.CallToolRequest)
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	var customer types.Customer
+	recent.Add(ctx, recent.KindCustomer, params.ID)
 
-	err = utils.MapToStruct(data, &customer)
+	customer := types.NewCustomerOutput(data)
+
+	result, err := types.NewResult(customer, fmt.Sprintf("Customer %s: %s", customer.ID, customer.Name))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "Failed to map data to Customer struct", "error", err)
 		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			func() mcp.TextContent {
-				jsonBytes, err := json.MarshalIndent(customer, "", "  ")
-				if err != nil {
-					return mcp.TextContent{Type: "text", Text: fmt.Sprintf("Customer: %+v", customer)}
-				}
-
-				return mcp.TextContent{Type: "text", Text: string(jsonBytes)}
-			}(),
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled FetchCustomerTool request", "result", result)
 
 	return result, nil
@@ -9,7 +9,10 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // CancelPayinTool represents the cancel payin tool
@@ -26,20 +29,24 @@ func NewCancelPayinTool(logger *slog.Logger) *CancelPayinTool {
 func (t *CancelPayinTool) Definition() mcp.Tool {
 	
 	return mcp.NewTool(
-		constants.CancelPayinToolName,
+		toolname.Name(constants.CancelPayinToolName),
 		mcp.WithDescription(constants.CancelPayinToolDesc),
-		mcp.WithString(constants.GetPayinIDField, mcp.Required(), mcp.Description(constants.CancelPayinIDDesc)),
+		schema.Input[types.CancelPayinParams](),
+		schema.Output[types.PayinOutput](),
 	)
 }
 
-func (t *CancelPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		err := errors.New("invalid arguments type: expected map[string]any")
-		t.logger.ErrorContext(ctx, err.Error())
-
-		return nil, err
+// Metadata describes the side effects of the tool
+func (*CancelPayinTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		Idempotent:  true,
+		OpenWorld:   true,
 	}
+}
+
+func (t *CancelPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
 
 	t.logger.InfoContext(ctx, "Handling CancelPayinTool request", "args", args)
 
@@ -49,15 +56,14 @@ func (t *CancelPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (
 		}
 	}()
 
-	id, ok := args[constants.GetPayinIDField].(string)
-	if !ok || id == "" {
-		err := constants.ErrInvalidIDFormat
+	var params types.CancelPayinParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/payin/%s/cancel", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/payin/%s/cancel", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandlePOSTHttpRequest(ctx, t.logger, url, nil, constants.PostHTTPMethod)
 	if err != nil {
@@ -78,11 +84,11 @@ func (t *CancelPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (
 	}
 	resultText := "Payin cancelled. Status: " + statusVal.(string)
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: resultText},
-		},
+	result, err := types.NewResult(types.NewPayinOutput(data), resultText)
+	if err != nil {
+		return nil, err
 	}
+
 	t.logger.InfoContext(ctx, "Successfully handled CancelPayinTool request", "result", result)
 
 	return result, nil
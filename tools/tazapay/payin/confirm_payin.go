@@ -9,7 +9,10 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // ConfirmPayinTool represents the confirm payin tool
@@ -28,38 +31,24 @@ func (t *ConfirmPayinTool) Definition() mcp.Tool {
 	t.logger.InfoContext(context.Background(), "Defining ConfirmPayinTool")
 
 	return mcp.NewTool(
-		"confirm_payin_tool",
+		toolname.Name(constants.ConfirmPayinToolName),
 		mcp.WithDescription("Confirm a payin and create a payment attempt on Tazapay"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the already created payin")),
-		mcp.WithObject("customer_details", mcp.Required(),
-			mcp.Properties(map[string]any{
-				"customer": map[string]any{"type": "string"},
-			}),
-		),
-		mcp.WithString("success_url", mcp.Required()),
-		mcp.WithString("cancel_url", mcp.Required()),
-		mcp.WithObject("shipping_details"),
-		mcp.WithObject("billing_details"),
-		mcp.WithObject("transaction_documents"),
-		mcp.WithObject("metadata"),
-		mcp.WithString("reference_id"),
-		mcp.WithString("statement_descriptor"),
-		mcp.WithObject("payment_method_details", mcp.Required()),
-		mcp.WithString("session_id", mcp.Required()),
+		schema.Input[types.ConfirmPayinParams](),
+		schema.Output[types.PayinOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*ConfirmPayinTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		OpenWorld: true,
+	}
+}
+
 func (t *ConfirmPayinTool) Handle(ctx context.Context,
 	req mcp.CallToolRequest,
 ) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		err := errors.New("invalid arguments type for ConfirmPayinTool")
-		t.logger.ErrorContext(ctx, err.Error())
-
-		return nil, err
-	}
-
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling ConfirmPayinTool request", "args", args)
 
 	defer func() {
@@ -68,17 +57,15 @@ func (t *ConfirmPayinTool) Handle(ctx context.Context,
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		err := errors.New("missing or invalid payin id")
+	var params types.ConfirmPayinParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	// Remove id from args for payload
-	delete(args, "id")
-	payload := args
+	id := params.ID
+	payload := params.ConfirmPayinRequest
 
 	url := fmt.Sprintf("%s/payin/%s/confirm", constants.ProdBaseURL, id)
 
@@ -107,11 +94,11 @@ func (t *ConfirmPayinTool) Handle(ctx context.Context,
 	}
 	resultText := fmt.Sprintf("Payin confirmed. Status: %s. %s", status, statusDesc)
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: resultText},
-		},
+	result, err := types.NewResult(types.NewPayinOutput(data), resultText)
+	if err != nil {
+		return nil, err
 	}
+
 	t.logger.InfoContext(ctx, "Successfully handled ConfirmPayinTool request", "result", result)
 
 	return result, nil
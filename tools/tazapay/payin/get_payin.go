@@ -2,16 +2,16 @@ package payin
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // GetPayinTool fetches a payin by ID
@@ -27,14 +27,24 @@ func NewGetPayinTool(logger *slog.Logger) *GetPayinTool {
 
 func (t *GetPayinTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		constants.GetPayinToolName,
+		toolname.Name(constants.GetPayinToolName),
 		mcp.WithDescription(constants.GetPayinToolDesc),
-		mcp.WithString(constants.GetPayinIDField, mcp.Required(), mcp.Description(constants.GetPayinIDDesc)),
+		schema.Input[types.GetPayinParams](),
+		schema.Output[types.PayinOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*GetPayinTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 func (t *GetPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling GetPayinTool request", "args", args)
 
 	defer func() {
@@ -43,15 +53,14 @@ func (t *GetPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mc
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" || utils.ValidatePrefixID("pay_", id) != nil {
-		err := errors.New("missing or invalid payin id, should be starting with pay_")
+	var params types.GetPayinParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/payin/%s", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/payin/%s", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandleGETHttpRequest(ctx, t.logger, url, constants.GetHTTPMethod)
 	if err != nil {
@@ -65,24 +74,14 @@ func (t *GetPayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mc
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	// Convert amount from cents to decimal value if present
-	if amount, exists := data["amount"].(float64); exists {
-		data["amount"] = money.Int64ToDecimal2(int64(amount))
-		data["amount_original"] = amount
-	}
+	output := types.NewPayinOutput(data)
 
-	// Marshal the data to pretty JSON
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	result, err := types.NewResult(output, fmt.Sprintf("Payin %s is %s: %s %.2f",
+		output.ID, output.Status, output.Currency, output.Amount))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "Failed to marshal payin data to JSON", "error", err)
 		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: string(jsonBytes)},
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled GetPayinTool request", "result", result)
 
 	return result, nil
@@ -8,7 +8,10 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // UpdatePayinTool represents the update payin tool
@@ -25,35 +28,24 @@ func NewUpdatePayinTool(logger *slog.Logger) *UpdatePayinTool {
 func (t *UpdatePayinTool) Definition() mcp.Tool {
 	
 	return mcp.NewTool(
-		"update_payin_tool",
+		toolname.Name(constants.UpdatePayinToolName),
 		mcp.WithDescription("Update a payin on Tazapay without confirming it"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the already created payin")),
-		mcp.WithObject("customer_details",
-			mcp.Properties(map[string]any{
-				"customer": map[string]any{"type": "string"},
-			}),
-		),
-		mcp.WithString("success_url", mcp.Required()),
-		mcp.WithString("cancel_url", mcp.Required()),
-		mcp.WithObject("shipping_details"),
-		mcp.WithObject("billing_details"),
-		mcp.WithObject("transaction_documents"),
-		mcp.WithObject("metadata"),
-		mcp.WithString("reference_id"),
-		mcp.WithString("statement_descriptor"),
-		mcp.WithObject("payment_method_details", mcp.Required()),
+		schema.Input[types.UpdatePayinParams](),
+		schema.Output[types.PayinOutput](),
 	)
 }
 
-func (t *UpdatePayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		err := constants.ErrInvalidType
-		t.logger.ErrorContext(ctx, err.Error())
-
-		return nil, err
+// Metadata describes the side effects of the tool
+func (*UpdatePayinTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		Idempotent:  true,
+		OpenWorld:   true,
 	}
+}
 
+func (t *UpdatePayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling UpdatePayinTool request", "args", args)
 
 	defer func() {
@@ -62,17 +54,15 @@ func (t *UpdatePayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" {
-		err := constants.ErrInvalidIDFormat
+	var params types.UpdatePayinParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	// Remove id from args for payload
-	delete(args, "id") // no error to check for delete in Go, safe to ignore
-	payload := args
+	id := params.ID
+	payload := params.UpdatePayinRequest
 
 	url := fmt.Sprintf("%s/payin/%s", constants.ProdBaseURL, id)
 
@@ -96,11 +86,11 @@ func (t *UpdatePayinTool) Handle(ctx context.Context, req mcp.CallToolRequest) (
 
 	resultText := "Payin updated. Status: " + status
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: resultText},
-		},
+	result, err := types.NewResult(types.NewPayinOutput(data), resultText)
+	if err != nil {
+		return nil, err
 	}
+
 	t.logger.InfoContext(ctx, "Successfully handled UpdatePayinTool request", "result", result)
 
 	return result, nil
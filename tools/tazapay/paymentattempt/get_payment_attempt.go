@@ -2,16 +2,16 @@ package paymentattempt
 
 import (
 	"context"
-	"encoding/json"
-	"errors"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // GetPaymentAttemptTool fetches a payment attempt by ID
@@ -27,14 +27,24 @@ func NewGetPaymentAttemptTool(logger *slog.Logger) *GetPaymentAttemptTool {
 
 func (t *GetPaymentAttemptTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		"get_payment_attempt_tool",
+		toolname.Name(constants.GetPaymentAttemptToolName),
 		mcp.WithDescription("Fetch a payment attempt by ID from Tazapay"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the payment attempt")),
+		schema.Input[types.GetPaymentAttemptParams](),
+		schema.Output[types.PaymentAttemptOutput](),
 	)
 }
 
+// Metadata describes the side effects of the tool
+func (*GetPaymentAttemptTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
+	}
+}
+
 func (t *GetPaymentAttemptTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, _ := req.Params.Arguments.(map[string]any)
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling GetPaymentAttemptTool request", "args", args)
 
 	defer func() {
@@ -43,15 +53,14 @@ func (t *GetPaymentAttemptTool) Handle(ctx context.Context, req mcp.CallToolRequ
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" || utils.ValidatePrefixID("pat_", id) != nil {
-		err := errors.New("missing or invalid payment attempt id, should be starting with pat_")
+	var params types.GetPaymentAttemptParams
+	if err := schema.Decode(args, &params); err != nil {
 		t.logger.ErrorContext(ctx, err.Error())
 
 		return nil, err
 	}
 
-	url := fmt.Sprintf("%s/payment_attempt/%s", constants.ProdBaseURL, id)
+	url := fmt.Sprintf("%s/payment_attempt/%s", constants.ProdBaseURL, params.ID)
 
 	resp, err := utils.HandlePOSTHttpRequest(ctx, t.logger, url, nil, constants.GetHTTPMethod)
 	if err != nil {
@@ -65,24 +74,13 @@ func (t *GetPaymentAttemptTool) Handle(ctx context.Context, req mcp.CallToolRequ
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	// Convert amount from cents to decimal value if present
-	if amount, exists := data["amount"].(float64); exists {
-		data["amount"] = money.Int64ToDecimal2(int64(amount))
-		data["amount_original"] = amount
-	}
+	output := types.NewPaymentAttemptOutput(data)
 
-	// Format the data as pretty JSON
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	result, err := types.NewResult(output, fmt.Sprintf("Payment attempt %s is %s", output.ID, output.Status))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "Failed to marshal payment attempt data", "error", err)
 		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: string(jsonBytes)},
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled GetPaymentAttemptTool request", "result", result)
 
 	return result, nil
@@ -0,0 +1,228 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/batch"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// maxReportedRowErrors bounds the validation errors listed for a batch
+const maxReportedRowErrors = 20
+
+// BatchPayoutTool creates the payouts of a CSV or JSON document
+type BatchPayoutTool struct {
+	logger *slog.Logger
+	create *CreatePayoutTool
+}
+
+// NewBatchPayoutTool returns a new instance of the BatchPayoutTool
+func NewBatchPayoutTool(logger *slog.Logger) *BatchPayoutTool {
+	logger.InfoContext(context.Background(), "Registering Batch_Payout_Tool")
+	return &BatchPayoutTool{logger: logger, create: &CreatePayoutTool{logger: logger}}
+}
+
+// Definition returns the tool definition
+func (*BatchPayoutTool) Definition() mcp.Tool {
+	return mcp.NewTool(
+		toolname.Name(constants.BatchPayoutToolName),
+		mcp.WithDescription(constants.BatchPayoutToolDesc),
+		schema.Input[types.BatchPayoutParams](),
+		schema.Output[types.BatchPayoutOutput](),
+	)
+}
+
+// Metadata describes the side effects of the tool
+func (*BatchPayoutTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		OpenWorld:   true,
+		MovesFunds:  true,
+	}
+}
+
+// BatchPayouts returns the arguments of every payout of the batch, for policies
+func (t *BatchPayoutTool) BatchPayouts(ctx context.Context, args map[string]any) ([]map[string]any, error) {
+	_, rows, err := t.load(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	payouts := make([]map[string]any, 0, len(rows))
+	for _, row := range rows {
+		payouts = append(payouts, row.Args)
+	}
+
+	return payouts, nil
+}
+
+// Summarize describes the batch for confirmation, with totals per currency
+func (t *BatchPayoutTool) Summarize(ctx context.Context, args map[string]any) (types.PaymentSummary, error) {
+	document, rows, err := t.load(ctx, args)
+	if err != nil {
+		return types.PaymentSummary{}, err
+	}
+
+	return summarizeBatch(document, rows), nil
+}
+
+// Handle processes the tool request
+func (t *BatchPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	t.logger.InfoContext(ctx, "Handling BatchPayoutTool request",
+		"document_uri", args[constants.BatchDocumentURIField], "format", args[constants.BatchFormatField])
+
+	params := types.BatchPayoutParams{Concurrency: constants.BatchDefaultConcurrency}
+	if err := schema.Decode(args, &params); err != nil {
+		return nil, err
+	}
+
+	document, rows, err := t.load(ctx, args)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Invalid batch", constants.KeyError, err)
+		return nil, err
+	}
+
+	key := batch.Key(document)
+
+	// every row is validated, nothing is sent
+	if utils.IsDryRun(ctx) {
+		return types.NotExecuted(mcp.NewToolResultText(
+			"Dry run, nothing was sent to Tazapay. All rows are valid.\n\n" + summarizeBatch(document, rows).String())), nil
+	}
+
+	// the totals are checked before any row is sent, so a batch blocked by the
+	// balance creates no payout at all
+	warning, blocked := batchPreflight(ctx, t.logger, rows)
+	if blocked != nil {
+		return blocked, nil
+	}
+
+	results := batch.Run(ctx, rows, params.Concurrency, func(ctx context.Context, row batch.Row) (string, error) {
+		params, err := t.create.decodePayout(ctx, row.Args)
+		if err != nil {
+			return "", err
+		}
+
+		return t.create.postPayout(utils.WithIdempotencyKey(ctx, batch.RowKey(key, row)), newPayoutRequest(&params))
+	})
+
+	ids := make([]string, len(results))
+	created := 0
+
+	for i, r := range results {
+		ids[i] = r.PayoutID
+		if r.Status == batch.StatusCreated {
+			created++
+		}
+	}
+
+	uri := batch.SaveResults(key, results)
+
+	t.logger.InfoContext(ctx, "Executed batch payout", "batch_id", key, "rows", len(rows),
+		"created", created, "failed", len(rows)-created)
+
+	output := types.BatchPayoutOutput{
+		BatchID:    key,
+		Created:    created,
+		Failed:     len(rows) - created,
+		Totals:     batch.Totals(rows),
+		ResultsURI: uri,
+		Results:    results,
+		Warning:    warning,
+	}
+
+	result, err := types.NewResult(output, withWarning(fmt.Sprintf("Batch %s: %d payouts created, %d failed. Results: %s",
+		key, output.Created, output.Failed, uri), warning))
+	if err != nil {
+		return nil, err
+	}
+
+	result.Content = append(result.Content,
+		mcp.NewResourceLink(uri, "Batch payout results", "Per-row results of batch "+key, "text/csv"))
+	result.IsError = created == 0
+
+	return types.WithBatchPayoutIDs(result, ids), nil
+}
+
+// load reads the batch document from the arguments and validates every row
+func (t *BatchPayoutTool) load(ctx context.Context, args map[string]any) ([]byte, []batch.Row, error) {
+	var params types.BatchPayoutParams
+	if err := schema.Decode(args, &params); err != nil {
+		return nil, nil, err
+	}
+
+	inline, uri := params.Document, params.DocumentURI
+
+	var document []byte
+
+	switch {
+	case inline != "" && uri != "":
+		return nil, nil, fmt.Errorf("%w: pass either %s or %s, not both", constants.ErrInvalidBatch,
+			constants.BatchDocumentField, constants.BatchDocumentURIField)
+	case inline != "":
+		document = []byte(inline)
+	case uri != "":
+		var err error
+		if document, err = batch.ReadDocument(uri); err != nil {
+			return nil, nil, err
+		}
+	default:
+		return nil, nil, utils.WrapMissingFieldsError([]string{constants.BatchDocumentField})
+	}
+
+	rows, err := batch.Parse(document, params.Format)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var problems []string
+
+	for _, row := range rows {
+		if err := t.validateRow(ctx, row); err != nil {
+			problems = append(problems, fmt.Sprintf("row %d: %v", row.Number, err))
+		}
+	}
+
+	if len(problems) > 0 {
+		if len(problems) > maxReportedRowErrors {
+			problems = append(problems[:maxReportedRowErrors],
+				fmt.Sprintf("and %d more rows", len(problems)-maxReportedRowErrors))
+		}
+
+		return nil, nil, fmt.Errorf("%w, nothing was executed:\n%s", constants.ErrInvalidBatch,
+			strings.Join(problems, "\n"))
+	}
+
+	return document, rows, nil
+}
+
+// validateRow checks a row with the create payout validation
+func (t *BatchPayoutTool) validateRow(ctx context.Context, row batch.Row) error {
+	_, err := t.create.decodePayout(ctx, row.Args)
+	return err
+}
+
+// summarizeBatch describes the batch with one line per currency
+func summarizeBatch(document []byte, rows []batch.Row) types.PaymentSummary {
+	totals := batch.Totals(rows)
+
+	breakdown := make([]string, 0, len(totals))
+	for _, total := range totals {
+		breakdown = append(breakdown, total.String())
+	}
+
+	return types.PaymentSummary{
+		Action:    fmt.Sprintf("Create %d payouts from batch %s", len(rows), batch.Key(document)),
+		Breakdown: breakdown,
+	}
+}
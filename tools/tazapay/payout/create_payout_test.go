@@ -0,0 +1,34 @@
+package payout
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestDecodePayoutAmount(t *testing.T) {
+	tool := NewCreatePayoutTool(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	tests := []struct {
+		name   string
+		amount float64
+		want   error
+	}{
+		{"positive", 100, nil},
+		{"zero", 0, constants.ErrInvalidFieldValue},
+		{"negative", -5, constants.ErrInvalidFieldValue},
+	}
+
+	for _, test := range tests {
+		args := vendorPayment("SGD", "PYR007")
+		args["amount"] = test.amount
+
+		if _, err := tool.decodePayout(context.Background(), args); !errors.Is(err, test.want) {
+			t.Errorf("%s: decodePayout() = %v; want %v", test.name, err, test.want)
+		}
+	}
+}
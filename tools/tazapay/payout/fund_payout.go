@@ -8,8 +8,10 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // FundPayoutTool funds a payout in requires_funding state
@@ -25,19 +27,24 @@ func NewFundPayoutTool(logger *slog.Logger) *FundPayoutTool {
 
 func (*FundPayoutTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		"fund_payout_tool",
+		toolname.Name(constants.FundPayoutToolName),
 		mcp.WithDescription("Fund a payout in requires_funding state by ID on Tazapay"),
-		mcp.WithString("id", mcp.Required(), mcp.Description("ID of the payout in requires_funding state")),
+		schema.Input[types.FundPayoutParams](),
+		schema.Output[types.PayoutOutput](),
 	)
 }
 
-func (t *FundPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		t.logger.ErrorContext(ctx, "Invalid arguments type for FundPayoutTool")
-		return nil, fmt.Errorf("%w", constants.ErrInvalidArgumentsType)
+// Metadata describes the side effects of the tool
+func (*FundPayoutTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		OpenWorld:   true,
+		MovesFunds:  true,
 	}
+}
 
+func (t *FundPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
 	t.logger.InfoContext(ctx, "Handling FundPayoutTool request", "args", args)
 
 	defer func() {
@@ -46,12 +53,59 @@ func (t *FundPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" || utils.ValidatePrefixID("pot_", id) != nil {
-		t.logger.ErrorContext(ctx, constants.ErrMissingOrInvalidPayoutID.Error())
-		return nil, constants.ErrMissingOrInvalidPayoutID
+	var params types.FundPayoutParams
+	if err := schema.Decode(args, &params); err != nil {
+		t.logger.ErrorContext(ctx, err.Error())
+
+		return nil, err
 	}
 
+	id := params.ID
+
+	warning, blocked := t.preflight(ctx, id)
+	if blocked != nil {
+		return blocked, nil
+	}
+
+	data, err := t.fund(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	output := types.NewPayoutOutput(data)
+	output.Warning = warning
+
+	summary := "Payout funded. Status: " + output.Status
+	if output.Currency != "" {
+		summary += fmt.Sprintf("\nAmount: %s %.2f", output.Currency, output.Amount)
+	}
+
+	result, err := types.NewResult(output, withWarning(summary, warning))
+	if err != nil {
+		return nil, err
+	}
+
+	t.logger.InfoContext(ctx, "Successfully handled FundPayoutTool request", "result", result)
+
+	return result, nil
+}
+
+// preflight checks the balance against the payout to fund
+func (t *FundPayoutTool) preflight(ctx context.Context, id string) (string, *mcp.CallToolResult) {
+	if balanceCheckMode() == constants.BalanceCheckOff {
+		return "", nil
+	}
+
+	state, err := fetchPayoutState(ctx, t.logger, id)
+	if err != nil {
+		return unchecked(ctx, t.logger, subjectPayout, err)
+	}
+
+	return preflight(ctx, t.logger, state)
+}
+
+// fund funds the payout and returns the updated payout object
+func (t *FundPayoutTool) fund(ctx context.Context, id string) (map[string]any, error) {
 	url := fmt.Sprintf("%s/payout/%s/fund", constants.ProdBaseURL, id)
 
 	resp, err := utils.HandlePOSTHttpRequest(ctx, t.logger, url, nil, constants.PostHTTPMethod)
@@ -66,34 +120,10 @@ func (t *FundPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*
 		return nil, constants.ErrNoDataInResponse
 	}
 
-	status, ok := data["status"].(string)
-	if !ok {
+	if _, ok := data["status"].(string); !ok {
 		t.logger.ErrorContext(ctx, "No status in fund payout data", "data", data)
 		return nil, constants.ErrNoStatusInFundPayoutData
 	}
 
-	resultText := "Payout funded. Status: " + status
-
-	// Convert amount from cents to decimal value if present
-	if amount, exists := data["amount"].(float64); exists {
-		currency, hasCurrency := data["currency"].(string)
-		amountValue := money.Int64ToDecimal2(int64(amount))
-
-		if hasCurrency {
-			resultText += fmt.Sprintf("\nAmount: %s %.2f", currency, amountValue)
-		} else {
-			resultText += fmt.Sprintf("\nAmount: %.2f", amountValue)
-		}
-	}
-
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: resultText},
-		},
-	}
-	err = nil
-
-	t.logger.InfoContext(ctx, "Successfully handled FundPayoutTool request", "result", result)
-
-	return result, err
+	return data, nil
 }
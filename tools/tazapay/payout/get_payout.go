@@ -2,15 +2,16 @@ package payout
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
 	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
-	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
 )
 
 // GetPayoutTool fetches a payout by ID
@@ -26,18 +27,24 @@ func NewGetPayoutTool(logger *slog.Logger) *GetPayoutTool {
 
 func (*GetPayoutTool) Definition() mcp.Tool {
 	return mcp.NewTool(
-		constants.GetPayoutToolName,
+		toolname.Name(constants.GetPayoutToolName),
 		mcp.WithDescription(constants.GetPayoutToolDesc),
-		mcp.WithString(constants.GetPayoutIDField, mcp.Required(), mcp.Description(constants.GetPayoutIDDesc)),
+		schema.Output[types.PayoutOutput](),
+		schema.Input[types.GetPayoutParams](),
 	)
 }
 
-func (t *GetPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args, ok := req.Params.Arguments.(map[string]any)
-	if !ok {
-		t.logger.ErrorContext(ctx, "Invalid arguments type for GetPayoutTool")
-		return nil, fmt.Errorf("%w", constants.ErrInvalidArgumentsType)
+// Metadata describes the side effects of the tool
+func (*GetPayoutTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		ReadOnly:   true,
+		Idempotent: true,
+		OpenWorld:  true,
 	}
+}
+
+func (t *GetPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
 
 	t.logger.InfoContext(ctx, "Handling GetPayoutTool request", "args", args)
 
@@ -47,58 +54,37 @@ func (t *GetPayoutTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*m
 		}
 	}()
 
-	id, ok := args["id"].(string)
-	if !ok || id == "" || utils.ValidatePrefixID("pot_", id) != nil {
-		t.logger.ErrorContext(ctx, constants.ErrMissingOrInvalidPayoutID.Error())
-		return nil, constants.ErrMissingOrInvalidPayoutID
-	}
+	var params types.GetPayoutParams
+	if err := schema.Decode(args, &params); err != nil {
+		t.logger.ErrorContext(ctx, err.Error())
 
-	url := fmt.Sprintf("%s/payout/%s", constants.ProdBaseURL, id)
+		return nil, err
+	}
 
-	resp, err := utils.HandleGETHttpRequest(ctx, t.logger, url, constants.GetHTTPMethod)
+	data, err := utils.FetchPayout(ctx, t.logger, params.ID)
 	if err != nil {
 		t.logger.ErrorContext(ctx, "Failed to fetch payout", "error", err)
 		return nil, err
 	}
 
-	data, ok := resp["data"].(map[string]any)
-	if !ok {
-		t.logger.ErrorContext(ctx, "No data in get payout API response", "resp", resp)
-		return nil, constants.ErrNoDataInResponse
-	}
-
-	// Convert amount from cents to decimal value if present
-	if amount, exists := data["amount"].(float64); exists {
-		data["amount"] = money.Int64ToDecimal2(int64(amount))
-		data["amount_original"] = amount
-	}
+	output := types.NewPayoutOutput(data)
 
-	// Check for amount in sub-objects as well, such as transactions
-	if transactions, ok := data["transactions"].([]any); ok {
-		for i, trans := range transactions {
-			if transMap, ok := trans.(map[string]any); ok {
-				if amount, exists := transMap["amount"].(float64); exists {
-					transMap["amount"] = money.Int64ToDecimal2(int64(amount))
-					transMap["amount_original"] = amount
-					transactions[i] = transMap
-				}
-			}
-		}
-		data["transactions"] = transactions
-	}
-
-	jsonBytes, err := json.MarshalIndent(data, "", "  ")
+	result, err := types.NewResult(output, payoutSummary(output))
 	if err != nil {
-		t.logger.ErrorContext(ctx, "Failed to marshal payout data", "error", err)
 		return nil, err
 	}
 
-	result := &mcp.CallToolResult{
-		Content: []mcp.Content{
-			mcp.TextContent{Type: "text", Text: string(jsonBytes)},
-		},
-	}
 	t.logger.InfoContext(ctx, "Successfully handled GetPayoutTool request", "result", result)
 
 	return result, nil
 }
+
+// payoutSummary describes a payout in one line
+func payoutSummary(p types.PayoutOutput) string {
+	summary := fmt.Sprintf("Payout %s is %s: %s %.2f", p.ID, p.Status, p.Currency, p.Amount)
+	if p.Beneficiary != "" {
+		summary += " to " + p.Beneficiary
+	}
+
+	return summary
+}
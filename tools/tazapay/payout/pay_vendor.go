@@ -0,0 +1,395 @@
+package payout
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/idempotency"
+	"github.com/tazapay/tazapay-mcp-server/pkg/recent"
+	"github.com/tazapay/tazapay-mcp-server/pkg/schema"
+	"github.com/tazapay/tazapay-mcp-server/pkg/toolname"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Outcomes of a vendor payment
+const (
+	outcomeFunded        = "funded"
+	outcomeAwaitingFunds = "awaiting_funds"
+	outcomeIncomplete    = "incomplete"
+)
+
+// statusRequiresFunding is the status of a payout waiting to be funded
+const statusRequiresFunding = "requires_funding"
+
+// fundedStatuses are the statuses of a payout funded earlier. Any other status
+// than these and requires_funding is a payout that will not be paid.
+var fundedStatuses = []string{"processing", "in_transit", "succeeded"}
+
+// placeholder IDs stand in for the objects a dry run does not create
+const (
+	dryRunBeneficiaryID = "bnf_dry_run"
+	dryRunPayoutID      = "pot_dry_run"
+)
+
+// PayVendorTool creates or reuses a beneficiary, creates a payout and funds it
+type PayVendorTool struct {
+	logger *slog.Logger
+	create *CreatePayoutTool
+	fund   *FundPayoutTool
+
+	// payouts maps the idempotency keys of each account to the payouts created
+	// with them, so that a retried call resumes the payout instead of creating
+	// another one
+	payouts *idempotency.Cache
+}
+
+// NewPayVendorTool returns a new instance of the PayVendorTool
+func NewPayVendorTool(logger *slog.Logger) *PayVendorTool {
+	logger.InfoContext(context.Background(), "Registering Pay_Vendor_Tool")
+
+	return &PayVendorTool{
+		logger:  logger,
+		create:  &CreatePayoutTool{logger: logger},
+		fund:    &FundPayoutTool{logger: logger},
+		payouts: idempotency.New(idempotency.DefaultTTL, idempotency.DefaultSize),
+	}
+}
+
+// Definition returns the create payout schema with the resume and idempotency arguments
+func (*PayVendorTool) Definition() mcp.Tool {
+	def := mcp.NewTool(
+		toolname.Name(constants.PayVendorToolName),
+		mcp.WithDescription(constants.PayVendorToolDesc+toolname.CorridorRequirementsHint()),
+		schema.Input[types.PayVendorParams](),
+		schema.Output[types.VendorPaymentOutput](),
+	)
+
+	// a resumed payment only needs payout_id, the handler validates the rest
+	def.InputSchema.Required = nil
+
+	return def
+}
+
+// Metadata describes the side effects of the tool
+func (*PayVendorTool) Metadata() types.ToolMetadata {
+	return types.ToolMetadata{
+		Destructive: true,
+		OpenWorld:   true,
+		MovesFunds:  true,
+	}
+}
+
+// Summarize describes the payment for confirmation
+func (t *PayVendorTool) Summarize(ctx context.Context, args map[string]any) (types.PaymentSummary, error) {
+	if payoutID, _ := args[constants.PayVendorPayoutIDField].(string); payoutID != "" {
+		return t.fund.Summarize(ctx, map[string]any{constants.GetPayoutIDField: payoutID})
+	}
+
+	summary, err := t.create.Summarize(ctx, args)
+	summary.Action = "Pay a vendor: create and fund a payout"
+
+	return summary, err
+}
+
+// ResumedPayout returns the payout given in payout_id, or the one created by an
+// earlier call with the same idempotency key
+func (t *PayVendorTool) ResumedPayout(ctx context.Context, args map[string]any) string {
+	if payoutID, _ := args[constants.PayVendorPayoutIDField].(string); payoutID != "" {
+		if utils.ValidatePrefixID("pot_", payoutID) != nil {
+			return ""
+		}
+
+		return payoutID
+	}
+
+	// decoding normalizes the arguments in place, so a copy is decoded and the
+	// handler still sees what the client sent
+	_, key, err := t.decode(ctx, cloneArgs(args))
+	if err != nil {
+		return ""
+	}
+
+	payoutID, _ := t.payouts.Get(cacheKey(ctx, key))
+
+	return payoutID
+}
+
+// Handle processes the tool request
+func (t *PayVendorTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args := req.GetArguments()
+	t.logger.InfoContext(ctx, "Handling PayVendorTool request", "args", args)
+
+	report := &types.VendorPaymentOutput{Outcome: outcomeIncomplete}
+
+	if payoutID := req.GetString(constants.PayVendorPayoutIDField, ""); payoutID != "" {
+		if utils.ValidatePrefixID("pot_", payoutID) != nil {
+			return nil, constants.ErrMissingOrInvalidPayoutID
+		}
+
+		report.PayoutID = payoutID
+		report.Add("create_payout", "reused", payoutID, "resuming the payout given in payout_id")
+
+		return t.fundIfCovered(ctx, report, nil)
+	}
+
+	params, key, err := t.decode(ctx, args)
+	if err != nil {
+		t.logger.ErrorContext(ctx, "Validation failed", constants.KeyError, err)
+		return nil, err
+	}
+
+	report.IdempotencyKey = key
+
+	if existing, ok := t.payouts.Get(cacheKey(ctx, key)); ok {
+		report.PayoutID = existing
+		report.Add("create_payout", "reused", report.PayoutID, "created by an earlier call with idempotency key "+key+
+			", pass a different idempotency_key to make another payment")
+
+		return t.fundIfCovered(ctx, report, nil)
+	}
+
+	beneficiaryID, err := t.beneficiary(ctx, &params, key, report)
+	if err != nil {
+		return t.failed(ctx, report, "beneficiary", err), nil
+	}
+
+	report.BeneficiaryID = beneficiaryID
+
+	payload := newPayoutRequest(&params)
+	payload.Beneficiary = beneficiaryID
+	payload.BeneficiaryDetails = nil
+
+	payoutID, err := t.create.postPayout(utils.WithIdempotencyKey(ctx, key), payload)
+	if errors.Is(err, constants.ErrDryRun) {
+		payoutID, err = dryRunPayoutID, nil
+	}
+
+	if err != nil {
+		return t.failed(ctx, report, "create_payout", err), nil
+	}
+
+	if !utils.IsDryRun(ctx) {
+		t.payouts.Put(cacheKey(ctx, key), payoutID)
+	}
+
+	report.PayoutID = payoutID
+	report.Add("create_payout", "created", payoutID, "idempotency key "+key)
+
+	// a dry run has no payout to read back, the arguments describe it
+	var state *payoutState
+	if utils.IsDryRun(ctx) {
+		state = &payoutState{
+			Status:          statusRequiresFunding,
+			Currency:        payload.Currency,
+			HoldingCurrency: payload.HoldingCurrency,
+			Amount:          payload.Amount,
+		}
+	}
+
+	return t.fundIfCovered(ctx, report, state)
+}
+
+// beneficiary returns the ID of the beneficiary given in the arguments after
+// checking that it exists, or creates one from beneficiary_details
+func (t *PayVendorTool) beneficiary(ctx context.Context, params *types.CreatePayoutParams, key string,
+	report *types.VendorPaymentOutput,
+) (string, error) {
+	if id := params.Beneficiary; id != "" {
+		details, err := fetchBeneficiary(ctx, t.logger, id)
+		if err != nil {
+			return "", err
+		}
+
+		report.Add("beneficiary", "reused", id, stringField(details, "name"))
+
+		return id, nil
+	}
+
+	payload := params.BeneficiaryDetails
+
+	resp, err := utils.HandlePOSTHttpRequest(utils.WithIdempotencyKey(ctx, key+"-bnf"), t.logger,
+		constants.CreateBeneficiaryAPIURL, payload, constants.PostHTTPMethod)
+	if errors.Is(err, constants.ErrDryRun) {
+		report.Add("beneficiary", "created", dryRunBeneficiaryID, payload.Name)
+		return dryRunBeneficiaryID, nil
+	}
+
+	if err != nil {
+		return "", err
+	}
+
+	data, _ := resp[constants.KeyData].(map[string]any)
+
+	id := stringField(data, "id")
+	if id == "" {
+		return "", constants.ErrNoBeneficiaryID
+	}
+
+	recent.Add(ctx, recent.KindBeneficiary, id)
+	report.Add("beneficiary", "created", id, payload.Name)
+
+	return id, nil
+}
+
+// fundIfCovered funds the payout when it requires funding and the holding
+// balance covers it. The payout is read from Tazapay unless state is given.
+func (t *PayVendorTool) fundIfCovered(ctx context.Context, report *types.VendorPaymentOutput,
+	state *payoutState,
+) (*mcp.CallToolResult, error) {
+	if state == nil {
+		fetched, err := fetchPayoutState(ctx, t.logger, report.PayoutID)
+		if err != nil {
+			return t.failed(ctx, report, "fund_payout", err), nil
+		}
+
+		state = &fetched
+	}
+
+	report.PayoutStatus = state.Status
+
+	switch {
+	case slices.Contains(fundedStatuses, state.Status):
+		report.Outcome = outcomeFunded
+		report.Add("fund_payout", "skipped", report.PayoutID, "payout is already "+state.Status)
+
+		return t.result(ctx, report), nil
+	case state.Status != statusRequiresFunding:
+		report.Add("fund_payout", "failed", report.PayoutID, "payout is "+state.Status+" and cannot be funded")
+		report.Resume = fmt.Sprintf("The payout is %s. Check it with %s, and if the vendor is still owed, pay again "+
+			"with a new %s.", state.Status, toolname.Name(constants.GetPayoutToolName),
+			constants.PayVendorIdempotencyKeyField)
+
+		return t.result(ctx, report), nil
+	}
+
+	if !utils.IsDryRun(ctx) {
+		check, err := checkBalance(ctx, t.logger, *state)
+		if err != nil {
+			return t.failed(ctx, report, "check_balance", err), nil
+		}
+
+		if !check.Covered() {
+			report.Outcome = outcomeAwaitingFunds
+			report.Add("check_balance", "insufficient", "", check.Shortfall())
+			report.Resume = fmt.Sprintf("Top up the balance, then call %s with %s=%s to fund the payout.",
+				toolname.Name(constants.PayVendorToolName), constants.PayVendorPayoutIDField, report.PayoutID)
+
+			return t.result(ctx, report), nil
+		}
+
+		report.Add("check_balance", "sufficient", "", check.String())
+	}
+
+	data, err := t.fund.fund(ctx, report.PayoutID)
+	if errors.Is(err, constants.ErrDryRun) {
+		data, err = map[string]any{"status": "dry_run"}, nil
+	}
+
+	if err != nil {
+		return t.failed(ctx, report, "fund_payout", err), nil
+	}
+
+	report.Outcome = outcomeFunded
+	report.PayoutStatus = stringField(data, "status")
+	report.Add("fund_payout", "funded", report.PayoutID, "status "+report.PayoutStatus)
+
+	return t.result(ctx, report), nil
+}
+
+// failed records the failed step and returns an error result telling how to resume
+func (t *PayVendorTool) failed(ctx context.Context, report *types.VendorPaymentOutput, name string,
+	err error,
+) *mcp.CallToolResult {
+	t.logger.ErrorContext(ctx, "Pay vendor step failed", "step", name, constants.KeyError, err)
+	report.Add(name, "failed", "", err.Error())
+
+	switch {
+	case report.PayoutID != "":
+		report.Resume = fmt.Sprintf("The payout exists but is not funded. Fix the cause, then call %s with %s=%s.",
+			toolname.Name(constants.PayVendorToolName), constants.PayVendorPayoutIDField, report.PayoutID)
+	case report.BeneficiaryID != "":
+		report.Resume = fmt.Sprintf("The beneficiary exists. Call %s again with %s=%s instead of beneficiary_details.",
+			toolname.Name(constants.PayVendorToolName), constants.PayoutBeneficiaryField, report.BeneficiaryID)
+	default:
+		report.Resume = "Nothing was created. Fix the cause and call the tool again."
+	}
+
+	result := t.result(ctx, report)
+	result.IsError = true
+
+	return result
+}
+
+// result renders the report
+func (t *PayVendorTool) result(ctx context.Context, report *types.VendorPaymentOutput) *mcp.CallToolResult {
+	summary := fmt.Sprintf("Vendor payment %s.", strings.ReplaceAll(report.Outcome, "_", " "))
+	if report.Resume != "" {
+		summary += " " + report.Resume
+	}
+
+	result, err := types.NewResult(report, summary)
+	if err != nil {
+		return mcp.NewToolResultError(err.Error())
+	}
+
+	result.IsError = report.Outcome != outcomeFunded
+
+	t.logger.InfoContext(ctx, "Handled PayVendorTool request", "outcome", report.Outcome,
+		"beneficiary_id", report.BeneficiaryID, "payout_id", report.PayoutID)
+
+	return result
+}
+
+// decode validates the payout arguments and returns them with their
+// idempotency key. The key is derived from the normalized payout, so a retry
+// spelling the currency or purpose code differently resumes the same payout.
+func (t *PayVendorTool) decode(ctx context.Context, args map[string]any) (types.CreatePayoutParams, string, error) {
+	params, err := t.create.decodePayout(ctx, args)
+	if err != nil {
+		return params, "", err
+	}
+
+	if key, _ := args[constants.PayVendorIdempotencyKeyField].(string); key != "" {
+		return params, key, nil
+	}
+
+	return params, idempotencyKey(params), nil
+}
+
+// cacheKey scopes an idempotency key to the account of the call
+func cacheKey(ctx context.Context, key string) string {
+	return utils.AccountID(ctx) + ":" + key
+}
+
+// idempotencyKey derives the default idempotency key from the decoded payout
+func idempotencyKey(params types.CreatePayoutParams) string {
+	// the confirmation token differs between the call asking and the call confirming
+	params.ConfirmationToken = ""
+
+	// fields and map keys are marshalled in a fixed order, so equal payouts give equal keys
+	raw, _ := json.Marshal(params)
+	sum := sha256.Sum256(raw)
+
+	return "mcp-" + hex.EncodeToString(sum[:16])
+}
+
+// cloneArgs returns a deep copy of tool arguments
+func cloneArgs(args map[string]any) map[string]any {
+	raw, _ := json.Marshal(args)
+
+	clone := make(map[string]any)
+	_ = json.Unmarshal(raw, &clone)
+
+	return clone
+}
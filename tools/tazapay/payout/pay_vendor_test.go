@@ -0,0 +1,102 @@
+package payout
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/policy"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+)
+
+// creatingVendorTool is the pay vendor tool with a handler that records payout
+// pot_1 under the idempotency key of the call instead of calling Tazapay
+type creatingVendorTool struct {
+	*PayVendorTool
+}
+
+func (t creatingVendorTool) Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	if payoutID := req.GetString("payout_id", ""); payoutID != "" {
+		return mcp.NewToolResultText("resumed " + payoutID), nil
+	}
+
+	_, key, err := t.decode(ctx, req.GetArguments())
+	if err != nil {
+		return mcp.NewToolResultError(err.Error()), nil
+	}
+
+	if existing, ok := t.payouts.Get(cacheKey(ctx, key)); ok {
+		return mcp.NewToolResultText("resumed " + existing), nil
+	}
+
+	t.payouts.Put(cacheKey(ctx, key), "pot_1")
+
+	return mcp.NewToolResultText("created pot_1"), nil
+}
+
+// vendorPayment returns the arguments of a payment of 100 INR
+func vendorPayment(currency, purpose string) map[string]any {
+	return map[string]any{
+		"amount":                  100.0,
+		"currency":                currency,
+		"purpose":                 purpose,
+		"transaction_description": "Invoice 1",
+		"beneficiary":             "bnf_1",
+	}
+}
+
+func TestPayVendorRetryCountedOnce(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	engine, err := policy.NewEngine(policy.Rules{DailyAccountCap: map[string]float64{"INR": 150}})
+	if err != nil {
+		t.Fatalf("NewEngine() error: %v", err)
+	}
+
+	tool := creatingVendorTool{NewPayVendorTool(logger)}
+	handle := policy.Middleware(engine, logger)(tool, tool.Handle)
+	ctx := utils.WithAuthToken(context.Background(), "alice")
+
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"first call", vendorPayment("INR", "PYR007"), "created pot_1"},
+		{"retry in lower case", vendorPayment("inr", "pyr007"), "resumed pot_1"},
+		{"resumed payout", map[string]any{"payout_id": "pot_1"}, "resumed pot_1"},
+	}
+
+	for _, test := range tests {
+		req := mcp.CallToolRequest{}
+		req.Params.Arguments = test.args
+
+		result, err := handle(ctx, req)
+		if err != nil || result.IsError {
+			t.Fatalf("%s: got %v, %v; want the payment allowed", test.name, result, err)
+		}
+
+		if got := result.Content[0].(mcp.TextContent).Text; got != test.want {
+			t.Errorf("%s: result %q; want %q", test.name, got, test.want)
+		}
+	}
+
+	// the cap only leaves room for the first payment
+	req := mcp.CallToolRequest{}
+	req.Params.Arguments = vendorPayment("INR", "PYR008")
+
+	if result, _ := handle(ctx, req); !result.IsError {
+		t.Error("second payment allowed; want it denied by the daily cap")
+	}
+}
+
+func TestPayVendorResumedPayoutChecksID(t *testing.T) {
+	tool := NewPayVendorTool(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	if got := tool.ResumedPayout(context.Background(), map[string]any{"payout_id": "../bnf_1"}); got != "" {
+		t.Errorf("ResumedPayout() = %q; want an invalid payout ID ignored", got)
+	}
+}
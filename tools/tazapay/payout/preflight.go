@@ -0,0 +1,290 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/spf13/viper"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/batch"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// What a balance check is for, in messages to the user
+const (
+	subjectPayout = "payout"
+	subjectBatch  = "batch"
+)
+
+// payoutState is what the balance check needs to know about a payout
+type payoutState struct {
+	Status          string
+	Currency        string
+	HoldingCurrency string
+	// Amount in cents, in the payout currency
+	Amount int64
+}
+
+// holdingCurrency is the balance currency funding the payout
+func (s payoutState) holdingCurrency() string {
+	if s.HoldingCurrency != "" {
+		return s.HoldingCurrency
+	}
+
+	return s.Currency
+}
+
+// stateFromParams describes the payout that create payout arguments would create
+func stateFromParams(p *types.CreatePayoutParams) payoutState {
+	return payoutState{
+		Currency:        p.Currency,
+		HoldingCurrency: p.HoldingCurrency,
+		Amount:          money.Decimal2ToInt64(p.Amount),
+	}
+}
+
+// fetchPayoutState reads the payout from Tazapay
+func fetchPayoutState(ctx context.Context, logger *slog.Logger, id string) (payoutState, error) {
+	data, err := utils.FetchPayout(ctx, logger, id)
+	if err != nil {
+		return payoutState{}, err
+	}
+
+	state := payoutState{
+		Status:          stringField(data, "status"),
+		Currency:        strings.ToUpper(stringField(data, constants.KeyCurrency)),
+		HoldingCurrency: strings.ToUpper(stringField(data, "holding_currency")),
+	}
+
+	if amount, ok := data[constants.PayoutAmountField].(float64); ok {
+		state.Amount = int64(amount)
+	}
+
+	return state, nil
+}
+
+// balanceCheck compares the amount a payout needs with the available balance,
+// both in cents of the holding currency
+type balanceCheck struct {
+	Currency  string
+	Needed    int64
+	Available int64
+	// Subject names what needs the balance, "payout" when empty
+	Subject string
+}
+
+// subject returns what needs the balance
+func (c balanceCheck) subject() string {
+	if c.Subject != "" {
+		return c.Subject
+	}
+
+	return subjectPayout
+}
+
+// Covered reports whether the balance covers the payout
+func (c balanceCheck) Covered() bool {
+	return c.Available >= c.Needed
+}
+
+// String renders the check in one line
+func (c balanceCheck) String() string {
+	return fmt.Sprintf("needs %s %.2f, available %s %.2f", c.Currency, money.Int64ToDecimal2(c.Needed),
+		c.Currency, money.Int64ToDecimal2(c.Available))
+}
+
+// Shortfall explains an uncovered payout to the user
+func (c balanceCheck) Shortfall() string {
+	return fmt.Sprintf("Insufficient %s balance: the %s needs %s %.2f but only %s %.2f is available, "+
+		"a shortfall of %s %.2f. Top up the %s balance or fund the %s from another holding_currency.",
+		c.Currency, c.subject(), c.Currency, money.Int64ToDecimal2(c.Needed), c.Currency,
+		money.Int64ToDecimal2(c.Available), c.Currency, money.Int64ToDecimal2(c.Needed-c.Available), c.Currency,
+		c.subject())
+}
+
+// checkBalance fetches the available balance in the holding currency of the
+// payout, converting the payout amount with the FX endpoint when the
+// currencies differ
+func checkBalance(ctx context.Context, logger *slog.Logger, state payoutState) (balanceCheck, error) {
+	check := balanceCheck{Currency: state.holdingCurrency(), Needed: state.Amount}
+
+	if check.Currency != state.Currency {
+		summary := types.PaymentSummary{Currency: state.Currency, HoldingCurrency: check.Currency, Amount: state.Amount}
+		quoteFX(ctx, logger, &summary)
+
+		if summary.HoldingAmount == 0 {
+			return check, fmt.Errorf("%w: no FX quote from %s to %s",
+				constants.ErrNoDataInResponse, state.Currency, check.Currency)
+		}
+
+		check.Needed = summary.HoldingAmount
+	}
+
+	resp, err := utils.HandleGETHttpRequest(ctx, logger, constants.BalanceBaseURLProd, constants.GetHTTPMethod)
+	if err != nil {
+		return check, err
+	}
+
+	check.Available, _, err = utils.AvailableBalance(resp, check.Currency)
+
+	return check, err
+}
+
+// preflight checks the balance before a payout is created or funded, as set by
+// TAZAPAY_BALANCE_CHECK: off, warn (the default) or block. It returns a warning
+// to show with the result, or the result to return instead of running the call
+// when the balance is short in block mode. A check that cannot be completed
+// warns, except in block mode where it stops the payout too.
+func preflight(ctx context.Context, logger *slog.Logger, state payoutState) (string, *mcp.CallToolResult) {
+	mode := balanceCheckMode()
+	if mode == constants.BalanceCheckOff || state.Amount == 0 {
+		return "", nil
+	}
+
+	check, err := balanceOf(ctx, logger, state)
+	if err != nil {
+		return unchecked(ctx, logger, subjectPayout, err)
+	}
+
+	return judge(ctx, logger, check)
+}
+
+// batchPreflight runs the preflight on the totals of a batch before any row is
+// sent. Rows funded from the same holding currency are checked together, so a
+// batch is stopped in block mode when its total overdraws the balance even if
+// every row alone would not.
+func batchPreflight(ctx context.Context, logger *slog.Logger, rows []batch.Row) (string, *mcp.CallToolResult) {
+	if balanceCheckMode() == constants.BalanceCheckOff {
+		return "", nil
+	}
+
+	byHolding := make(map[string]*balanceCheck)
+
+	for _, state := range batchStates(rows) {
+		check, err := balanceOf(ctx, logger, state)
+		if err != nil {
+			return unchecked(ctx, logger, subjectBatch, err)
+		}
+
+		total, ok := byHolding[check.Currency]
+		if !ok {
+			total = &balanceCheck{Currency: check.Currency, Available: check.Available, Subject: subjectBatch}
+			byHolding[check.Currency] = total
+		}
+
+		total.Needed += check.Needed
+	}
+
+	var warnings []string
+
+	for _, currency := range slices.Sorted(maps.Keys(byHolding)) {
+		warning, blocked := judge(ctx, logger, *byHolding[currency])
+		if blocked != nil {
+			return "", blocked
+		}
+
+		if warning != "" {
+			warnings = append(warnings, warning)
+		}
+	}
+
+	return strings.Join(warnings, "\n"), nil
+}
+
+// batchStates sums the rows of a batch per payout and holding currency. The
+// rows have been decoded, so their currencies are normalized.
+func batchStates(rows []batch.Row) []payoutState {
+	byPair := make(map[string]*payoutState)
+
+	for _, row := range rows {
+		currency, _ := row.Args[constants.KeyCurrency].(string)
+		holding, _ := row.Args[constants.PayoutHoldingCurrencyField].(string)
+
+		state, ok := byPair[currency+"/"+holding]
+		if !ok {
+			state = &payoutState{Currency: currency, HoldingCurrency: holding}
+			byPair[currency+"/"+holding] = state
+		}
+
+		amount, _ := row.Args[constants.PayoutAmountField].(float64)
+		state.Amount += money.Decimal2ToInt64(amount)
+	}
+
+	states := make([]payoutState, 0, len(byPair))
+	for _, pair := range slices.Sorted(maps.Keys(byPair)) {
+		states = append(states, *byPair[pair])
+	}
+
+	return states
+}
+
+// judge applies the configured mode to a completed balance check
+func judge(ctx context.Context, logger *slog.Logger, check balanceCheck) (string, *mcp.CallToolResult) {
+	mode := balanceCheckMode()
+	logger.InfoContext(ctx, "Balance checked", "currency", check.Currency, "needed", check.Needed,
+		"available", check.Available, "subject", check.subject(), "mode", mode)
+
+	if check.Covered() {
+		return "", nil
+	}
+
+	if mode == constants.BalanceCheckBlock {
+		return "", mcp.NewToolResultError(notSent(check.subject()) + ". " + check.Shortfall())
+	}
+
+	return "Warning: " + check.Shortfall(), nil
+}
+
+// unchecked handles a balance check that could not be completed: the payout is
+// stopped in block mode and goes ahead with a warning otherwise
+func unchecked(ctx context.Context, logger *slog.Logger, subject string, err error) (string, *mcp.CallToolResult) {
+	mode := balanceCheckMode()
+	logger.WarnContext(ctx, "Balance check failed", constants.KeyError, err, "mode", mode)
+
+	if mode == constants.BalanceCheckBlock {
+		return "", mcp.NewToolResultError(notSent(subject) + ": the balance could not be checked and " +
+			constants.StrTAZAPAYBalanceCheck + " is block. " + err.Error())
+	}
+
+	return "Warning: the balance could not be checked before the " + subject + ": " + err.Error(), nil
+}
+
+// notSent tells the user that nothing was sent
+func notSent(subject string) string {
+	if subject == subjectBatch {
+		return "Batch not sent, no payout was created"
+	}
+
+	return "Payout not sent"
+}
+
+// balanceOf checks the balance, failing when the payout currency is unknown
+func balanceOf(ctx context.Context, logger *slog.Logger, state payoutState) (balanceCheck, error) {
+	if state.Currency == "" {
+		return balanceCheck{}, constants.ErrMissingPayoutCurrency
+	}
+
+	return checkBalance(ctx, logger, state)
+}
+
+// balanceCheckMode returns the configured TAZAPAY_BALANCE_CHECK mode
+func balanceCheckMode() string {
+	return strings.ToLower(viper.GetString(constants.StrTAZAPAYBalanceCheck))
+}
+
+// withWarning puts the preflight warning in front of a result summary
+func withWarning(summary, warning string) string {
+	if warning == "" {
+		return summary
+	}
+
+	return warning + "\n" + summary
+}
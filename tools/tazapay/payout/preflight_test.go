@@ -0,0 +1,51 @@
+package payout
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/batch"
+)
+
+func TestBatchStates(t *testing.T) {
+	rows := []batch.Row{
+		{Number: 1, Args: map[string]any{"currency": "INR", "amount": 100.5}},
+		{Number: 2, Args: map[string]any{"currency": "INR", "amount": 20.0}},
+		{Number: 3, Args: map[string]any{"currency": "INR", "holding_currency": "USD", "amount": 10.0}},
+		{Number: 4, Args: map[string]any{"currency": "SGD", "holding_currency": "USD", "amount": 5.25}},
+	}
+
+	want := []payoutState{
+		{Currency: "INR", Amount: 12050},
+		{Currency: "INR", HoldingCurrency: "USD", Amount: 1000},
+		{Currency: "SGD", HoldingCurrency: "USD", Amount: 525},
+	}
+
+	if got := batchStates(rows); !reflect.DeepEqual(got, want) {
+		t.Errorf("batchStates() = %+v; want %+v", got, want)
+	}
+}
+
+func TestShortfallSubject(t *testing.T) {
+	tests := []struct {
+		check balanceCheck
+		want  string
+	}{
+		{
+			balanceCheck{Currency: "USD", Needed: 1500, Available: 1000},
+			"Insufficient USD balance: the payout needs USD 15.00 but only USD 10.00 is available, " +
+				"a shortfall of USD 5.00. Top up the USD balance or fund the payout from another holding_currency.",
+		},
+		{
+			balanceCheck{Currency: "USD", Needed: 1500, Available: 1000, Subject: subjectBatch},
+			"Insufficient USD balance: the batch needs USD 15.00 but only USD 10.00 is available, " +
+				"a shortfall of USD 5.00. Top up the USD balance or fund the batch from another holding_currency.",
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.check.Shortfall(); got != test.want {
+			t.Errorf("Shortfall() = %q; want %q", got, test.want)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package payout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/types"
+)
+
+// Summarize describes the payout that the call would create. The arguments are
+// validated and normalized as Handle does, so the user confirms the payout that
+// is sent. A copy is decoded, leaving the confirmation token bound to the
+// arguments the client sent.
+func (t *CreatePayoutTool) Summarize(ctx context.Context, args map[string]any) (types.PaymentSummary, error) {
+	args = cloneArgs(args)
+
+	params, err := t.decodePayout(ctx, args)
+	if err != nil {
+		return types.PaymentSummary{}, err
+	}
+
+	summary := types.PaymentSummary{
+		Action:          "Create a payout",
+		Currency:        params.Currency,
+		HoldingCurrency: params.HoldingCurrency,
+		ChargeType:      params.ChargeType,
+		BeneficiaryID:   params.Beneficiary,
+		Amount:          money.Decimal2ToInt64(params.Amount),
+	}
+
+	details, _ := args[constants.KeyBeneficiaryDetails].(map[string]any)
+	if summary.BeneficiaryID != "" {
+		if details, err = fetchBeneficiary(ctx, t.logger, summary.BeneficiaryID); err != nil {
+			return summary, err
+		}
+	}
+
+	describeBeneficiary(&summary, details)
+	quoteFX(ctx, t.logger, &summary)
+
+	return summary, nil
+}
+
+// Summarize describes the payout that the call would fund
+func (t *FundPayoutTool) Summarize(ctx context.Context, args map[string]any) (types.PaymentSummary, error) {
+	id, _ := args["id"].(string)
+	if utils.ValidatePrefixID("pot_", id) != nil {
+		return types.PaymentSummary{}, constants.ErrMissingOrInvalidPayoutID
+	}
+
+	data, err := utils.FetchPayout(ctx, t.logger, id)
+	if err != nil {
+		return types.PaymentSummary{}, err
+	}
+
+	summary := types.PaymentSummary{
+		Action:          "Fund payout " + id,
+		Currency:        strings.ToUpper(stringField(data, constants.KeyCurrency)),
+		HoldingCurrency: strings.ToUpper(stringField(data, "holding_currency")),
+		ChargeType:      stringField(data, "charge_type"),
+		BeneficiaryID:   stringField(data, "beneficiary"),
+	}
+
+	if amount, ok := data["amount"].(float64); ok {
+		summary.Amount = int64(amount)
+	}
+
+	details, _ := data[constants.KeyBeneficiaryDetails].(map[string]any)
+	if details == nil && summary.BeneficiaryID != "" {
+		if details, err = fetchBeneficiary(ctx, t.logger, summary.BeneficiaryID); err != nil {
+			return summary, err
+		}
+	}
+
+	describeBeneficiary(&summary, details)
+	quoteFX(ctx, t.logger, &summary)
+
+	return summary, nil
+}
+
+// fetchBeneficiary returns the beneficiary object with the given ID
+func fetchBeneficiary(ctx context.Context, logger *slog.Logger, id string) (map[string]any, error) {
+	return utils.FetchObject(ctx, logger, "beneficiary", id)
+}
+
+// describeBeneficiary fills in the beneficiary name and masked destination account
+func describeBeneficiary(summary *types.PaymentSummary, details map[string]any) {
+	summary.BeneficiaryName = stringField(details, "name")
+
+	destination, _ := details[constants.BeneficiaryDestinationDetailsField].(map[string]any)
+
+	if bank, ok := destination[constants.KeyBank].(map[string]any); ok {
+		account := stringField(bank, "account_number")
+		if account == "" {
+			account = stringField(bank, "iban")
+		}
+
+		summary.Account = strings.TrimSpace(stringField(bank, "bank_name") + " " + utils.MaskAccount(account))
+
+		if summary.Currency == "" {
+			summary.Currency = strings.ToUpper(stringField(bank, constants.KeyCurrency))
+		}
+
+		return
+	}
+
+	if wallet, ok := destination[constants.KeyWallet].(map[string]any); ok {
+		summary.Account = "wallet " + utils.MaskAccount(stringField(wallet, "deposit_address"))
+		return
+	}
+
+	if network, ok := destination["local_payment_network"].(map[string]any); ok {
+		summary.Account = strings.TrimSpace(stringField(network, constants.KeyType) + " " +
+			utils.MaskAccount(stringField(network, "deposit_key")))
+	}
+}
+
+// quoteFX adds the exchange rate when the payout is funded from a balance in
+// another currency. A failed quote is logged and left out of the summary.
+func quoteFX(ctx context.Context, logger *slog.Logger, summary *types.PaymentSummary) {
+	if summary.HoldingCurrency == "" || summary.HoldingCurrency == summary.Currency || summary.Amount == 0 {
+		return
+	}
+
+	url := fmt.Sprintf("%s?initial_currency=%s&final_currency=%s&amount=%d",
+		constants.PaymentFxBaseURLProd, summary.Currency, summary.HoldingCurrency, summary.Amount)
+
+	resp, err := utils.HandleGETHttpRequest(ctx, logger, url, constants.GetHTTPMethod)
+	if err != nil {
+		logger.WarnContext(ctx, "FX quote for payout summary failed", constants.KeyError, err)
+		return
+	}
+
+	data, _ := resp[constants.KeyData].(map[string]any)
+
+	rate, _ := data["exchange_rate"].(float64)
+	converted, _ := data["converted_amount"].(float64)
+
+	summary.ExchangeRate = rate
+	summary.HoldingAmount = int64(converted)
+}
+
+// stringField returns m[key] if it is a string
+func stringField(m map[string]any, key string) string {
+	v, _ := m[key].(string)
+	return v
+}
@@ -0,0 +1,42 @@
+package payout
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func TestCreatePayoutSummarizeValidates(t *testing.T) {
+	tool := NewCreatePayoutTool(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	args := vendorPayment("sgd", "pyr007")
+	delete(args, "beneficiary")
+	args["beneficiary_details"] = map[string]any{
+		"name": "Acme Ltd",
+		"type": "business",
+		"destination_details": map[string]any{
+			"type": "bank",
+			"bank": map[string]any{"account_number": "123456789", "country": "sg", "currency": "sgd"},
+		},
+	}
+
+	summary, err := tool.Summarize(context.Background(), args)
+	if err != nil {
+		t.Fatalf("Summarize() error: %v", err)
+	}
+
+	if summary.Currency != "SGD" || summary.Amount != 10000 {
+		t.Errorf("Summarize() = %s %d; want SGD 10000", summary.Currency, summary.Amount)
+	}
+
+	if args["currency"] != "sgd" {
+		t.Errorf("Summarize() changed the arguments: currency %v", args["currency"])
+	}
+
+	delete(args, "beneficiary_details")
+
+	if _, err := tool.Summarize(context.Background(), args); err == nil {
+		t.Error("Summarize() without a beneficiary succeeded; want it rejected")
+	}
+}
@@ -1,7 +1,8 @@
 package types
 
+// BalanceRequest are the arguments of the balance tool
 type BalanceRequest struct {
-	Currency string `json:"currency,omitempty"` // Optional currency filter
+	Currency string `json:"currency,omitempty" schema:"format=currency" description:"Currency to fetch balance for, as a 3 letter ISO 4217 code, e.g. USD. Omit it or send an empty string for all balances."`
 }
 
 type BalanceResponse struct {
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+)
+
+// MetaKeyBatchPayoutIDs is the result _meta key listing the payout created for
+// each payout of a batch, empty for the payouts that failed
+const MetaKeyBatchPayoutIDs = "tazapay/batchPayoutIDs"
+
+// BatchPayoutParams are the arguments of the batch payout tool. Exactly one of
+// document and document_uri is given.
+type BatchPayoutParams struct {
+	ConfirmationParams
+
+	Document    string `json:"document,omitempty" description:"Inline CSV with a header row, or a JSON array of payout objects. Columns and keys are the create payout arguments (amount, currency, purpose, transaction_description, beneficiary, reference_id, holding_currency, charge_type, type, statement_descriptor). Nested beneficiary_details fields use dotted CSV columns, e.g. beneficiary_details.destination_details.bank.account_number."`
+	DocumentURI string `json:"document_uri,omitempty" description:"URI of a batch file resource, tazapay://batch-files/{name}, instead of an inline document"`
+	Format      string `json:"format,omitempty" schema:"enum=csv|json" description:"csv or json, detected from the document when omitted"`
+	Concurrency int    `json:"concurrency,omitempty" description:"Number of payouts created in parallel (default 4, at most 10)"`
+}
+
+// Batcher is implemented by tools that make one payout per item of their input,
+// so that policies can evaluate every payout of the call
+type Batcher interface {
+	// BatchPayouts returns the create payout arguments of every payout of the call
+	BatchPayouts(ctx context.Context, args map[string]any) ([]map[string]any, error)
+}
+
+// WithBatchPayoutIDs records the payout created for each payout of a batch
+func WithBatchPayoutIDs(result *mcp.CallToolResult, ids []string) *mcp.CallToolResult {
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = make(map[string]any)
+	}
+
+	result.Meta.AdditionalFields[MetaKeyBatchPayoutIDs] = ids
+
+	return result
+}
+
+// BatchPayoutIDs returns the IDs recorded with WithBatchPayoutIDs
+func BatchPayoutIDs(result *mcp.CallToolResult) []string {
+	if result == nil || result.Meta == nil {
+		return nil
+	}
+
+	ids, _ := result.Meta.AdditionalFields[MetaKeyBatchPayoutIDs].([]string)
+
+	return ids
+}
+
+// BatchTotal is the sum of the payouts of a batch in one currency
+type BatchTotal struct {
+	Currency string `json:"currency"`
+	Count    int    `json:"count"`
+	// Amount in cents
+	Amount int64 `json:"amount" description:"Amount in cents"`
+}
+
+// String renders the total for the user
+func (t BatchTotal) String() string {
+	payouts := "payouts"
+	if t.Count == 1 {
+		payouts = "payout"
+	}
+
+	return fmt.Sprintf("%s %.2f in %d %s", t.Currency, money.Int64ToDecimal2(t.Amount), t.Count, payouts)
+}
+
+// BatchRowResult is the outcome of one payout of a batch
+type BatchRowResult struct {
+	Row         int    `json:"row"`
+	ReferenceID string `json:"reference_id,omitempty"`
+	Currency    string `json:"currency"`
+	Amount      string `json:"amount"`
+	Status      string `json:"status" schema:"enum=created|failed"`
+	PayoutID    string `json:"payout_id,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+// BatchPayoutOutput is the structured output of the batch payout tool
+type BatchPayoutOutput struct {
+	BatchID    string           `json:"batch_id"`
+	Created    int              `json:"created"`
+	Failed     int              `json:"failed"`
+	Totals     []BatchTotal     `json:"totals"`
+	ResultsURI string           `json:"results_uri" description:"Resource with the results as CSV"`
+	Results    []BatchRowResult `json:"results"`
+	Warning    string           `json:"warning,omitempty" description:"Balance check warning"`
+}
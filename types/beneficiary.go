@@ -1,89 +1,110 @@
 package types
 
-// DestinationRequest represents the payload for registering a destination
+// CreateBeneficiaryRequest is the payload registering a beneficiary. It is
+// also the input schema of the beneficiary and beneficiary_details arguments.
 type CreateBeneficiaryRequest struct {
-	DestinationDetails           DestinationDetails `json:"destination_details"`
-	Phone                        *Phone             `json:"phone,omitempty"`
-	Address                      *Address           `json:"address,omitempty"`
-	Documents                    *Documents         `json:"documents,omitempty"`
-	Name                         string             `json:"name"`
-	Email                        string             `json:"email,omitempty"`
-	Type                         string             `json:"type"`
-	AccountID                    string             `json:"account_id"`
-	NationalIdentificationNumber string             `json:"national_identification_number,omitempty"`
-	TaxID                        string             `json:"tax_id,omitempty"`
+	Name                         string              `json:"name" schema:"required" description:"Full legal name of the beneficiary as it appears on their bank account or official documents"`
+	Email                        string              `json:"email,omitempty" schema:"format=email" description:"Email address of the beneficiary for notifications and communication"`
+	Type                         string              `json:"type" schema:"required,enum=individual|business" description:"individual for persons or business for companies"`
+	NationalIdentificationNumber string              `json:"national_identification_number,omitempty" description:"National ID number, passport number, or other government-issued identification"`
+	TaxID                        string              `json:"tax_id,omitempty" description:"Tax identification number (TIN, SSN, VAT number, etc.) depending on jurisdiction"`
+	DestinationDetails           *DestinationDetails `json:"destination_details" schema:"required" description:"Payment destination, how the beneficiary receives funds"`
+	Phone                        *Phone              `json:"phone,omitempty" description:"Phone number of the beneficiary"`
+	Address                      *Address            `json:"address,omitempty" description:"Physical address of the beneficiary for compliance and verification"`
+	Documents                    []Documents         `json:"documents,omitempty" description:"Supporting documents for identity verification or compliance"`
+}
+
+// UpdateBeneficiaryRequest is the payload updating a beneficiary, with only
+// the fields to change
+type UpdateBeneficiaryRequest struct {
+	Name                         string              `json:"name,omitempty" description:"Full legal name of the beneficiary"`
+	Email                        string              `json:"email,omitempty" schema:"format=email" description:"Email address of the beneficiary"`
+	Type                         string              `json:"type,omitempty" schema:"enum=individual|business" description:"individual for persons or business for companies"`
+	NationalIdentificationNumber string              `json:"national_identification_number,omitempty" description:"National ID number, passport number, or other government-issued identification"`
+	TaxID                        string              `json:"tax_id,omitempty" description:"Tax identification number (TIN, SSN, VAT number, etc.)"`
+	DestinationDetails           *DestinationDetails `json:"destination_details,omitempty" description:"New payment destination"`
+	Phone                        *Phone              `json:"phone,omitempty" description:"Phone number of the beneficiary"`
+	Address                      *Address            `json:"address,omitempty" description:"Physical address of the beneficiary"`
+	Documents                    []Documents         `json:"documents,omitempty" description:"Supporting documents"`
+	Metadata                     map[string]any      `json:"metadata,omitempty" description:"Set of key-value pairs to attach to the beneficiary"`
+}
+
+// UpdateBeneficiaryParams are the arguments of the update beneficiary tool
+type UpdateBeneficiaryParams struct {
+	ID string `json:"id" schema:"required,prefix=bnf_" description:"ID of the existing beneficiary, e.g. bnf_..."`
+	UpdateBeneficiaryRequest
 }
 
 // DestinationDetails represents the details of a destination
 type DestinationDetails struct {
-	Bank                *Bank                `json:"bank,omitempty"`
-	Wallet              *Wallet              `json:"wallet,omitempty"`
-	LocalPaymentNetwork *LocalPaymentNetwork `json:"local_payment_network,omitempty"`
-	Type                string               `json:"type"`
+	Type                string               `json:"type" schema:"required,enum=bank|wallet|local_payment_network" description:"bank for bank accounts, wallet for digital wallets, local_payment_network for regional payment systems"`
+	Bank                *Bank                `json:"bank,omitempty" description:"Bank account details"`
+	Wallet              *Wallet              `json:"wallet,omitempty" description:"Digital wallet details"`
+	LocalPaymentNetwork *LocalPaymentNetwork `json:"local_payment_network,omitempty" description:"Regional payment network details, e.g. UPI, PIX or FPS"`
 }
 
 // Bank represents bank details
 type Bank struct {
-	BankCodes     BankCodes `json:"bank_codes,omitempty"`
-	AccountNumber string    `json:"account_number,omitempty"`
-	IBAN          string    `json:"iban,omitempty"`
-	BankName      string    `json:"bank_name,omitempty"`
-	BranchName    string    `json:"branch_name,omitempty"`
-	Country       string    `json:"country,omitempty"`
-	Currency      string    `json:"currency,omitempty"`
-	PurposeCode   string    `json:"purpose_code,omitempty"`
-	AccountType   string    `json:"account_type,omitempty"`
-	TransferType  string    `json:"transfer_type,omitempty"`
-	FIRCRequired  bool      `json:"firc_required,omitempty"`
+	AccountNumber string     `json:"account_number,omitempty" description:"Bank account number. Either account_number or iban is required."`
+	IBAN          string     `json:"iban,omitempty" description:"International Bank Account Number. Either account_number or iban is required."`
+	BankName      string     `json:"bank_name,omitempty" description:"Full official name of the bank"`
+	BranchName    string     `json:"branch_name,omitempty" description:"Name of the branch, if applicable"`
+	Country       string     `json:"country,omitempty" schema:"format=country" description:"Country of the bank, ISO 3166-1 alpha-2 code (e.g. US, GB, SG, IN, AU)"`
+	Currency      string     `json:"currency,omitempty" schema:"format=currency" description:"Currency the account receives, ISO 4217 code (e.g. USD, EUR, GBP, SGD, INR)"`
+	PurposeCode   string     `json:"purpose_code,omitempty" description:"Purpose code, required for INR bank accounts"`
+	AccountType   string     `json:"account_type,omitempty" description:"Type of bank account, e.g. checking, savings or business"`
+	TransferType  string     `json:"transfer_type,omitempty" schema:"enum=local|swift" description:"local for domestic clearing, swift for SWIFT wires"`
+	FIRCRequired  bool       `json:"firc_required,omitempty" description:"Set to true to get a Foreign Inward Remittance Certificate for a payout to India"`
+	BankCodes     *BankCodes `json:"bank_codes,omitempty" description:"Bank identification codes, e.g. {\"swift_code\":\"HSBCHKHHHKH\",\"ifsc_code\":\"HDFC0001234\"}. Codes passed directly on bank are moved here."`
 }
 
 // BankCodes holds various bank identification codes
 type BankCodes struct {
-	SwiftCode  string `json:"swift_code,omitempty"`
-	BICCode    string `json:"bic_code,omitempty"`
-	IFSCCode   string `json:"ifsc_code,omitempty"`
-	ABACode    string `json:"aba_code,omitempty"`
-	SortCode   string `json:"sort_code,omitempty"`
-	BranchCode string `json:"branch_code,omitempty"`
-	BSBCode    string `json:"bsb_code,omitempty"`
-	BankCode   string `json:"bank_code,omitempty"`
-	CNAPS      string `json:"cnaps,omitempty"`
+	SwiftCode  string `json:"swift_code,omitempty" description:"SWIFT/BIC code for international wires (8 or 11 characters)"`
+	BICCode    string `json:"bic_code,omitempty" description:"Bank Identifier Code, alternative to swift_code"`
+	IFSCCode   string `json:"ifsc_code,omitempty" description:"Indian Financial System Code, for banks in India"`
+	ABACode    string `json:"aba_code,omitempty" description:"ABA routing number, for banks in the US (9 digits)"`
+	SortCode   string `json:"sort_code,omitempty" description:"Sort code, for banks in the UK (6 digits)"`
+	BranchCode string `json:"branch_code,omitempty" description:"Branch identifier code"`
+	BSBCode    string `json:"bsb_code,omitempty" description:"Bank State Branch code, for banks in Australia"`
+	BankCode   string `json:"bank_code,omitempty" description:"National bank code, format varies by country"`
+	CNAPS      string `json:"cnaps,omitempty" description:"China National Advanced Payment System code, for banks in China"`
 }
 
 // Wallet represents wallet details
 type Wallet struct {
-	DepositAddress string `json:"deposit_address,omitempty"`
-	Type           string `json:"type,omitempty"`
-	Currency       string `json:"currency,omitempty"`
+	DepositAddress string `json:"deposit_address,omitempty" description:"Wallet address, account ID, or deposit identifier"`
+	Type           string `json:"type,omitempty" description:"Type of wallet or digital payment service"`
+	Currency       string `json:"currency,omitempty" schema:"format=currency" description:"Currency of the wallet, ISO 4217 code (e.g. USD, EUR)"`
 }
 
 // LocalPaymentNetwork represents local payment network details
 type LocalPaymentNetwork struct {
-	Type           string `json:"type,omitempty"`
-	DepositKeyType string `json:"deposit_key_type,omitempty"`
-	DepositKey     string `json:"deposit_key,omitempty"`
+	Type           string `json:"type,omitempty" description:"Name of the local payment network, e.g. UPI, PIX or FPS"`
+	DepositKeyType string `json:"deposit_key_type,omitempty" description:"Type of identifier used, e.g. mobile, email or account_id"`
+	DepositKey     string `json:"deposit_key,omitempty" description:"Identifier value, e.g. the phone number, email or account identifier"`
 }
 
 // Phone represents phone details
 type Phone struct {
-	Number      string `json:"number,omitempty"`
-	CallingCode string `json:"calling_code,omitempty"`
+	Number      string `json:"number,omitempty" description:"Phone number without calling code, e.g. 1234567890"`
+	CallingCode string `json:"calling_code,omitempty" description:"International calling code without the + sign, e.g. 1 for US, 44 for UK, 91 for India"`
 }
 
 // Address represents address details
 type Address struct {
-	Line1      string `json:"line1,omitempty"`
-	Line2      string `json:"line2,omitempty"`
+	Line1      string `json:"line1,omitempty" description:"Street number and street name"`
+	Line2      string `json:"line2,omitempty" description:"Apartment, suite or unit number"`
 	City       string `json:"city,omitempty"`
-	State      string `json:"state,omitempty"`
-	PostalCode string `json:"postal_code,omitempty"`
-	Country    string `json:"country,omitempty"`
+	State      string `json:"state,omitempty" description:"State, province or region"`
+	PostalCode string `json:"postal_code,omitempty" description:"ZIP code, postal code or equivalent"`
+	Country    string `json:"country,omitempty" schema:"format=country" description:"Country, ISO 3166-1 alpha-2 code (e.g. US, GB, SG, IN, AU)"`
 }
 
 // Documents represents documents details
 type Documents struct {
-	Type string `json:"type,omitempty"`
-	URL  string `json:"url,omitempty"`
+	Type string `json:"type,omitempty" description:"Type of document, e.g. invoice, passport, utility_bill or other"`
+	URL  string `json:"url,omitempty" schema:"format=uri" description:"Publicly downloadable URL of the document"`
 }
 
 // Beneficiary represents the full beneficiary object returned by the API
@@ -104,3 +125,8 @@ type Beneficiary struct {
 	Object                       string             `json:"object"`
 	Documents                    []Documents        `json:"documents"`
 }
+
+// GetBeneficiaryParams are the arguments of the get beneficiary tool
+type GetBeneficiaryParams struct {
+	ID string `json:"id" schema:"required,prefix=bnf_" description:"ID of the existing beneficiary, e.g. bnf_..."`
+}
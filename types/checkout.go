@@ -0,0 +1,6 @@
+package types
+
+// CheckoutParams are the arguments of the tools acting on a checkout session
+type CheckoutParams struct {
+	ID string `json:"id" schema:"required" description:"ID of the checkout session"`
+}
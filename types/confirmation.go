@@ -0,0 +1,183 @@
+package types
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+// MetaKeyNotExecuted is the result _meta key set when a tool call returned
+// without doing anything, e.g. because it is waiting for the user's confirmation
+const MetaKeyNotExecuted = "tazapay/notExecuted"
+
+// ConfirmationParams is embedded in the arguments of the tools asking for confirmation
+type ConfirmationParams struct {
+	ConfirmationToken string `json:"confirmation_token,omitempty" description:"Leave empty on the first call. Set it to the token returned by the first call only after the user has explicitly approved the payout summary."`
+}
+
+// Summarizer is implemented by money-moving tools to describe a call before it
+// runs. Summarize validates the arguments the way the handler does and fails on
+// a call that would be rejected, so nothing invalid is put up for confirmation.
+type Summarizer interface {
+	Summarize(ctx context.Context, args map[string]any) (PaymentSummary, error)
+}
+
+// PaymentSummary describes a payment for the user to confirm. Amounts are in cents.
+type PaymentSummary struct {
+	Action          string
+	Currency        string
+	BeneficiaryID   string
+	BeneficiaryName string
+	// Account is the masked destination account
+	Account         string
+	HoldingCurrency string
+	ChargeType      string
+	Fee             string
+	Amount          int64
+	HoldingAmount   int64
+	ExchangeRate    float64
+	// Breakdown replaces the amount and beneficiary lines for calls making
+	// several payouts, e.g. one line per currency
+	Breakdown []string
+}
+
+// String renders the summary as shown to the user
+func (s PaymentSummary) String() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "%s\n", s.Action)
+
+	if len(s.Breakdown) > 0 {
+		for _, line := range s.Breakdown {
+			fmt.Fprintf(&b, "- %s\n", line)
+		}
+
+		b.WriteString("Fees: as per your Tazapay pricing, shown on each payout once created")
+
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Amount: %.2f %s\n", float64(s.Amount)/100, s.Currency)
+
+	beneficiary := s.BeneficiaryName
+	if beneficiary == "" {
+		beneficiary = "unknown"
+	}
+
+	if s.BeneficiaryID != "" {
+		beneficiary += " (" + s.BeneficiaryID + ")"
+	}
+
+	fmt.Fprintf(&b, "Beneficiary: %s\n", beneficiary)
+
+	if s.Account != "" {
+		fmt.Fprintf(&b, "Account: %s\n", s.Account)
+	}
+
+	if s.HoldingCurrency != "" && s.HoldingCurrency != s.Currency {
+		if s.ExchangeRate > 0 {
+			fmt.Fprintf(&b, "FX: 1 %s = %.6g %s, about %.2f %s debited from your %s balance\n",
+				s.Currency, s.ExchangeRate, s.HoldingCurrency,
+				float64(s.HoldingAmount)/100, s.HoldingCurrency, s.HoldingCurrency)
+		} else {
+			fmt.Fprintf(&b, "FX: converted from your %s balance at the rate applied on execution\n", s.HoldingCurrency)
+		}
+	}
+
+	fee := s.Fee
+	if fee == "" {
+		fee = "as per your Tazapay pricing, shown on the payout once created"
+	}
+
+	if s.ChargeType != "" {
+		fee += " (charge type: " + s.ChargeType + ")"
+	}
+
+	fmt.Fprintf(&b, "Fees: %s", fee)
+
+	return b.String()
+}
+
+// NotExecuted marks a result as returned without executing the call, with a
+// NotExecutedOutput as structured content unless it has its own
+func NotExecuted(result *mcp.CallToolResult) *mcp.CallToolResult {
+	if result.Meta == nil {
+		result.Meta = &mcp.Meta{}
+	}
+
+	if result.Meta.AdditionalFields == nil {
+		result.Meta.AdditionalFields = make(map[string]any)
+	}
+
+	result.Meta.AdditionalFields[MetaKeyNotExecuted] = true
+
+	// tools declaring an output schema must return structured content
+	if result.StructuredContent == nil {
+		output := NotExecutedOutput{NotExecuted: true}
+		if len(result.Content) > 0 {
+			if text, ok := result.Content[0].(mcp.TextContent); ok {
+				output.Message = text.Text
+			}
+		}
+
+		result.StructuredContent = output
+	}
+
+	return result
+}
+
+// IsNotExecuted reports whether a result was marked with NotExecuted
+func IsNotExecuted(result *mcp.CallToolResult) bool {
+	if result == nil || result.Meta == nil {
+		return false
+	}
+
+	notExecuted, _ := result.Meta.AdditionalFields[MetaKeyNotExecuted].(bool)
+
+	return notExecuted
+}
+
+// Outcomes of a tool call, shared by the audit log and the metrics
+const (
+	OutcomeSuccess     = "success"
+	OutcomeError       = "error"
+	OutcomeDenied      = "denied"
+	OutcomeNotExecuted = "not_executed"
+)
+
+// Outcome classifies the result of a tool call
+func Outcome(result *mcp.CallToolResult, err error) string {
+	switch {
+	case err != nil:
+		return OutcomeError
+	case result == nil:
+		return OutcomeSuccess
+	case result.IsError:
+		if strings.HasPrefix(ResultText(result), constants.ErrPolicyDenied.Error()) {
+			return OutcomeDenied
+		}
+
+		return OutcomeError
+	case IsNotExecuted(result):
+		return OutcomeNotExecuted
+	default:
+		return OutcomeSuccess
+	}
+}
+
+// ResultText joins the text content of a tool result
+func ResultText(result *mcp.CallToolResult) string {
+	var b strings.Builder
+
+	for _, content := range result.Content {
+		if tc, ok := content.(mcp.TextContent); ok {
+			b.WriteString(tc.Text)
+		}
+	}
+
+	return b.String()
+}
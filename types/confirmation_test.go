@@ -0,0 +1,33 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/constants"
+)
+
+func TestOutcome(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *mcp.CallToolResult
+		err    error
+		want   string
+	}{
+		{"success", mcp.NewToolResultText("ok"), nil, OutcomeSuccess},
+		{"go error", nil, errors.New("boom"), OutcomeError},
+		{"error result", mcp.NewToolResultError("bad amount"), nil, OutcomeError},
+		{"policy denial", mcp.NewToolResultError(constants.ErrPolicyDenied.Error() + ": limit"), nil, OutcomeDenied},
+		{"dry run", NotExecuted(mcp.NewToolResultText("dry run")), nil, OutcomeNotExecuted},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := Outcome(test.result, test.err); got != test.want {
+				t.Errorf("Outcome() = %q; want %q", got, test.want)
+			}
+		})
+	}
+}
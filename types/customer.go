@@ -15,8 +15,27 @@ type Customer struct {
 	Shipping  []CustomerContact `json:"shipping,omitempty"`
 }
 
+// CustomerContact is a billing or shipping contact of a customer
 type CustomerContact struct {
-	Address *Address `json:"address,omitempty"`
-	Phone   *Phone   `json:"phone,omitempty"`
-	Name    string   `json:"name"`
+	Address *Address `json:"address,omitempty" description:"Address of the contact"`
+	Phone   *Phone   `json:"phone,omitempty" description:"Phone number of the contact"`
+	Name    string   `json:"name" description:"Name of the contact"`
+	Label   string   `json:"label,omitempty" description:"Type of address, e.g. home or work"`
+}
+
+// CreateCustomerParams are the arguments of the create customer tool
+type CreateCustomerParams struct {
+	Name        string            `json:"name" schema:"required" description:"Customer's name"`
+	Email       string            `json:"email" schema:"required,format=email" description:"Customer's email address"`
+	Country     string            `json:"country" schema:"required,format=country" description:"Customer's country, ISO 3166-1 alpha-2 code"`
+	ReferenceID string            `json:"reference_id,omitempty" description:"The unique reference_id on your system representing the customer"`
+	Phone       *Phone            `json:"phone,omitempty" description:"Customer's phone details"`
+	Billing     []CustomerContact `json:"billing,omitempty" description:"Customer's billing details"`
+	Shipping    []CustomerContact `json:"shipping,omitempty" description:"Customer's shipping details"`
+	Metadata    map[string]any    `json:"metadata,omitempty" description:"Set of key-value pairs to attach to the customer object"`
+}
+
+// GetCustomerParams are the arguments of the fetch customer tool
+type GetCustomerParams struct {
+	ID string `json:"id" schema:"required,prefix=cus_" description:"ID of the customer, e.g. cus_..."`
 }
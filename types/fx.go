@@ -1,8 +1,8 @@
 package types
 
-// FXParams represents the input fields extracted from MCP request
+// FXParams are the arguments of the FX tool
 type FXParams struct {
-	From   string
-	To     string
-	Amount float64
+	From   string  `json:"from" schema:"required,format=currency" description:"Currency to convert from, as a 3 letter ISO 4217 code, e.g. USD"`
+	To     string  `json:"to" schema:"required,format=currency" description:"Currency to convert to, as a 3 letter ISO 4217 code, e.g. INR"`
+	Amount float64 `json:"amount" schema:"required" description:"Amount to convert, in major units of the from currency, e.g. 100.50"`
 }
@@ -0,0 +1,266 @@
+package types
+
+import (
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/tazapay/tazapay-mcp-server/pkg/corridor"
+	"github.com/tazapay/tazapay-mcp-server/pkg/purpose"
+	"github.com/tazapay/tazapay-mcp-server/pkg/utils/money"
+	"github.com/tazapay/tazapay-mcp-server/pkg/wait"
+)
+
+// NewResult returns a result carrying output as structured content, with a
+// short summary and the output as JSON text for clients that only read text
+func NewResult(output any, summary string) (*mcp.CallToolResult, error) {
+	body, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{
+			mcp.NewTextContent(summary),
+			mcp.NewTextContent(string(body)),
+		},
+		StructuredContent: output,
+	}, nil
+}
+
+// NotExecutedOutput is the structured content of a result returned without
+// running the call
+type NotExecutedOutput struct {
+	NotExecuted bool   `json:"not_executed"`
+	Message     string `json:"message"`
+}
+
+// PayoutOutput is the structured output of the payout tools
+type PayoutOutput struct {
+	ID              string  `json:"id"`
+	Status          string  `json:"status"`
+	Amount          float64 `json:"amount" description:"Amount in major units, e.g. 10.50"`
+	Currency        string  `json:"currency"`
+	HoldingCurrency string  `json:"holding_currency,omitempty"`
+	Beneficiary     string  `json:"beneficiary,omitempty"`
+	Purpose         string  `json:"purpose,omitempty"`
+	ReferenceID     string  `json:"reference_id,omitempty"`
+	CreatedAt       string  `json:"created_at,omitempty"`
+	Warning         string  `json:"warning,omitempty" description:"Balance check warning"`
+	Object          Object  `json:"object"`
+}
+
+// PayinOutput is the structured output of the payin tools
+type PayinOutput struct {
+	ID          string  `json:"id"`
+	Status      string  `json:"status"`
+	Amount      float64 `json:"amount" description:"Amount in major units, e.g. 10.50"`
+	Currency    string  `json:"currency"`
+	Customer    string  `json:"customer,omitempty"`
+	ReferenceID string  `json:"reference_id,omitempty"`
+	CreatedAt   string  `json:"created_at,omitempty"`
+	Object      Object  `json:"object"`
+}
+
+// CheckoutOutput is the structured output of the checkout tools
+type CheckoutOutput struct {
+	ID            string  `json:"id"`
+	Status        string  `json:"status"`
+	PaymentStatus string  `json:"payment_status,omitempty"`
+	URL           string  `json:"url,omitempty" description:"Payment link to share with the customer"`
+	Amount        float64 `json:"amount" description:"Amount in major units, e.g. 10.50"`
+	Currency      string  `json:"currency"`
+	Customer      string  `json:"customer,omitempty"`
+	ReferenceID   string  `json:"reference_id,omitempty"`
+	ExpiresAt     string  `json:"expires_at,omitempty"`
+	Object        Object  `json:"object"`
+}
+
+// BeneficiaryOutput is the structured output of the beneficiary tools
+type BeneficiaryOutput struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Type        string `json:"type,omitempty"`
+	Email       string `json:"email,omitempty"`
+	Destination string `json:"destination,omitempty" description:"ID of the default destination"`
+	Object      Object `json:"object"`
+}
+
+// CustomerOutput is the structured output of the customer tools
+type CustomerOutput struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Email   string `json:"email,omitempty"`
+	Country string `json:"country,omitempty"`
+	Object  Object `json:"object"`
+}
+
+// PaymentAttemptOutput is the structured output of the payment attempt tools
+type PaymentAttemptOutput struct {
+	ID       string  `json:"id"`
+	Status   string  `json:"status"`
+	Payin    string  `json:"payin,omitempty"`
+	Amount   float64 `json:"amount" description:"Amount in major units, e.g. 10.50"`
+	Currency string  `json:"currency,omitempty"`
+	Object   Object  `json:"object"`
+}
+
+// BalanceOutput is the structured output of the balance tool
+type BalanceOutput struct {
+	UpdatedAt string          `json:"updated_at,omitempty"`
+	Balances  []BalanceAmount `json:"balances"`
+}
+
+// BalanceAmount is the available balance in one currency
+type BalanceAmount struct {
+	Currency string  `json:"currency"`
+	Amount   float64 `json:"amount" description:"Amount in major units, e.g. 10.50"`
+}
+
+// FXOutput is the structured output of the FX tool
+type FXOutput struct {
+	From            string  `json:"from"`
+	To              string  `json:"to"`
+	Amount          float64 `json:"amount" description:"Amount in the from currency, in major units"`
+	ExchangeRate    float64 `json:"exchange_rate"`
+	ConvertedAmount float64 `json:"converted_amount" description:"Amount in the to currency, in major units"`
+}
+
+// RequirementsOutput is the structured output of the describe requirements tool
+type RequirementsOutput struct {
+	Country      string          `json:"country"`
+	Currency     string          `json:"currency"`
+	Requirements []corridor.Rule `json:"requirements"`
+	Notes        string          `json:"notes"`
+}
+
+// CodesOutput is the structured output of the lookup codes tool
+type CodesOutput struct {
+	Countries  []CountryCode  `json:"countries,omitempty"`
+	Currencies []CurrencyCode `json:"currencies,omitempty"`
+}
+
+// CountryCode is an ISO 3166 country
+type CountryCode struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+}
+
+// CurrencyCode is an ISO 4217 currency
+type CurrencyCode struct {
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	MinorUnits int    `json:"minor_units"`
+}
+
+// PurposeCodesOutput is the structured output of the recommend purpose code
+// tool. Without a description it lists every code accepted for the currency.
+type PurposeCodesOutput struct {
+	Recommended     string                   `json:"recommended,omitempty"`
+	Recommendations []purpose.Recommendation `json:"recommendations,omitempty"`
+	PurposeCodes    []purpose.Recommendation `json:"purpose_codes,omitempty"`
+	Note            string                   `json:"note,omitempty"`
+}
+
+// WaitOutput is the structured output of the wait for status tool
+type WaitOutput struct {
+	ID      string   `json:"id"`
+	Targets []string `json:"target_statuses"`
+	wait.Result
+}
+
+// Object is a Tazapay object as returned by the API, amounts in cents
+type Object map[string]any
+
+// String returns a string field of the object
+func (o Object) String(key string) string {
+	s, _ := o[key].(string)
+	return s
+}
+
+// Amount returns an amount field of the object in major units
+func (o Object) Amount(key string) float64 {
+	cents, _ := o[key].(float64)
+	return money.Int64ToDecimal2(int64(cents))
+}
+
+// NewPayoutOutput describes a payout object
+func NewPayoutOutput(o Object) PayoutOutput {
+	return PayoutOutput{
+		ID:              o.String("id"),
+		Status:          o.String("status"),
+		Amount:          o.Amount("amount"),
+		Currency:        o.String("currency"),
+		HoldingCurrency: o.String("holding_currency"),
+		Beneficiary:     o.String("beneficiary"),
+		Purpose:         o.String("purpose"),
+		ReferenceID:     o.String("reference_id"),
+		CreatedAt:       o.String("created_at"),
+		Object:          o,
+	}
+}
+
+// NewPayinOutput describes a payin object
+func NewPayinOutput(o Object) PayinOutput {
+	return PayinOutput{
+		ID:          o.String("id"),
+		Status:      o.String("status"),
+		Amount:      o.Amount("amount"),
+		Currency:    o.String("invoice_currency"),
+		Customer:    o.String("customer"),
+		ReferenceID: o.String("reference_id"),
+		CreatedAt:   o.String("created_at"),
+		Object:      o,
+	}
+}
+
+// NewCheckoutOutput describes a checkout object
+func NewCheckoutOutput(o Object) CheckoutOutput {
+	return CheckoutOutput{
+		ID:            o.String("id"),
+		Status:        o.String("status"),
+		PaymentStatus: o.String("payment_status"),
+		URL:           o.String("url"),
+		Amount:        o.Amount("amount"),
+		Currency:      o.String("invoice_currency"),
+		Customer:      o.String("customer"),
+		ReferenceID:   o.String("reference_id"),
+		ExpiresAt:     o.String("expires_at"),
+		Object:        o,
+	}
+}
+
+// NewBeneficiaryOutput describes a beneficiary object
+func NewBeneficiaryOutput(o Object) BeneficiaryOutput {
+	return BeneficiaryOutput{
+		ID:          o.String("id"),
+		Name:        o.String("name"),
+		Type:        o.String("type"),
+		Email:       o.String("email"),
+		Destination: o.String("destination"),
+		Object:      o,
+	}
+}
+
+// NewCustomerOutput describes a customer object
+func NewCustomerOutput(o Object) CustomerOutput {
+	return CustomerOutput{
+		ID:      o.String("id"),
+		Name:    o.String("name"),
+		Email:   o.String("email"),
+		Country: o.String("country"),
+		Object:  o,
+	}
+}
+
+// NewPaymentAttemptOutput describes a payment attempt object
+func NewPaymentAttemptOutput(o Object) PaymentAttemptOutput {
+	return PaymentAttemptOutput{
+		ID:       o.String("id"),
+		Status:   o.String("status"),
+		Payin:    o.String("payin"),
+		Amount:   o.Amount("amount"),
+		Currency: o.String("currency"),
+		Object:   o,
+	}
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestOutputs(t *testing.T) {
+	payout := Object{
+		"id": "pot_d1i3e6l8fc3t2rdrf5s0", "object": "payout", "status": "succeeded", "amount": float64(125050),
+		"currency": "INR", "holding_currency": "USD", "beneficiary": "bnf_d1i3e3d8fc3t2rdrf4u0",
+		"purpose": "PYR028", "reference_id": "inv-1042", "created_at": "2026-05-04T09:12:45Z",
+	}
+	payin := Object{
+		"id": "chk_d0v1nsh8fc3sbq6vh7tg", "object": "payin", "status": "requires_payment_method",
+		"amount": float64(4999), "invoice_currency": "SGD", "customer": "cus_d0v1ns98fc3sbq6vh7s0",
+		"reference_id": "order-77", "created_at": "2026-05-04T10:00:00Z",
+	}
+	checkout := Object{
+		"id": "chk_d0v2ko18fc3sbq6vh8dg", "object": "checkout", "status": "active", "payment_status": "unpaid",
+		"url": "https://checkout.tazapay.com/chk_d0v2ko18fc3sbq6vh8dg", "amount": float64(10000),
+		"invoice_currency": "USD", "customer": "cus_d0v1ns98fc3sbq6vh7s0", "expires_at": "2026-05-05T10:00:00Z",
+	}
+	beneficiary := Object{
+		"id": "bnf_d1i3e3d8fc3t2rdrf4u0", "object": "beneficiary", "name": "Acme Supplies Pvt Ltd",
+		"type": "business", "email": "billing@acme.example", "destination": "bnk_d1i3e3d8fc3t2rdrf4ug",
+	}
+	customer := Object{
+		"id": "cus_d0v1ns98fc3sbq6vh7s0", "object": "customer", "name": "Jane Tan",
+		"email": "jane@example.com", "country": "SG",
+	}
+	attempt := Object{
+		"id": "pat_d0v1ntp8fc3sbq6vh7u0", "object": "payment_attempt", "status": "failed",
+		"payin": "chk_d0v1nsh8fc3sbq6vh7tg", "amount": float64(4999), "currency": "SGD",
+	}
+
+	vendor := VendorPaymentOutput{Outcome: "funded", BeneficiaryID: "bnf_d1i3e3d8fc3t2rdrf4u0",
+		PayoutID: "pot_d1i3e6l8fc3t2rdrf5s0", PayoutStatus: "succeeded"}
+	vendor.Add("create_beneficiary", "succeeded", "bnf_d1i3e3d8fc3t2rdrf4u0", "")
+	vendor.Add("create_payout", "succeeded", "pot_d1i3e6l8fc3t2rdrf5s0", "")
+	vendor.Add("fund_payout", "succeeded", "pot_d1i3e6l8fc3t2rdrf5s0", "")
+
+	tests := []struct {
+		name   string
+		output any
+	}{
+		{"payout", NewPayoutOutput(payout)},
+		{"payin", NewPayinOutput(payin)},
+		{"checkout", NewCheckoutOutput(checkout)},
+		{"beneficiary", NewBeneficiaryOutput(beneficiary)},
+		{"customer", NewCustomerOutput(customer)},
+		{"payment_attempt", NewPaymentAttemptOutput(attempt)},
+		{"balance", BalanceOutput{UpdatedAt: "2026-05-04T09:00:00Z", Balances: []BalanceAmount{
+			{Currency: "SGD", Amount: 1520.5}, {Currency: "USD", Amount: 88},
+		}}},
+		{"vendor_payment", vendor},
+		{"batch_payout", BatchPayoutOutput{
+			BatchID: "b7f9", Created: 1, Failed: 1, ResultsURI: "tazapay://batch/b7f9/results",
+			Totals: []BatchTotal{{Currency: "INR", Count: 2, Amount: 250000}},
+			Results: []BatchRowResult{
+				{Row: 1, Currency: "INR", Amount: "1250.00", Status: "created", PayoutID: "pot_d1i3e6l8fc3t2rdrf5s0"},
+				{Row: 2, Currency: "INR", Amount: "1250.00", Status: "failed", Error: "missing purpose"},
+			},
+		}},
+		{"not_executed", NotExecuted(mcp.NewToolResultText("Dry run: no payout was created.")).StructuredContent},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.MarshalIndent(test.output, "", "  ")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			path := filepath.Join("testdata", test.name+".golden")
+			if *update {
+				if err := os.WriteFile(path, append(got, '\n'), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			want, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("%v; run go test -update to create it", err)
+			}
+
+			if !bytes.Equal(bytes.TrimSpace(want), got) {
+				t.Errorf("output changed, run go test -update if intended\ngot:\n%s\nwant:\n%s", got, want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package types
+
+// GetPayinParams are the arguments of the get payin tool
+type GetPayinParams struct {
+	ID string `json:"id" schema:"required,prefix=pay_" description:"ID of the already created payin, e.g. pay_..."`
+}
+
+// CancelPayinParams are the arguments of the cancel payin tool
+type CancelPayinParams struct {
+	ID string `json:"id" schema:"required" description:"ID of the already created payin to cancel"`
+}
+
+// GetPaymentAttemptParams are the arguments of the get payment attempt tool
+type GetPaymentAttemptParams struct {
+	ID string `json:"id" schema:"required,prefix=pat_" description:"ID of the payment attempt, e.g. pat_..."`
+}
+
+// CustomerDetails describes the customer paying a payin
+type CustomerDetails struct {
+	Name    string `json:"name,omitempty" description:"Name of the customer"`
+	Email   string `json:"email,omitempty" schema:"format=email" description:"Email address of the customer"`
+	Country string `json:"country,omitempty" schema:"format=country" description:"Country of the customer, ISO 3166-1 alpha-2 code, e.g. SG, IN, US"`
+	Phone   *Phone `json:"phone,omitempty" description:"Phone number of the customer"`
+}
+
+// CreatePayinParams are the arguments of the create payin tool, amount in major units
+type CreatePayinParams struct {
+	InvoiceCurrency        string           `json:"invoice_currency" schema:"required,format=currency" description:"Currency in which the invoice is to be raised, ISO 4217 code, e.g. USD, EUR"`
+	Amount                 float64          `json:"amount" schema:"required" description:"Amount in major units of the invoice currency, e.g. 49.99"`
+	TransactionDescription string           `json:"transaction_description" schema:"required" description:"Short description of the payment"`
+	CustomerDetails        *CustomerDetails `json:"customer_details" schema:"required" description:"Customer paying the payin"`
+	Customer               string           `json:"customer,omitempty" schema:"prefix=cus_" description:"ID of an existing customer, e.g. cus_..."`
+	SuccessURL             string           `json:"success_url" schema:"required,format=uri" description:"URL the customer is sent to after paying"`
+	CancelURL              string           `json:"cancel_url" schema:"required,format=uri" description:"URL the customer is sent to after cancelling"`
+	WebhookURL             string           `json:"webhook_url,omitempty" schema:"format=uri" description:"URL receiving the payin events. Defaults to this server's webhook receiver when it has one."`
+	ShippingDetails        *CustomerContact `json:"shipping_details,omitempty" description:"Shipping contact"`
+	BillingDetails         *CustomerContact `json:"billing_details,omitempty" description:"Billing contact"`
+	TransactionDocuments   []Documents      `json:"transaction_documents,omitempty" description:"Documents supporting the payment, e.g. the invoice"`
+	Metadata               map[string]any   `json:"metadata,omitempty" description:"Set of key-value pairs to attach to the payin"`
+	ReferenceID            string           `json:"reference_id,omitempty" description:"Reference ID of the payin on your system"`
+	StatementDescriptor    string           `json:"statement_descriptor,omitempty" description:"Statement descriptor of the payin"`
+	Confirm                bool             `json:"confirm,omitempty" description:"Confirm the payin on creation, with payment_method_details"`
+	PaymentMethodDetails   map[string]any   `json:"payment_method_details,omitempty" description:"Payment method of the payin, as described by the Tazapay API"`
+	SessionID              string           `json:"session_id,omitempty" description:"Session ID of the customer's device"`
+}
+
+// PayinFields are the payin fields set when updating or confirming a payin
+type PayinFields struct {
+	SuccessURL           string           `json:"success_url" schema:"required,format=uri" description:"URL the customer is sent to after paying"`
+	CancelURL            string           `json:"cancel_url" schema:"required,format=uri" description:"URL the customer is sent to after cancelling"`
+	PaymentMethodDetails map[string]any   `json:"payment_method_details" schema:"required" description:"Payment method of the payin, as described by the Tazapay API"`
+	ShippingDetails      *CustomerContact `json:"shipping_details,omitempty" description:"Shipping contact"`
+	BillingDetails       *CustomerContact `json:"billing_details,omitempty" description:"Billing contact"`
+	TransactionDocuments []Documents      `json:"transaction_documents,omitempty" description:"Documents supporting the payment, e.g. the invoice"`
+	Metadata             map[string]any   `json:"metadata,omitempty" description:"Set of key-value pairs to attach to the payin"`
+	ReferenceID          string           `json:"reference_id,omitempty" description:"Reference ID of the payin on your system"`
+	StatementDescriptor  string           `json:"statement_descriptor,omitempty" description:"Statement descriptor of the payin"`
+}
+
+// UpdatePayinRequest is the payload updating a payin
+type UpdatePayinRequest struct {
+	CustomerDetails *CustomerDetails `json:"customer_details,omitempty" description:"Customer paying the payin"`
+	PayinFields
+}
+
+// UpdatePayinParams are the arguments of the update payin tool
+type UpdatePayinParams struct {
+	ID string `json:"id" schema:"required" description:"ID of the already created payin"`
+	UpdatePayinRequest
+}
+
+// ConfirmPayinRequest is the payload confirming a payin
+type ConfirmPayinRequest struct {
+	CustomerDetails *CustomerDetails `json:"customer_details" schema:"required" description:"Customer paying the payin"`
+	SessionID       string           `json:"session_id" schema:"required" description:"Session ID of the customer's device"`
+	PayinFields
+}
+
+// ConfirmPayinParams are the arguments of the confirm payin tool
+type ConfirmPayinParams struct {
+	ID string `json:"id" schema:"required" description:"ID of the already created payin"`
+	ConfirmPayinRequest
+}
@@ -1,13 +1,13 @@
 package types
 
-// PaymentLinkParams represents the input fields extracted from MCP request
+// PaymentLinkParams are the arguments of the payment link tool
 type PaymentLinkParams struct {
-	InvoiceCurrency string
-	Description     string
-	CustomerName    string
-	CustomerEmail   string
-	CustomerCountry string
-	PaymentAmount   float64
+	InvoiceCurrency string  `json:"invoice_currency" schema:"required,format=currency" description:"Currency in which the invoice is to be raised, as a 3 letter ISO 4217 code, e.g. USD, EUR"`
+	PaymentAmount   float64 `json:"payment_amount" schema:"required" description:"Total invoice amount to be paid, in major units, e.g. 49.99"`
+	CustomerName    string  `json:"customer_name" schema:"required" description:"Full name of the customer"`
+	CustomerEmail   string  `json:"customer_email" schema:"required,format=email" description:"Email address of the customer"`
+	CustomerCountry string  `json:"customer_country" schema:"required,format=country" description:"Country of the customer, as an ISO 3166 alpha-2 code, e.g. SG, IN, US"`
+	Description     string  `json:"transaction_description" schema:"required" description:"Short description or purpose of the transaction"`
 }
 
 // PaymentLinkRequest defines the payload sent to the internal API
@@ -1,30 +1,96 @@
 package types
 
-// PayoutRequest represents the payload for creating a payout
-// Reuses beneficiary types for nested fields
-// Add/modify fields as needed for your payout API
-
+// PayoutRequest represents the payload for creating a payout, amount in cents.
+// beneficiary_details is only sent when no beneficiary ID is given.
 type PayoutRequest struct {
 	LogisticsTrackingDetails *LogisticsTrackingDetails `json:"logistics_tracking_details,omitempty"`
-	StatementDescription     string                    `json:"statement_description,omitempty"`
+	BeneficiaryDetails       *CreateBeneficiaryRequest `json:"beneficiary_details,omitempty"`
+	Metadata                 map[string]any            `json:"metadata,omitempty"`
+	StatementDescriptor      string                    `json:"statement_descriptor,omitempty"`
 	TransactionDescription   string                    `json:"transaction_description,omitempty"`
 	ReferenceID              string                    `json:"reference_id"`
-	Beneficiary              string                    `json:"beneficiary"`
+	Beneficiary              string                    `json:"beneficiary,omitempty"`
 	Purpose                  string                    `json:"purpose"`
 	ChargeType               string                    `json:"charge_type,omitempty"`
 	Type                     string                    `json:"type,omitempty"`
 	HoldingCurrency          string                    `json:"holding_currency,omitempty"`
 	OnBehalfOf               string                    `json:"on_behalf_of,omitempty"`
-	Metadata                 string                    `json:"metadata,omitempty"`
 	Currency                 string                    `json:"currency"`
-	BeneficiaryDetails       Beneficiary               `json:"beneficiary_details"`
+	Documents                []Documents               `json:"documents,omitempty"`
 	Amount                   int64                     `json:"amount"`
 }
 
+// CreatePayoutParams are the arguments of the create payout tool, amount in
+// major units. Exactly one of beneficiary and beneficiary_details is given.
+type CreatePayoutParams struct {
+	ConfirmationParams
+
+	Amount                   float64                   `json:"amount" schema:"required,positive" description:"Amount in major units of the payout currency, e.g. 10.12 for $10.12"`
+	Currency                 string                    `json:"currency" schema:"required,format=currency" description:"Payout currency, ISO 4217 code"`
+	Purpose                  string                    `json:"purpose" schema:"required" description:"Payout purpose code, PYR001 to PYR028. Call the recommend purpose code tool to pick the code fitting the payment instead of guessing."`
+	TransactionDescription   string                    `json:"transaction_description" schema:"required" description:"Additional details of the payout"`
+	ReferenceID              string                    `json:"reference_id,omitempty" description:"Reference ID of the payout on your system"`
+	StatementDescriptor      string                    `json:"statement_descriptor,omitempty" description:"Statement descriptor of the payout"`
+	ChargeType               string                    `json:"charge_type,omitempty" schema:"enum=shared|ours" description:"Who bears the charges, for wire transfers only"`
+	Type                     string                    `json:"type,omitempty" schema:"enum=local|swift|wallet" description:"Payout rail. Bank details are checked against the requirements of this rail, inferred from the bank codes when omitted."`
+	HoldingCurrency          string                    `json:"holding_currency,omitempty" schema:"format=currency" description:"Balance currency funding the payout, ISO 4217 code. Defaults to the payout currency."`
+	OnBehalfOf               string                    `json:"on_behalf_of,omitempty" description:"ID of the entity the payout is created on behalf of"`
+	Metadata                 map[string]any            `json:"metadata,omitempty" description:"Set of key-value pairs to attach to the payout"`
+	Beneficiary              string                    `json:"beneficiary,omitempty" schema:"prefix=bnf_" description:"ID of an existing beneficiary"`
+	BeneficiaryDetails       *CreateBeneficiaryRequest `json:"beneficiary_details,omitempty" description:"Beneficiary to pay, when no beneficiary ID is given"`
+	Documents                []Documents               `json:"documents,omitempty" description:"Documents attached to the payout"`
+	LogisticsTrackingDetails *LogisticsTrackingDetails `json:"logistics_tracking_details,omitempty" description:"Shipment of the goods paid for"`
+}
+
+// PayVendorParams are the arguments of the pay vendor tool. A resumed payment
+// only needs payout_id.
+type PayVendorParams struct {
+	CreatePayoutParams
+
+	PayoutID       string `json:"payout_id,omitempty" schema:"prefix=pot_" description:"ID of a payout created by an earlier call, to resume funding it. The other payout arguments are then ignored."`
+	IdempotencyKey string `json:"idempotency_key,omitempty" description:"Key making payout creation safe to retry. Defaults to a hash of the payout arguments, and a call repeating a key used within the last hour resumes that payout. Set a distinct reference_id or key to pay the same vendor the same amount again."`
+}
+
+// FundPayoutParams are the arguments of the fund payout tool
+type FundPayoutParams struct {
+	ConfirmationParams
+
+	ID string `json:"id" schema:"required,prefix=pot_" description:"ID of the payout in requires_funding state"`
+}
+
 // LogisticsTrackingDetails represents logistics tracking info for a payout
-// (Add/modify fields as needed)
 type LogisticsTrackingDetails struct {
 	LogisticsProviderName string `json:"logistics_provider_name,omitempty"`
 	LogisticsProviderCode string `json:"logistics_provider_code,omitempty"`
 	TrackingNumber        string `json:"tracking_number,omitempty"`
 }
+
+// VendorPaymentStep reports one step of a vendor payment
+type VendorPaymentStep struct {
+	Step   string `json:"step"`
+	Status string `json:"status"`
+	ID     string `json:"id,omitempty"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// VendorPaymentOutput is the structured output of the pay vendor tool
+type VendorPaymentOutput struct {
+	Outcome       string `json:"outcome" schema:"enum=funded|awaiting_funds|incomplete"`
+	BeneficiaryID string `json:"beneficiary_id,omitempty"`
+	PayoutID      string `json:"payout_id,omitempty"`
+	PayoutStatus  string `json:"payout_status,omitempty"`
+	// IdempotencyKey is echoed so the caller can tell a resumed payout from a new one
+	IdempotencyKey string              `json:"idempotency_key,omitempty" description:"Key the payout was created with. Pass a different idempotency_key to pay the same vendor the same amount again."`
+	Steps          []VendorPaymentStep `json:"steps"`
+	Resume         string              `json:"resume,omitempty" description:"How to resume an unfinished payment"`
+}
+
+// Add appends a step to the report
+func (p *VendorPaymentOutput) Add(name, status, id, detail string) {
+	p.Steps = append(p.Steps, VendorPaymentStep{Step: name, Status: status, ID: id, Detail: detail})
+}
+
+// GetPayoutParams are the arguments of the get payout tool
+type GetPayoutParams struct {
+	ID string `json:"id" schema:"required,prefix=pot_" description:"ID of the existing payout, e.g. pot_..."`
+}
@@ -0,0 +1,16 @@
+package types
+
+import (
+	"context"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Prompt defines an interface that all prompts must implement
+type Prompt interface {
+	// Definition returns the prompt definition
+	Definition() mcp.Prompt
+
+	// Handle renders the prompt for the given arguments
+	Handle(ctx context.Context, req mcp.GetPromptRequest) (*mcp.GetPromptResult, error)
+}
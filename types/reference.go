@@ -0,0 +1,19 @@
+package types
+
+// RequirementsParams are the arguments of the describe requirements tool
+type RequirementsParams struct {
+	Country      string `json:"country" schema:"required,format=country" description:"Destination bank country, ISO 3166-1 alpha-2 code (e.g. IN, US, GB)"`
+	Currency     string `json:"currency" schema:"required,format=currency" description:"Currency the beneficiary receives, ISO 4217 code (e.g. INR, USD, GBP)"`
+	TransferType string `json:"transfer_type,omitempty" schema:"enum=local|swift" description:"local for domestic clearing, swift for SWIFT wires. Both are described when omitted. A transfer is treated as SWIFT when the bank details carry a SWIFT/BIC code and no domestic bank code."`
+}
+
+// LookupCodesParams are the arguments of the lookup codes tool
+type LookupCodesParams struct {
+	Query string `json:"query" schema:"required" description:"Code or name, or words of the name, of a country or currency"`
+	Kind  string `json:"kind,omitempty" schema:"enum=country|currency" description:"Only look up countries or only currencies. Both are looked up when omitted."`
+}
+
+// PurposeCodeParams are the arguments of the recommend purpose code tool
+type PurposeCodeParams struct {
+	Description string `json:"description,omitempty" description:"What the payout is for, in a few words"`
+}
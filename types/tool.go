@@ -5,13 +5,88 @@ import (
 	"context"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
+// MetaKeyMovesFunds is the tool _meta key carrying the MovesFunds flag
+const MetaKeyMovesFunds = "tazapay/movesFunds"
+
 // Tool defines an interface that all tools must implement
 type Tool interface {
 	// Definition returns the tool definition
 	Definition() mcp.Tool
 
+	// Metadata describes the side effects of the tool
+	Metadata() ToolMetadata
+
 	// Handle processes the tool call
 	Handle(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
 }
+
+// Resumer is implemented by tools that may fund an existing payout instead of
+// creating one, e.g. on a retry. Middlewares counting amounts use it so that a
+// resumed payment is not counted twice.
+type Resumer interface {
+	// ResumedPayout returns the ID of the payout the call would resume, or ""
+	ResumedPayout(ctx context.Context, args map[string]any) string
+}
+
+// ToolHandlerFunc handles a tool call
+type ToolHandlerFunc func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error)
+
+// ToolMiddleware wraps the handler of a tool with cross-cutting behaviour such as
+// policy checks. It receives the tool so it can key off its name and metadata.
+type ToolMiddleware func(tool Tool, next ToolHandlerFunc) ToolHandlerFunc
+
+// ToolMetadata describes what a tool does to the Tazapay account.
+// It is published to clients as MCP tool annotations and is used by
+// server side policies to decide which tools may be exposed or called.
+type ToolMetadata struct {
+	// ReadOnly tools never modify any Tazapay object
+	ReadOnly bool
+	// Destructive tools may overwrite, cancel or irreversibly act on existing objects
+	Destructive bool
+	// Idempotent tools have no additional effect when called again with the same arguments
+	Idempotent bool
+	// OpenWorld tools talk to systems outside the server (the Tazapay API)
+	OpenWorld bool
+	// MovesFunds tools send money out of the account
+	MovesFunds bool
+}
+
+// Annotation converts the metadata to MCP tool annotations, keeping the given title
+func (m ToolMetadata) Annotation(title string) mcp.ToolAnnotation {
+	return mcp.ToolAnnotation{
+		Title:           title,
+		ReadOnlyHint:    mcp.ToBoolPtr(m.ReadOnly),
+		DestructiveHint: mcp.ToBoolPtr(m.Destructive),
+		IdempotentHint:  mcp.ToBoolPtr(m.Idempotent),
+		OpenWorldHint:   mcp.ToBoolPtr(m.OpenWorld),
+	}
+}
+
+// Apply sets the annotations and the moves funds _meta flag on a tool definition
+func (m ToolMetadata) Apply(def mcp.Tool) mcp.Tool {
+	def.Annotations = m.Annotation(def.Annotations.Title)
+
+	if def.Meta == nil {
+		def.Meta = &mcp.Meta{}
+	}
+
+	if def.Meta.AdditionalFields == nil {
+		def.Meta.AdditionalFields = make(map[string]any)
+	}
+
+	def.Meta.AdditionalFields[MetaKeyMovesFunds] = m.MovesFunds
+
+	return def
+}
+
+// SessionID returns the MCP session ID of the request, if any
+func SessionID(ctx context.Context) string {
+	if session := server.ClientSessionFromContext(ctx); session != nil {
+		return session.SessionID()
+	}
+
+	return ""
+}
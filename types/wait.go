@@ -0,0 +1,8 @@
+package types
+
+// WaitParams are the arguments of the wait for status tool
+type WaitParams struct {
+	ID             string   `json:"id" schema:"required" description:"ID of the payout, payin or checkout to wait on"`
+	TargetStatuses []string `json:"target_statuses,omitempty" description:"Statuses to wait for. Defaults to the final statuses of the object: succeeded, failed, reversed, rejected or cancelled for payouts; succeeded, cancelled or expired for payins; paid, expired or cancelled for checkouts. The status of an active checkout is its payment_status."`
+	TimeoutSeconds int      `json:"timeout_seconds,omitempty" description:"How long to wait before giving up (default 300, at most 1800)"`
+}